@@ -0,0 +1,386 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/format"
+)
+
+// Kinds of Change.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Change is a difference between two versions of a specification. Path
+// names the element, e.g. `type User.name` or `interface Store.get(id)`,
+// and Old and New are its declarations.
+//
+//tinyjson:json
+type Change struct {
+	Kind     string `json:"kind"`
+	Path     string `json:"path"`
+	Old      string `json:"old,omitempty"`
+	New      string `json:"new,omitempty"`
+	Breaking bool   `json:"breaking"`
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return "+ " + c.Path + ": " + c.New
+	case ChangeRemoved:
+		return "- " + c.Path + ": " + c.Old
+	}
+	return "~ " + c.Path + ": " + c.Old + " -> " + c.New
+}
+
+// DiffReport is the result of Diff.
+//
+//tinyjson:json
+type DiffReport struct {
+	Changes []Change `json:"changes"`
+}
+
+// Breaking reports whether any change in r is breaking.
+func (r *DiffReport) Breaking() bool {
+	for _, c := range r.Changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteText writes a human readable form of the report to w.
+func (r *DiffReport) WriteText(w io.Writer) error {
+	var b strings.Builder
+	for _, c := range r.Changes {
+		b.WriteString(c.String())
+		if c.Breaking {
+			b.WriteString(" (breaking)")
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Diff compares the definitions of two versions of a specification.
+// Removing or changing an element is breaking, as is adding a required
+// field or parameter. Descriptions and annotations are not compared.
+func Diff(from, to *ast.Document) *DiffReport {
+	d := differ{changes: []Change{}}
+	d.namespaces(namespaceOf(from), namespaceOf(to))
+
+	before, after := definitionsOf(from), definitionsOf(to)
+	for _, key := range before.keys {
+		def := before.defs[key]
+		other, ok := after.defs[key]
+		if !ok {
+			d.add(ChangeRemoved, key, declaration(def), "", true)
+			continue
+		}
+		d.definition(key, def, other)
+	}
+	for _, key := range after.keys {
+		if _, ok := before.defs[key]; !ok {
+			d.add(ChangeAdded, key, "", declaration(after.defs[key]), false)
+		}
+	}
+
+	return &DiffReport{Changes: d.changes}
+}
+
+type differ struct {
+	changes []Change
+}
+
+func (d *differ) add(kind, path, from, to string, breaking bool) {
+	d.changes = append(d.changes, Change{
+		Kind:     kind,
+		Path:     path,
+		Old:      from,
+		New:      to,
+		Breaking: breaking,
+	})
+}
+
+func (d *differ) compare(path, from, to string) {
+	if from != to {
+		d.add(ChangeChanged, path, from, to, true)
+	}
+}
+
+func (d *differ) namespaces(from, to *ast.NamespaceDefinition) {
+	switch {
+	case from == nil && to == nil:
+	case from == nil:
+		d.add(ChangeAdded, "namespace", "", to.Name.Value, false)
+	case to == nil:
+		d.add(ChangeRemoved, "namespace", from.Name.Value, "", true)
+	default:
+		d.compare("namespace", from.Name.Value, to.Name.Value)
+	}
+}
+
+func (d *differ) definition(path string, from, to ast.Node) {
+	switch v := from.(type) {
+	case *ast.TypeDefinition:
+		other := to.(*ast.TypeDefinition)
+		d.members(path+".", valuedMembers(fields(v.Fields)), valuedMembers(fields(other.Fields)))
+	case *ast.InterfaceDefinition:
+		d.operations(path+".", v.Operations, to.(*ast.InterfaceDefinition).Operations)
+	case *ast.OperationDefinition:
+		d.operation(path, v, to.(*ast.OperationDefinition))
+	case *ast.AliasDefinition:
+		d.compare(path, format.Type(v.Type), format.Type(to.(*ast.AliasDefinition).Type))
+	case *ast.UnionDefinition:
+		other := to.(*ast.UnionDefinition)
+		d.members(path+"|", unionMembers(v.Members), unionMembers(other.Members))
+	case *ast.EnumDefinition:
+		other := to.(*ast.EnumDefinition)
+		d.members(path+".", enumMembers(v.Values), enumMembers(other.Values))
+	case *ast.DirectiveDefinition:
+		d.compare(path, declaration(v), declaration(to))
+	}
+}
+
+func (d *differ) operations(prefix string, from, to []*ast.OperationDefinition) {
+	after := make(map[string]*ast.OperationDefinition, len(to))
+	for _, oper := range to {
+		after[oper.Name.Value] = oper
+	}
+	before := make(map[string]bool, len(from))
+	for _, oper := range from {
+		before[oper.Name.Value] = true
+		if other, ok := after[oper.Name.Value]; ok {
+			d.operation(prefix+oper.Name.Value, oper, other)
+		} else {
+			d.add(ChangeRemoved, prefix+oper.Name.Value, signature(oper), "", true)
+		}
+	}
+	for _, oper := range to {
+		if !before[oper.Name.Value] {
+			d.add(ChangeAdded, prefix+oper.Name.Value, "", signature(oper), false)
+		}
+	}
+}
+
+func (d *differ) operation(path string, from, to *ast.OperationDefinition) {
+	if from.Unary != to.Unary {
+		d.compare(path, signature(from), signature(to))
+		return
+	}
+	d.members(path+"(", valuedMembers(params(from.Parameters)), valuedMembers(params(to.Parameters)))
+	d.compare(path+" returns", returns(from), returns(to))
+}
+
+// member is a named element of a definition and its declaration.
+type member struct {
+	name        string
+	declaration string
+	// required is true when adding the member breaks existing users.
+	required bool
+}
+
+func (d *differ) members(prefix string, from, to []member) {
+	suffix := ""
+	if strings.HasSuffix(prefix, "(") {
+		suffix = ")"
+	}
+	after := make(map[string]member, len(to))
+	for _, m := range to {
+		after[m.name] = m
+	}
+	before := make(map[string]member, len(from))
+	for _, m := range from {
+		before[m.name] = m
+		if n, ok := after[m.name]; ok {
+			d.compare(prefix+m.name+suffix, m.declaration, n.declaration)
+		} else {
+			d.add(ChangeRemoved, prefix+m.name+suffix, m.declaration, "", true)
+		}
+	}
+	for _, m := range to {
+		if _, ok := before[m.name]; !ok {
+			d.add(ChangeAdded, prefix+m.name+suffix, "", m.declaration, m.required)
+		}
+	}
+}
+
+func valuedMembers(defs []*ast.ValuedDefinition) []member {
+	members := make([]member, len(defs))
+	for i, def := range defs {
+		s := def.Name.Value + ": " + format.Type(def.Type)
+		if def.Default != nil {
+			s += " = " + format.Value(def.Default)
+		}
+		_, optional := def.Type.(*ast.Optional)
+		members[i] = member{
+			name:        def.Name.Value,
+			declaration: s,
+			required:    !optional && def.Default == nil,
+		}
+	}
+	return members
+}
+
+func fields(defs []*ast.FieldDefinition) []*ast.ValuedDefinition {
+	valued := make([]*ast.ValuedDefinition, len(defs))
+	for i, def := range defs {
+		valued[i] = (*ast.ValuedDefinition)(def)
+	}
+	return valued
+}
+
+func params(defs []*ast.ParameterDefinition) []*ast.ValuedDefinition {
+	valued := make([]*ast.ValuedDefinition, len(defs))
+	for i, def := range defs {
+		valued[i] = (*ast.ValuedDefinition)(def)
+	}
+	return valued
+}
+
+func unionMembers(defs []*ast.UnionMemberDefinition) []member {
+	members := make([]member, len(defs))
+	for i, def := range defs {
+		t := format.Type(def.Type)
+		members[i] = member{name: t, declaration: t}
+	}
+	return members
+}
+
+func enumMembers(defs []*ast.EnumValueDefinition) []member {
+	members := make([]member, len(defs))
+	for i, def := range defs {
+		s := def.Name.Value + " = " + strconv.Itoa(def.Index.Value)
+		if def.Display != nil {
+			s += " as " + strconv.Quote(def.Display.Value)
+		}
+		members[i] = member{name: def.Name.Value, declaration: s}
+	}
+	return members
+}
+
+func returns(oper *ast.OperationDefinition) string {
+	if oper.Type == nil {
+		return "void"
+	}
+	return format.Type(oper.Type)
+}
+
+// signature renders an operation without its description and annotations.
+func signature(oper *ast.OperationDefinition) string {
+	members := valuedMembers(params(oper.Parameters))
+	s := make([]string, len(members))
+	for i, m := range members {
+		s[i] = m.declaration
+	}
+	if oper.Unary {
+		return "[" + strings.Join(s, ", ") + "]: " + returns(oper)
+	}
+	return "(" + strings.Join(s, ", ") + "): " + returns(oper)
+}
+
+// declaration renders a top level definition without its description and
+// annotations.
+func declaration(def ast.Node) string {
+	switch v := def.(type) {
+	case *ast.TypeDefinition:
+		return "type with " + strconv.Itoa(len(v.Fields)) + " fields"
+	case *ast.InterfaceDefinition:
+		return "interface with " + strconv.Itoa(len(v.Operations)) + " operations"
+	case *ast.OperationDefinition:
+		return signature(v)
+	case *ast.AliasDefinition:
+		return format.Type(v.Type)
+	case *ast.UnionDefinition:
+		members := unionMembers(v.Members)
+		s := make([]string, len(members))
+		for i, m := range members {
+			s[i] = m.declaration
+		}
+		return strings.Join(s, " | ")
+	case *ast.EnumDefinition:
+		return "enum with " + strconv.Itoa(len(v.Values)) + " values"
+	case *ast.DirectiveDefinition:
+		members := valuedMembers(params(v.Parameters))
+		s := make([]string, len(members))
+		for i, m := range members {
+			s[i] = m.declaration
+		}
+		locations := make([]string, len(v.Locations))
+		for i, l := range v.Locations {
+			locations[i] = l.Value
+		}
+		return "(" + strings.Join(s, ", ") + ") on " + strings.Join(locations, " | ")
+	}
+	return ""
+}
+
+// definitions are the named top level definitions of a document, keyed by
+// kind and name, e.g. `type User`, in source order.
+type definitions struct {
+	keys []string
+	defs map[string]ast.Node
+}
+
+func definitionsOf(doc *ast.Document) definitions {
+	d := definitions{defs: make(map[string]ast.Node)}
+	for _, def := range doc.Definitions {
+		var key string
+		switch v := def.(type) {
+		case *ast.TypeDefinition:
+			key = "type " + v.Name.Value
+		case *ast.InterfaceDefinition:
+			key = "interface " + v.Name.Value
+		case *ast.OperationDefinition:
+			key = "func " + v.Name.Value
+		case *ast.AliasDefinition:
+			key = "alias " + v.Name.Value
+		case *ast.UnionDefinition:
+			key = "union " + v.Name.Value
+		case *ast.EnumDefinition:
+			key = "enum " + v.Name.Value
+		case *ast.DirectiveDefinition:
+			key = "directive @" + v.Name.Value
+		default:
+			continue
+		}
+		if _, ok := d.defs[key]; !ok {
+			d.keys = append(d.keys, key)
+		}
+		d.defs[key] = def
+	}
+	return d
+}
+
+func namespaceOf(doc *ast.Document) *ast.NamespaceDefinition {
+	for _, def := range doc.Definitions {
+		if ns, ok := def.(*ast.NamespaceDefinition); ok {
+			return ns
+		}
+	}
+	return nil
+}
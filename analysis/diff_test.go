@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/analysis"
+)
+
+const diffBefore = `namespace "shop"
+
+"Descriptions are not compared."
+type Item {
+  name: string
+  price: f64
+  tags: [string]
+}
+
+interface Store {
+  get(id: string): Item
+  list(): [Item]
+  remove[id: string]
+}
+
+union Result = Item | string
+
+enum Status {
+  active = 0 as "Active"
+  closed = 1
+}
+
+alias ID = string
+`
+
+const diffAfter = `namespace "shop"
+
+type Item @entity {
+  name: string
+  price: f64 = 0
+  tags: [string]?
+  sku: string
+  note: string?
+}
+
+interface Store {
+  get(id: string, expand: bool?): Item?
+  list(): [Item]
+  add(item: Item)
+}
+
+union Result = Item | i64
+
+enum Status {
+  active = 0
+  closed = 1
+  pending = 2
+}
+
+func ping(): string
+`
+
+func TestDiff(t *testing.T) {
+	report := analysis.Diff(parse(t, diffBefore), parse(t, diffAfter))
+	var b strings.Builder
+	if err := report.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `~ type Item.price: price: f64 -> price: f64 = 0 (breaking)
+~ type Item.tags: tags: [string] -> tags: [string]? (breaking)
++ type Item.sku: sku: string (breaking)
++ type Item.note: note: string?
++ interface Store.get(expand): expand: bool?
+~ interface Store.get returns: Item -> Item? (breaking)
+- interface Store.remove: [id: string]: void (breaking)
++ interface Store.add: (item: Item): void
+- union Result|string: string (breaking)
++ union Result|i64: i64
+~ enum Status.active: active = 0 as "Active" -> active = 0 (breaking)
++ enum Status.pending: pending = 2
+- alias ID: string (breaking)
++ func ping: (): string
+`
+	if got := b.String(); got != want {
+		t.Errorf("text:\n%s\nwant:\n%s", got, want)
+	}
+	if !report.Breaking() {
+		t.Error("Breaking() = false, want true")
+	}
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	report := analysis.Diff(parse(t, diffBefore), parse(t, diffBefore))
+	if len(report.Changes) != 0 || report.Breaking() {
+		t.Errorf("changes = %v, want none", report.Changes)
+	}
+	data, err := report.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"changes":[]}`; string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+}
+
+func TestDiffNamespace(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     string
+	}{
+		{`namespace "a"`, `namespace "b"`, "~ namespace: a -> b (breaking)\n"},
+		{`alias A = string`, `namespace "b"`, "+ namespace: b\n- alias A: string (breaking)\n"},
+		{`namespace "a"`, `alias A = string`, "- namespace: a (breaking)\n+ alias A: string\n"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		analysis.Diff(parse(t, tt.from), parse(t, tt.to)).WriteText(&b)
+		if got := b.String(); got != tt.want {
+			t.Errorf("Diff(%q, %q):\n%s\nwant:\n%s", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestDiffReportJSON(t *testing.T) {
+	report := analysis.Diff(parse(t, `type A { a: string }`), parse(t, `type A { b: string? }`))
+	data, err := report.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"changes":[{"kind":"removed","path":"type A.a","old":"a: string","breaking":true},{"kind":"added","path":"type A.b","new":"b: string?","breaking":false}]}`
+	if string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+
+	var decoded analysis.DiffReport
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&decoded, report) {
+		t.Errorf("decoded = %+v, want %+v", decoded, *report)
+	}
+}
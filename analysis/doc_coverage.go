@@ -0,0 +1,264 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package analysis computes reports over a parsed Apex document.
+package analysis
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/location"
+)
+
+// Definition kinds tracked by DocCoverage, in report order.
+const (
+	KindNamespace = "namespace"
+	KindDirective = "directive"
+	KindAlias     = "alias"
+	KindFunction  = "function"
+	KindInterface = "interface"
+	KindOperation = "operation"
+	KindParameter = "parameter"
+	KindType      = "type"
+	KindField     = "field"
+	KindUnion     = "union"
+	KindEnum      = "enum"
+	KindEnumValue = "enum value"
+)
+
+var coverageKinds = []string{
+	KindNamespace,
+	KindDirective,
+	KindAlias,
+	KindFunction,
+	KindInterface,
+	KindOperation,
+	KindParameter,
+	KindType,
+	KindField,
+	KindUnion,
+	KindEnum,
+	KindEnumValue,
+}
+
+// Coverage counts documented elements out of a total.
+//
+//tinyjson:json
+type Coverage struct {
+	Documented int `json:"documented"`
+	Total      int `json:"total"`
+}
+
+// Percent returns the documented share of Total, or 100 when there is
+// nothing to document.
+func (c Coverage) Percent() float64 {
+	if c.Total == 0 {
+		return 100
+	}
+	return float64(c.Documented) * 100 / float64(c.Total)
+}
+
+// KindCoverage is the coverage of a single kind of element.
+//
+//tinyjson:json
+type KindCoverage struct {
+	Kind string `json:"kind"`
+	Coverage
+}
+
+// Undocumented identifies an element without a description.
+//
+//tinyjson:json
+type Undocumented struct {
+	Kind     string                   `json:"kind"`
+	Name     string                   `json:"name"`
+	Location *location.SourceLocation `json:"location,omitempty"`
+}
+
+// CoverageReport is the result of DocCoverage.
+//
+//tinyjson:json
+type CoverageReport struct {
+	Overall      Coverage       `json:"overall"`
+	Kinds        []KindCoverage `json:"kinds"`
+	Undocumented []Undocumented `json:"undocumented,omitempty"`
+}
+
+// DocCoverage computes how many elements of doc have a description.
+func DocCoverage(doc *ast.Document) *CoverageReport {
+	v := docCoverage{counts: make(map[string]*Coverage, len(coverageKinds))}
+	for _, kind := range coverageKinds {
+		v.counts[kind] = &Coverage{}
+	}
+	doc.Accept(ast.NewContext(doc), &v)
+
+	report := CoverageReport{
+		Kinds:        make([]KindCoverage, 0, len(coverageKinds)),
+		Undocumented: v.undocumented,
+	}
+	for _, kind := range coverageKinds {
+		c := v.counts[kind]
+		if c.Total == 0 {
+			continue
+		}
+		report.Kinds = append(report.Kinds, KindCoverage{Kind: kind, Coverage: *c})
+		report.Overall.Documented += c.Documented
+		report.Overall.Total += c.Total
+	}
+	return &report
+}
+
+// WriteText writes a human readable form of the report to w.
+func (r *CoverageReport) WriteText(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString(pad("KIND", -12) + " " + pad("DOCUMENTED", 10) + " " + pad("TOTAL", 6) + " " + pad("COVERAGE", 9) + "\n")
+	for _, k := range r.Kinds {
+		coverageRow(&b, k.Kind, k.Coverage)
+	}
+	coverageRow(&b, "overall", r.Overall)
+	if len(r.Undocumented) > 0 {
+		b.WriteString("\nUndocumented:\n")
+		for _, u := range r.Undocumented {
+			if u.Location != nil {
+				b.WriteString("  " + strconv.FormatUint(uint64(u.Location.Line), 10) + ":" +
+					strconv.FormatUint(uint64(u.Location.Column), 10) + "\t" + u.Kind + " " + u.Name + "\n")
+			} else {
+				b.WriteString("  " + u.Kind + " " + u.Name + "\n")
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// coverageRow writes the table row of c to b.
+func coverageRow(b *strings.Builder, kind string, c Coverage) {
+	b.WriteString(pad(kind, -12) + " " + pad(strconv.Itoa(c.Documented), 10) + " " + pad(strconv.Itoa(c.Total), 6) + " " +
+		pad(strconv.FormatFloat(c.Percent(), 'f', 1, 64)+"%", 9) + "\n")
+}
+
+// pad pads s with spaces to width, on the left, or on the right when
+// width is negative.
+func pad(s string, width int) string {
+	if width < 0 {
+		if n := -width - len(s); n > 0 {
+			return s + strings.Repeat(" ", n)
+		}
+		return s
+	}
+	if n := width - len(s); n > 0 {
+		return strings.Repeat(" ", n) + s
+	}
+	return s
+}
+
+type docCoverage struct {
+	ast.BaseVisitor
+	counts       map[string]*Coverage
+	undocumented []Undocumented
+}
+
+func (v *docCoverage) VisitNamespace(context ast.Context) {
+	ns := context.Namespace
+	v.add(KindNamespace, ns.Name.Value, ns, ns.Description)
+}
+
+func (v *docCoverage) VisitDirective(context ast.Context) {
+	d := context.Directive
+	v.add(KindDirective, "@"+d.Name.Value, d, d.Description)
+}
+
+func (v *docCoverage) VisitDirectiveParameter(context ast.Context) {
+	p := context.Parameter
+	v.add(KindParameter, "@"+context.Directive.Name.Value+"."+p.Name.Value, p, p.Description)
+}
+
+func (v *docCoverage) VisitAlias(context ast.Context) {
+	a := context.Alias
+	v.add(KindAlias, a.Name.Value, a, a.Description)
+}
+
+func (v *docCoverage) VisitFunction(context ast.Context) {
+	f := context.Function
+	v.add(KindFunction, f.Name.Value, f, f.Description)
+}
+
+func (v *docCoverage) VisitInterface(context ast.Context) {
+	i := context.Interface
+	v.add(KindInterface, i.Name.Value, i, i.Description)
+}
+
+func (v *docCoverage) VisitOperation(context ast.Context) {
+	o := context.Operation
+	v.add(KindOperation, context.Interface.Name.Value+"."+o.Name.Value, o, o.Description)
+}
+
+func (v *docCoverage) VisitParameter(context ast.Context) {
+	p := context.Parameter
+	name := context.Operation.Name.Value + "." + p.Name.Value
+	if context.Interface != nil {
+		name = context.Interface.Name.Value + "." + name
+	}
+	v.add(KindParameter, name, p, p.Description)
+}
+
+func (v *docCoverage) VisitType(context ast.Context) {
+	t := context.Type
+	v.add(KindType, t.Name.Value, t, t.Description)
+}
+
+func (v *docCoverage) VisitTypeField(context ast.Context) {
+	f := context.Field
+	v.add(KindField, context.Type.Name.Value+"."+f.Name.Value, f, f.Description)
+}
+
+func (v *docCoverage) VisitUnion(context ast.Context) {
+	u := context.Union
+	v.add(KindUnion, u.Name.Value, u, u.Description)
+}
+
+func (v *docCoverage) VisitEnum(context ast.Context) {
+	e := context.Enum
+	v.add(KindEnum, e.Name.Value, e, e.Description)
+}
+
+func (v *docCoverage) VisitEnumValue(context ast.Context) {
+	ev := context.EnumValue
+	v.add(KindEnumValue, context.Enum.Name.Value+"."+ev.Name.Value, ev, ev.Description)
+}
+
+func (v *docCoverage) add(kind, name string, node ast.Node, description *ast.StringValue) {
+	c := v.counts[kind]
+	c.Total++
+	if HasDescription(description) {
+		c.Documented++
+		return
+	}
+	u := Undocumented{Kind: kind, Name: name}
+	if loc := node.GetLoc(); loc != nil && loc.Source != nil {
+		l := location.GetLocation(loc.Source, loc.Start)
+		u.Location = &l
+	}
+	v.undocumented = append(v.undocumented, u)
+}
+
+// HasDescription returns true if description contains non-whitespace text.
+func HasDescription(description *ast.StringValue) bool {
+	return description != nil && strings.TrimSpace(description.Value) != ""
+}
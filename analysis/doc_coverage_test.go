@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/analysis"
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+)
+
+// parse parses src without resolving imports.
+func parse(t *testing.T, src string) *ast.Document {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestDocCoverage(t *testing.T) {
+	doc := parse(t, `"The users API."
+namespace "users"
+
+"A user."
+type User {
+  "The user ID."
+  id: string
+  name: string
+}
+
+interface Users {
+  "Gets a user."
+  get(id: string): User
+}
+
+enum Role { ADMIN = 0 "Read only." VIEWER = 1 }
+`)
+	report := analysis.DocCoverage(doc)
+
+	want := map[string]analysis.Coverage{
+		analysis.KindNamespace: {Documented: 1, Total: 1},
+		analysis.KindInterface: {Documented: 0, Total: 1},
+		analysis.KindOperation: {Documented: 1, Total: 1},
+		analysis.KindParameter: {Documented: 0, Total: 1},
+		analysis.KindType:      {Documented: 1, Total: 1},
+		analysis.KindField:     {Documented: 1, Total: 2},
+		analysis.KindEnum:      {Documented: 0, Total: 1},
+		analysis.KindEnumValue: {Documented: 1, Total: 2},
+	}
+	if len(report.Kinds) != len(want) {
+		t.Errorf("got %d kinds, want %d: %+v", len(report.Kinds), len(want), report.Kinds)
+	}
+	for _, k := range report.Kinds {
+		if k.Coverage != want[k.Kind] {
+			t.Errorf("%s coverage = %+v, want %+v", k.Kind, k.Coverage, want[k.Kind])
+		}
+	}
+	if report.Overall != (analysis.Coverage{Documented: 5, Total: 10}) {
+		t.Errorf("overall = %+v", report.Overall)
+	}
+	if p := report.Overall.Percent(); p != 50 {
+		t.Errorf("overall percent = %v, want 50", p)
+	}
+
+	var undocumented []string
+	for _, u := range report.Undocumented {
+		undocumented = append(undocumented, u.Kind+" "+u.Name)
+	}
+	wantUndocumented := []string{
+		"interface Users",
+		"parameter Users.get.id",
+		"field User.name",
+		"enum Role",
+		"enum value Role.ADMIN",
+	}
+	if strings.Join(undocumented, "\n") != strings.Join(wantUndocumented, "\n") {
+		t.Errorf("undocumented = %q, want %q", undocumented, wantUndocumented)
+	}
+	if l := report.Undocumented[0].Location; l == nil || l.Line != 11 {
+		t.Errorf("location of Users = %+v, want line 11", l)
+	}
+}
+
+func TestCoveragePercentWithoutElements(t *testing.T) {
+	if p := (analysis.Coverage{}).Percent(); p != 100 {
+		t.Errorf("percent = %v, want 100", p)
+	}
+}
+
+func TestCoverageReportWriteText(t *testing.T) {
+	report := analysis.DocCoverage(parse(t, `namespace "users"
+"A user."
+type User { id: string }
+`))
+	var b strings.Builder
+	if err := report.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `KIND         DOCUMENTED  TOTAL  COVERAGE
+namespace             0      1      0.0%
+type                  1      1    100.0%
+field                 0      1      0.0%
+overall               1      3     33.3%
+
+Undocumented:
+  1:1	namespace users
+  3:13	field User.id
+`
+	if b.String() != want {
+		t.Errorf("text:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestCoverageReportJSON(t *testing.T) {
+	report := analysis.DocCoverage(parse(t, `namespace "users"`))
+	data, err := report.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"overall":{"documented":0,"total":1},"kinds":[{"kind":"namespace","documented":0,"total":1}],"undocumented":[{"kind":"namespace","name":"users","location":{"line":1,"column":1}}]}`
+	if string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"io"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+// DefaultClassifications are the annotations that mark sensitive data.
+var DefaultClassifications = []string{"pii", "secret"}
+
+// ExposurePath describes how classified data is reached from an operation.
+// Steps are the elements traversed, e.g. `User.profile`, `Profile.ssn`.
+//
+//tinyjson:json
+type ExposurePath struct {
+	Classification string   `json:"classification"`
+	Steps          []string `json:"steps"`
+}
+
+func (p ExposurePath) String() string {
+	return strings.Join(p.Steps, " -> ")
+}
+
+// Exposure lists the classified data an operation returns or accepts.
+//
+//tinyjson:json
+type Exposure struct {
+	// Interface is empty for functions.
+	Interface string `json:"interface,omitempty"`
+	Operation string `json:"operation"`
+	// Parameter is empty when the exposure is through the return type.
+	Parameter string         `json:"parameter,omitempty"`
+	Paths     []ExposurePath `json:"paths"`
+
+	// Node is the operation or parameter definition.
+	Node ast.Node `json:"-"`
+}
+
+// Returns reports whether the exposure is through the return type.
+func (e *Exposure) Returns() bool {
+	return e.Parameter == ""
+}
+
+// Classifications returns the distinct classifications in e.Paths.
+func (e *Exposure) Classifications() []string {
+	var classes []string
+	seen := make(map[string]struct{})
+	for _, p := range e.Paths {
+		if _, ok := seen[p.Classification]; !ok {
+			seen[p.Classification] = struct{}{}
+			classes = append(classes, p.Classification)
+		}
+	}
+	return classes
+}
+
+// ExposureReport is the result of Exposures.
+//
+//tinyjson:json
+type ExposureReport struct {
+	Exposures []Exposure `json:"exposures"`
+
+	interfaces map[string]*ast.InterfaceDefinition
+}
+
+// Exposures propagates classification annotations through fields, lists,
+// maps, streams, unions and aliases to every operation return and parameter.
+// DefaultClassifications are used when none are given.
+func Exposures(doc *ast.Document, classifications ...string) *ExposureReport {
+	if len(classifications) == 0 {
+		classifications = DefaultClassifications
+	}
+	e := exposures{
+		classifications: classifications,
+		named:           make(map[string]ast.Node),
+		qualified:       make(map[string]map[string]ast.Node),
+		paths:           make(map[string][]ExposurePath),
+	}
+	report := ExposureReport{
+		interfaces: make(map[string]*ast.InterfaceDefinition),
+	}
+	for _, def := range doc.Definitions {
+		switch v := def.(type) {
+		case *ast.TypeDefinition:
+			e.named[v.Name.Value] = v
+		case *ast.AliasDefinition:
+			e.named[v.Name.Value] = v
+		case *ast.UnionDefinition:
+			e.named[v.Name.Value] = v
+		case *ast.EnumDefinition:
+			e.named[v.Name.Value] = v
+		case *ast.InterfaceDefinition:
+			report.interfaces[v.Name.Value] = v
+		case *ast.ImportDefinition:
+			if v.Alias != nil && v.Document != nil && e.qualified[v.Alias.Value] == nil {
+				named := make(map[string]ast.Node)
+				for name, def := range ast.NamedDefinitions(v.Document) {
+					named[name] = def
+				}
+				e.qualified[v.Alias.Value] = named
+			}
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		switch v := def.(type) {
+		case *ast.OperationDefinition:
+			report.Exposures = append(report.Exposures, e.operation("", v)...)
+		case *ast.InterfaceDefinition:
+			for _, oper := range v.Operations {
+				report.Exposures = append(report.Exposures, e.operation(v.Name.Value, oper)...)
+			}
+		}
+	}
+
+	return &report
+}
+
+// ExposurePolicy forbids classifications on the operations of interfaces
+// carrying an annotation, e.g. `@public` interfaces must not expose `secret`.
+type ExposurePolicy struct {
+	// Interface is the annotation that selects interfaces. An empty value
+	// selects every interface and function.
+	Interface string
+	// Forbidden lists the classifications that must not be exposed.
+	Forbidden []string
+	// Parameters also applies the policy to operation parameters.
+	Parameters bool
+}
+
+// Violation is an exposure that breaks an ExposurePolicy.
+type Violation struct {
+	Exposure       *Exposure
+	Classification string
+	Path           ExposurePath
+}
+
+// Check returns the exposures in r that break policy.
+func (r *ExposureReport) Check(policy ExposurePolicy) []Violation {
+	forbidden := make(map[string]struct{}, len(policy.Forbidden))
+	for _, f := range policy.Forbidden {
+		forbidden[f] = struct{}{}
+	}
+
+	var violations []Violation
+	for i := range r.Exposures {
+		exp := &r.Exposures[i]
+		if !exp.Returns() && !policy.Parameters {
+			continue
+		}
+		if policy.Interface != "" {
+			iface, ok := r.interfaces[exp.Interface]
+			if !ok || iface.Annotation(policy.Interface) == nil {
+				continue
+			}
+		}
+		for _, p := range exp.Paths {
+			if _, ok := forbidden[p.Classification]; ok {
+				violations = append(violations, Violation{
+					Exposure:       exp,
+					Classification: p.Classification,
+					Path:           p,
+				})
+			}
+		}
+	}
+	return violations
+}
+
+type exposures struct {
+	classifications []string
+	named           map[string]ast.Node
+	// qualified are the definitions of namespaces imported with an alias.
+	qualified map[string]map[string]ast.Node
+	// paths caches the result of namedType by qualified name.
+	paths map[string][]ExposurePath
+}
+
+type annotated interface {
+	Annotation(name string) *ast.Annotation
+}
+
+func (e *exposures) operation(iface string, oper *ast.OperationDefinition) []Exposure {
+	var result []Exposure
+	prefix := oper.Name.Value
+	if iface != "" {
+		prefix = iface + "." + prefix
+	}
+
+	if paths := e.typeRef(oper.Type); len(paths) > 0 {
+		result = append(result, Exposure{
+			Interface: iface,
+			Operation: oper.Name.Value,
+			Paths:     prepend(prefix, paths),
+			Node:      oper,
+		})
+	}
+
+	for _, param := range oper.Parameters {
+		step := prefix + "(" + param.Name.Value + ")"
+		paths := e.direct(param, []string{step})
+		paths = append(paths, prepend(step, e.typeRef(param.Type))...)
+		if len(paths) > 0 {
+			result = append(result, Exposure{
+				Interface: iface,
+				Operation: oper.Name.Value,
+				Parameter: param.Name.Value,
+				Paths:     paths,
+				Node:      param,
+			})
+		}
+	}
+	return result
+}
+
+// direct returns a path with steps for each classification annotation on
+// node.
+func (e *exposures) direct(node annotated, steps []string) []ExposurePath {
+	var paths []ExposurePath
+	for _, c := range e.classifications {
+		if node.Annotation(c) != nil {
+			paths = append(paths, ExposurePath{Classification: c, Steps: steps})
+		}
+	}
+	return paths
+}
+
+// typeRef returns the paths of each named type in t.
+func (e *exposures) typeRef(t ast.Type) []ExposurePath {
+	var paths []ExposurePath
+	e.follow("", t, func(qualifier, name string) {
+		paths = append(paths, e.namedType(qualifier, name)...)
+	})
+	return paths
+}
+
+// reached is a named type found by namedType, and the step that led to it
+// from the type at index parent.
+type reached struct {
+	qualifier string
+	name      string
+	def       ast.Node
+	parent    int
+	step      string
+}
+
+// namedType returns a path to each classified element that can be reached
+// from the named type. Types are searched breadth first and each is
+// reached once, so the path to an element is one of the shortest and the
+// search is linear in the number of types and fields. Results are cached.
+func (e *exposures) namedType(qualifier, name string) []ExposurePath {
+	key := qualifiedName(qualifier, name)
+	if paths, ok := e.paths[key]; ok {
+		return paths[:len(paths):len(paths)]
+	}
+
+	var queue []reached
+	seen := make(map[string]bool)
+	enqueue := func(qualifier, name string, parent int, step string) {
+		named := e.named
+		if qualifier != "" {
+			named = e.qualified[qualifier]
+		}
+		key := qualifiedName(qualifier, name)
+		def, ok := named[name]
+		if !ok || seen[key] {
+			// Unknown or already reached types add nothing new.
+			return
+		}
+		seen[key] = true
+		queue = append(queue, reached{qualifier, name, def, parent, step})
+	}
+	// steps returns the steps leading to the type at index i followed by
+	// last.
+	steps := func(i int, last string) []string {
+		result := []string{last}
+		for ; i >= 0 && queue[i].parent >= 0; i = queue[i].parent {
+			result = append(result, queue[i].step)
+		}
+		for l, r := 0, len(result)-1; l < r; l, r = l+1, r-1 {
+			result[l], result[r] = result[r], result[l]
+		}
+		return result
+	}
+
+	enqueue(qualifier, name, -1, "")
+	var paths []ExposurePath
+	for i := 0; i < len(queue); i++ {
+		t := queue[i]
+		name := qualifiedName(t.qualifier, t.name)
+		switch v := t.def.(type) {
+		case *ast.TypeDefinition:
+			paths = append(paths, e.direct(v, steps(i, name))...)
+			for _, field := range v.Fields {
+				step := name + "." + field.Name.Value
+				paths = append(paths, e.direct(field, steps(i, step))...)
+				e.follow(t.qualifier, field.Type, func(q, n string) {
+					enqueue(q, n, i, step)
+				})
+			}
+		case *ast.AliasDefinition:
+			paths = append(paths, e.direct(v, steps(i, name))...)
+			e.follow(t.qualifier, v.Type, func(q, n string) {
+				enqueue(q, n, i, name)
+			})
+		case *ast.UnionDefinition:
+			paths = append(paths, e.direct(v, steps(i, name))...)
+			for _, member := range v.Members {
+				paths = append(paths, e.direct(member, steps(i, name+"|"+typeString(member.Type)))...)
+				e.follow(t.qualifier, member.Type, func(q, n string) {
+					enqueue(q, n, i, name)
+				})
+			}
+		case *ast.EnumDefinition:
+			paths = append(paths, e.direct(v, steps(i, name))...)
+		}
+	}
+	e.paths[key] = paths
+	return paths[:len(paths):len(paths)]
+}
+
+// follow calls reach with each named type in t. Unqualified names in
+// definitions of a namespace imported as qualifier refer to that
+// namespace.
+func (e *exposures) follow(qualifier string, t ast.Type, reach func(qualifier, name string)) {
+	switch v := t.(type) {
+	case *ast.Named:
+		if v.Namespace != nil {
+			if qualifier != "" {
+				// Aliases of imported namespaces are not in scope.
+				return
+			}
+			qualifier = v.Namespace.Value
+		}
+		reach(qualifier, v.Name.Value)
+	case *ast.Optional:
+		e.follow(qualifier, v.Type, reach)
+	case *ast.ListType:
+		e.follow(qualifier, v.Type, reach)
+	case *ast.Stream:
+		e.follow(qualifier, v.Type, reach)
+	case *ast.MapType:
+		e.follow(qualifier, v.KeyType, reach)
+		e.follow(qualifier, v.ValueType, reach)
+	}
+}
+
+func qualifiedName(qualifier, name string) string {
+	if qualifier != "" {
+		return qualifier + "." + name
+	}
+	return name
+}
+
+// prepend returns paths with step added in front. Paths are copied because
+// namedType caches them.
+func prepend(step string, paths []ExposurePath) []ExposurePath {
+	result := make([]ExposurePath, len(paths))
+	for i, p := range paths {
+		result[i] = ExposurePath{
+			Classification: p.Classification,
+			Steps:          append([]string{step}, p.Steps...),
+		}
+	}
+	return result
+}
+
+// typeString renders t the way it is written in a specification.
+func typeString(t ast.Type) string {
+	switch v := t.(type) {
+	case *ast.Named:
+		return v.QualifiedName()
+	case *ast.Optional:
+		return typeString(v.Type) + "?"
+	case *ast.ListType:
+		return "[" + typeString(v.Type) + "]"
+	case *ast.Stream:
+		return "stream " + typeString(v.Type)
+	case *ast.MapType:
+		return "{" + typeString(v.KeyType) + ": " + typeString(v.ValueType) + "}"
+	}
+	return ""
+}
+
+// WriteText writes each exposure and its paths to w.
+func (r *ExposureReport) WriteText(w io.Writer) error {
+	var b strings.Builder
+	for _, exp := range r.Exposures {
+		name := exp.Operation
+		if exp.Interface != "" {
+			name = exp.Interface + "." + name
+		}
+		if exp.Returns() {
+			b.WriteString(name + " returns " + strings.Join(exp.Classifications(), ", ") + "\n")
+		} else {
+			b.WriteString(name + "(" + exp.Parameter + ") accepts " + strings.Join(exp.Classifications(), ", ") + "\n")
+		}
+		for _, p := range exp.Paths {
+			b.WriteString("  " + p.Classification + ": " + p.String() + "\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
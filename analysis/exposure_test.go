@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/analysis"
+	"github.com/apexlang/apex-go/parser"
+)
+
+const exposureSpec = `namespace "test"
+
+type User @pii {
+  name: string @pii
+  profile: Profile
+  friends: [User]
+  tags: {string: Profile}
+  ids: Ids
+  u: Either?
+}
+type Profile { ssn: string @secret  owner: User }
+alias Ids = [Secret]
+type Secret @secret { v: string }
+union Either = Profile | Secret @pii
+interface Users @public {
+  get(id: string @pii): User
+  plain(): string
+}
+func lookup(p: Profile): Ids
+`
+
+func TestExposures(t *testing.T) {
+	report := analysis.Exposures(parse(t, exposureSpec))
+	var b strings.Builder
+	if err := report.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `Users.get returns pii, secret
+  pii: Users.get -> User
+  pii: Users.get -> User.name
+  secret: Users.get -> User.profile -> Profile.ssn
+  pii: Users.get -> User.u -> Either|Secret
+  secret: Users.get -> User.ids -> Ids -> Secret
+Users.get(id) accepts pii
+  pii: Users.get(id)
+lookup returns secret
+  secret: lookup -> Ids -> Secret
+lookup(p) accepts secret, pii
+  secret: lookup(p) -> Profile.ssn
+  pii: lookup(p) -> Profile.owner -> User
+  pii: lookup(p) -> Profile.owner -> User.name
+  pii: lookup(p) -> Profile.owner -> User.u -> Either|Secret
+  secret: lookup(p) -> Profile.owner -> User.ids -> Ids -> Secret
+`
+	if b.String() != want {
+		t.Errorf("report:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestExposuresClassifications(t *testing.T) {
+	report := analysis.Exposures(parse(t, exposureSpec), "secret")
+	var got []string
+	for _, exp := range report.Exposures {
+		got = append(got, exp.Operation+":"+strings.Join(exp.Classifications(), ","))
+	}
+	want := "get:secret lookup:secret lookup:secret"
+	if strings.Join(got, " ") != want {
+		t.Errorf("exposures = %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+// Types that share their subtypes have exponentially many paths to a
+// classified field, but each type is only reached once.
+func TestExposuresSharedSubtypes(t *testing.T) {
+	const depth = 40
+	var b strings.Builder
+	b.WriteString("namespace \"test\"\nfunc get(): T0\n")
+	for i := 0; i < depth; i++ {
+		fmt.Fprintf(&b, "type T%d { a: T%d b: [T%d] c: {string: T%d} }\n", i, i+1, i+1, i+1)
+	}
+	fmt.Fprintf(&b, "type T%d { ssn: string @secret }\n", depth)
+
+	report := analysis.Exposures(parse(t, b.String()))
+	if len(report.Exposures) != 1 || len(report.Exposures[0].Paths) != 1 {
+		t.Fatalf("exposures = %+v, want one path", report.Exposures)
+	}
+	if steps := report.Exposures[0].Paths[0].Steps; len(steps) != depth+2 {
+		t.Errorf("steps = %v, want %d", steps, depth+2)
+	}
+}
+
+func TestExposuresQualifiedImports(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: `namespace "test"
+import * as p from "people"
+type Account { owner: p.Person }
+func get(): Account
+`,
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				return `namespace "people"
+type Person { email: string @pii  home: Address }
+type Address @pii { line: string }
+`, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := analysis.Exposures(doc)
+	var got []string
+	for _, p := range report.Exposures[0].Paths {
+		got = append(got, p.String())
+	}
+	want := []string{
+		"get -> Account.owner -> p.Person.email",
+		"get -> Account.owner -> p.Person.home -> p.Address",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("paths = %q, want %q", got, want)
+	}
+}
+
+func TestExposureReportCheck(t *testing.T) {
+	report := analysis.Exposures(parse(t, exposureSpec))
+	tests := []struct {
+		name   string
+		policy analysis.ExposurePolicy
+		want   []string
+	}{
+		{
+			name:   "public returns",
+			policy: analysis.ExposurePolicy{Interface: "public", Forbidden: []string{"secret"}},
+			want:   []string{"get: User.profile -> Profile.ssn", "get: User.ids -> Ids -> Secret"},
+		},
+		{
+			name:   "public parameters",
+			policy: analysis.ExposurePolicy{Interface: "public", Forbidden: []string{"pii"}, Parameters: true},
+			want: []string{
+				"get: User", "get: User.name", "get: User.u -> Either|Secret", "get(id): Users.get(id)",
+			},
+		},
+		{
+			name:   "functions",
+			policy: analysis.ExposurePolicy{Forbidden: []string{"secret"}},
+			want:   []string{"get: User.profile -> Profile.ssn", "get: User.ids -> Ids -> Secret", "lookup: Ids -> Secret"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range report.Check(tt.policy) {
+				name := v.Exposure.Operation
+				if !v.Exposure.Returns() {
+					name += "(" + v.Exposure.Parameter + ")"
+				}
+				steps := v.Path.Steps
+				if v.Exposure.Returns() {
+					steps = steps[1:]
+				}
+				got = append(got, name+": "+strings.Join(steps, " -> "))
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
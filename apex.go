@@ -0,0 +1,26 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package apex holds the Apex specification of the model in package model.
+package apex
+
+import _ "embed"
+
+// ModelSpec is model.axdl, from which package model is generated. Its
+// annotations describe the Apex syntax of each model type.
+//
+//go:embed model.axdl
+var ModelSpec string
@@ -16,7 +16,10 @@ limitations under the License.
 
 package ast
 
-import "github.com/apexlang/apex-go/kinds"
+import (
+	"github.com/apexlang/apex-go/kinds"
+	"github.com/apexlang/apex-go/source"
+)
 
 type (
 	Definition interface {
@@ -97,11 +100,21 @@ var _ Definition = (*ImportDefinition)(nil)
 
 type ImportDefinition struct {
 	BaseNode
-	Description *StringValue  `json:"description,omitempty"` // Optional
-	All         bool          `json:"all"`
-	Names       []*ImportName `json:"names"`
-	From        *StringValue  `json:"from"`
+	Description *StringValue `json:"description,omitempty"` // Optional
+	All         bool         `json:"all"`
+	// Alias names the imported namespace in qualified references, as in
+	// `import * as billing from "billing"`.
+	Alias *Name         `json:"alias,omitempty"` // Optional
+	Names []*ImportName `json:"names"`
+	From  *StringValue  `json:"from"`
 	AnnotatedNode
+	// Source is the imported source. It is set when the document is parsed
+	// with a resolver.
+	Source *source.Source `json:"-"`
+	// Document is the imported document. It is set when the document is
+	// parsed with a resolver. The definitions of a namespace imported with
+	// an alias are not added to the importing document.
+	Document *Document `json:"-"`
 }
 
 func NewImportDefinition(loc *Location, description *StringValue, all bool, names []*ImportName, from *StringValue, annotations []*Annotation) *ImportDefinition {
@@ -115,6 +128,19 @@ func NewImportDefinition(loc *Location, description *StringValue, all bool, name
 	}
 }
 
+// NewAliasedImportDefinition returns the import of a whole namespace under
+// alias, as in `import * as billing from "billing"`.
+func NewAliasedImportDefinition(loc *Location, description *StringValue, alias *Name, from *StringValue, annotations []*Annotation) *ImportDefinition {
+	return &ImportDefinition{
+		BaseNode:      BaseNode{kinds.ImportDefinition, loc},
+		Description:   description,
+		All:           true,
+		Alias:         alias,
+		From:          from,
+		AnnotatedNode: AnnotatedNode{annotations},
+	}
+}
+
 func (d *ImportDefinition) Accept(context Context, visitor Visitor) {
 	visitor.VisitImport(context)
 	VisitAnnotations(context, visitor, d.Annotations)
@@ -347,7 +373,7 @@ type UnionMemberDefinition struct {
 
 func NewUnionMemberDefinition(loc *Location, description *StringValue, t Type, annotations []*Annotation) *UnionMemberDefinition {
 	return &UnionMemberDefinition{
-		BaseNode:      BaseNode{kinds.EnumValueDefinition, loc},
+		BaseNode:      BaseNode{kinds.UnionMemberDefinition, loc},
 		Description:   description,
 		Type:          t,
 		AnnotatedNode: AnnotatedNode{annotations},
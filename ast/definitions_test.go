@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+func TestNewImportDefinition(t *testing.T) {
+	from := ast.NewStringValue(nil, "billing")
+	imp := ast.NewImportDefinition(nil, nil, true, nil, from, nil)
+	if !imp.All || imp.Alias != nil || imp.From != from {
+		t.Errorf("import = %+v", imp)
+	}
+	alias := ast.NewName(nil, "billing")
+	imp = ast.NewAliasedImportDefinition(nil, nil, alias, from, nil)
+	if !imp.All || imp.Alias != alias || imp.From != from {
+		t.Errorf("aliased import = %+v", imp)
+	}
+}
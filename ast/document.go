@@ -70,6 +70,7 @@ func (d *Document) Accept(context Context, visitor Visitor) {
 	for _, function := range context.Functions {
 		c := context
 		c.Function = function
+		c.Operation = function
 		function.Accept(c, visitor)
 	}
 	visitor.VisitFunctionsAfter(context)
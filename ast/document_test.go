@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+)
+
+type functionVisitor struct {
+	ast.BaseVisitor
+	functions  []string
+	parameters []string
+}
+
+func (v *functionVisitor) VisitFunction(context ast.Context) {
+	v.functions = append(v.functions, context.Operation.Name.Value)
+}
+
+func (v *functionVisitor) VisitParameter(context ast.Context) {
+	v.parameters = append(v.parameters, context.Operation.Name.Value+"."+context.Parameter.Name.Value)
+}
+
+// Functions are visited as operations, so visitors that only look at
+// Context.Operation see their parameters too.
+func TestDocumentAcceptFunctions(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: `namespace "test"
+
+func add(a: i32, b: i32): i32
+func reset(): void
+`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := functionVisitor{}
+	doc.Accept(ast.NewContext(doc), &v)
+
+	if got, want := v.functions, []string{"add", "reset"}; !equal(got, want) {
+		t.Errorf("functions = %v, want %v", got, want)
+	}
+	if got, want := v.parameters, []string{"add.a", "add.b"}; !equal(got, want) {
+		t.Errorf("parameters = %v, want %v", got, want)
+	}
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -46,6 +46,82 @@ func (node *BaseNode) String() string {
 	return string(node.Kind)
 }
 
+// IsNil returns true if node is nil or a nil pointer to a node type. It
+// avoids reflection so that it stays cheap in TinyGo builds.
+func IsNil(node Node) bool {
+	switch n := node.(type) {
+	case nil:
+		return true
+	case *BaseNode:
+		return n == nil
+	case *Document:
+		return n == nil
+	case *Name:
+		return n == nil
+	case *Annotation:
+		return n == nil
+	case *Argument:
+		return n == nil
+	case *DirectiveRequire:
+		return n == nil
+	case *ImportName:
+		return n == nil
+	case *NamespaceDefinition:
+		return n == nil
+	case *AliasDefinition:
+		return n == nil
+	case *ImportDefinition:
+		return n == nil
+	case *TypeDefinition:
+		return n == nil
+	case *FieldDefinition:
+		return n == nil
+	case *InterfaceDefinition:
+		return n == nil
+	case *OperationDefinition:
+		return n == nil
+	case *ParameterDefinition:
+		return n == nil
+	case *UnionDefinition:
+		return n == nil
+	case *UnionMemberDefinition:
+		return n == nil
+	case *EnumDefinition:
+		return n == nil
+	case *EnumValueDefinition:
+		return n == nil
+	case *DirectiveDefinition:
+		return n == nil
+	case *Named:
+		return n == nil
+	case *ListType:
+		return n == nil
+	case *MapType:
+		return n == nil
+	case *Optional:
+		return n == nil
+	case *Stream:
+		return n == nil
+	case *IntValue:
+		return n == nil
+	case *FloatValue:
+		return n == nil
+	case *StringValue:
+		return n == nil
+	case *BooleanValue:
+		return n == nil
+	case *EnumValue:
+		return n == nil
+	case *ListValue:
+		return n == nil
+	case *ObjectValue:
+		return n == nil
+	case *ObjectField:
+		return n == nil
+	}
+	return false
+}
+
 // Name implements Node
 var _ Node = (*Name)(nil)
 
@@ -82,6 +158,16 @@ func (a *Annotation) Accept(context Context, visitor Visitor) {
 	visitor.VisitAnnotation(context)
 }
 
+// Argument returns the argument with the given name or nil if it is not present.
+func (a *Annotation) Argument(name string) *Argument {
+	for _, arg := range a.Arguments {
+		if arg.Name.Value == name {
+			return arg
+		}
+	}
+	return nil
+}
+
 // Argument implements Node
 var _ Node = (*Argument)(nil)
 
@@ -110,7 +196,7 @@ type DirectiveRequire struct {
 
 func NewDirectiveRequire(loc *Location, directive *Name, locations []*Name) *DirectiveRequire {
 	return &DirectiveRequire{
-		BaseNode:  BaseNode{kinds.Argument, loc},
+		BaseNode:  BaseNode{kinds.DirectiveRequire, loc},
 		Directive: directive,
 		Locations: locations,
 	}
@@ -34,7 +34,10 @@ var _ Type = (*Stream)(nil)
 // Named implements Node, Type
 type Named struct {
 	BaseNode
-	Name *Name `json:"name"`
+	// Namespace is the alias of the namespace import that qualifies the
+	// name, as `billing` in `billing.Invoice`.
+	Namespace *Name `json:"namespace,omitempty"` // Optional
+	Name      *Name `json:"name"`
 }
 
 func NewNamed(loc *Location, name *Name) *Named {
@@ -44,6 +47,24 @@ func NewNamed(loc *Location, name *Name) *Named {
 	}
 }
 
+// NewQualifiedNamed returns a reference to a definition of the namespace
+// imported as namespace.
+func NewQualifiedNamed(loc *Location, namespace *Name, name *Name) *Named {
+	return &Named{
+		BaseNode:  BaseNode{kinds.Named, loc},
+		Namespace: namespace,
+		Name:      name,
+	}
+}
+
+// QualifiedName returns the name as written, with its namespace.
+func (n *Named) QualifiedName() string {
+	if n.Namespace != nil {
+		return n.Namespace.Value + "." + n.Name.Value
+	}
+	return n.Name.Value
+}
+
 // ListType implements Node, Type
 type ListType struct {
 	BaseNode
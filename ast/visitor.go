@@ -48,6 +48,9 @@ type Context struct {
 	Annotation *Annotation
 
 	Named map[string]Definition
+	// Qualified are the named definitions of the namespaces imported with
+	// an alias, by alias.
+	Qualified map[string]map[string]Definition
 
 	persistent *contextPersistent
 }
@@ -57,34 +60,45 @@ type contextPersistent struct {
 }
 
 func NewContext(doc *Document) Context {
-	named := make(map[string]Definition)
 	c := Context{
 		Document:   doc,
-		Named:      named,
+		Named:      NamedDefinitions(doc),
+		Qualified:  make(map[string]map[string]Definition),
 		persistent: &contextPersistent{},
 	}
+	imported := ImportedDefinitions(doc)
 	for _, def := range doc.Definitions {
 		switch t := def.(type) {
 		case *NamespaceDefinition:
-			c.Namespace = t
+			// Imported and merged documents declare more than one
+			// namespace. The first that is not imported is the
+			// document's own.
+			if c.Namespace == nil && !imported[t] {
+				c.Namespace = t
+			}
 		case *ImportDefinition:
 			c.Imports = append(c.Imports, t)
+			if t.Alias != nil && c.Qualified[t.Alias.Value] == nil {
+				// The first import of an alias wins, as reported by the
+				// ValidImports rule.
+				named := make(map[string]Definition)
+				if t.Document != nil {
+					named = NamedDefinitions(t.Document)
+				}
+				c.Qualified[t.Alias.Value] = named
+			}
 		case *DirectiveDefinition:
 			c.Directives = append(c.Directives, t)
 		case *AliasDefinition:
 			c.Aliases = append(c.Aliases, t)
-			named[t.Name.Value] = t
 		case *UnionDefinition:
 			c.Unions = append(c.Unions, t)
-			named[t.Name.Value] = t
 		case *EnumDefinition:
 			c.Enums = append(c.Enums, t)
-			named[t.Name.Value] = t
 		case *OperationDefinition:
 			c.Functions = append(c.Functions, t)
 		case *TypeDefinition:
 			c.Types = append(c.Types, t)
-			named[t.Name.Value] = t
 		case *InterfaceDefinition:
 			c.Interfaces = append(c.Interfaces, t)
 		}
@@ -92,6 +106,85 @@ func NewContext(doc *Document) Context {
 	return c
 }
 
+// NamedDefinitions returns the types, enums, unions and aliases of doc by
+// name.
+func NamedDefinitions(doc *Document) map[string]Definition {
+	named := make(map[string]Definition)
+	for _, def := range doc.Definitions {
+		switch t := def.(type) {
+		case *AliasDefinition:
+			named[t.Name.Value] = t
+		case *UnionDefinition:
+			named[t.Name.Value] = t
+		case *EnumDefinition:
+			named[t.Name.Value] = t
+		case *TypeDefinition:
+			named[t.Name.Value] = t
+		}
+	}
+	return named
+}
+
+// ImportedDefinitions returns the definitions of doc that its imports
+// bring in. They are found without source locations, so that documents
+// parsed without sources tell them from their own.
+func ImportedDefinitions(doc *Document) map[Node]bool {
+	imported := make(map[Node]bool)
+	names := make(map[*Name]bool)
+	for _, def := range doc.Definitions {
+		imp, ok := def.(*ImportDefinition)
+		if !ok {
+			continue
+		}
+		if imp.All && imp.Alias == nil && imp.Document != nil {
+			for _, def := range imp.Document.Definitions {
+				imported[def] = true
+			}
+		}
+		// Named imports are brought in as copies named by the import.
+		for _, n := range imp.Names {
+			names[n.Name] = true
+			if n.Alias != nil {
+				names[n.Alias] = true
+			}
+		}
+	}
+	if len(names) == 0 {
+		return imported
+	}
+	for _, def := range doc.Definitions {
+		var name *Name
+		switch t := def.(type) {
+		case *InterfaceDefinition:
+			name = t.Name
+		case *TypeDefinition:
+			name = t.Name
+		case *EnumDefinition:
+			name = t.Name
+		case *UnionDefinition:
+			name = t.Name
+		case *DirectiveDefinition:
+			name = t.Name
+		case *AliasDefinition:
+			name = t.Name
+		}
+		if name != nil && names[name] {
+			imported[def] = true
+		}
+	}
+	return imported
+}
+
+// Lookup returns the type, enum, union or alias that named refers to, or
+// nil when it is unknown. Qualified names are looked up in the namespace
+// imported under their qualifier.
+func (c *Context) Lookup(named *Named) Definition {
+	if named.Namespace == nil {
+		return c.Named[named.Name.Value]
+	}
+	return c.Qualified[named.Namespace.Value][named.Name.Value]
+}
+
 func (c *Context) ReportError(err error) {
 	c.persistent.Errors = append(c.persistent.Errors, err)
 }
@@ -0,0 +1,367 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bundle inlines the definitions that an Apex specification
+// imports, producing a single specification that parses without a
+// resolver.
+package bundle
+
+import (
+	stderrs "errors"
+	"fmt"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/format"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/query"
+	"github.com/apexlang/apex-go/source"
+)
+
+// Kinds of inlined definitions.
+const (
+	KindDirective = "directive"
+	KindAlias     = "alias"
+	KindFunction  = "function"
+	KindInterface = "interface"
+	KindType      = "type"
+	KindUnion     = "union"
+	KindEnum      = "enum"
+)
+
+// Options configure how imports are read.
+type Options struct {
+	// Resolver reads the source of an import.
+	Resolver parser.Resolver
+	// Locator names imported sources. parser.ImportSourceName is used
+	// when nil.
+	Locator parser.Locator
+}
+
+// Result is a bundled specification.
+type Result struct {
+	// Source is the formatted specification, without imports.
+	Source   []byte
+	Manifest Manifest
+}
+
+// Manifest lists the definitions inlined into a bundle.
+//
+//tinyjson:json
+type Manifest struct {
+	Namespace string    `json:"namespace"`
+	Source    string    `json:"source"`
+	Inlined   []Inlined `json:"inlined"`
+}
+
+// Inlined is a definition that was inlined in place of an import.
+//
+//tinyjson:json
+type Inlined struct {
+	Kind string `json:"kind"`
+	// Name is the name of the definition in the bundle.
+	Name string `json:"name"`
+	// Original is the name declared in Source when the definition was
+	// renamed by an import.
+	Original string `json:"original,omitempty"`
+	// Source is the name of the source declaring the definition.
+	Source string `json:"source"`
+	// From is the location of the import that brought the definition in,
+	// as written in Importer.
+	From     string `json:"from"`
+	Importer string `json:"importer"`
+}
+
+// Bundle replaces the imports of src with the definitions they bring in.
+// Imports are expanded as the parser expands them: all definitions of the
+// imported source, including those it imports, for `import *`, and the
+// named definitions, under their aliases, otherwise. Each inlined
+// definition is preceded by a comment naming the source it comes from,
+// and definitions reached through several imports are inlined once.
+func Bundle(src *source.Source, opts Options) (*Result, error) {
+	if opts.Resolver == nil {
+		return nil, stderrs.New("bundle: a resolver is required")
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		return nil, err
+	}
+
+	b := bundler{
+		opts:  opts,
+		stack: []string{src.Name},
+		seen:  make(map[origin]bool),
+	}
+	m := Manifest{Source: src.Name, Inlined: []Inlined{}}
+	var edits []source.Edit
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.NamespaceDefinition:
+			if m.Namespace == "" {
+				m.Namespace = d.Name.Value
+			}
+		case *ast.ImportDefinition:
+			defs, err := b.imported(d, src.Name)
+			if err != nil {
+				return nil, err
+			}
+			var text strings.Builder
+			// A comment trailing the import stays with what it brought in.
+			end := d.Loc.End
+			if comment := trailingComment(src.Body, end); comment != "" {
+				end += uint(len(comment))
+				text.WriteString(strings.TrimSpace(comment) + "\n")
+			}
+			for _, def := range defs {
+				key := def.origin()
+				if b.seen[key] {
+					continue
+				}
+				b.seen[key] = true
+				m.Inlined = append(m.Inlined, def.inlined)
+				text.WriteString(provenance(def.inlined))
+				text.WriteString(format.Document(ast.NewDocument(nil, []ast.Node{def.node})))
+				text.WriteString("\n")
+			}
+			edits = append(edits, source.Edit{
+				Start:   d.Loc.Start,
+				End:     end,
+				NewText: text.String(),
+			})
+		}
+	}
+
+	body, err := source.ApplyEdits(src.Body, edits)
+	if err != nil {
+		return nil, err
+	}
+	data, err := format.Source(source.NewSource(src.Name, body))
+	if err != nil {
+		return nil, fmt.Errorf("bundle of %s does not parse: %w", src.Name, err)
+	}
+	return &Result{Source: data, Manifest: m}, nil
+}
+
+// trailingComment returns the text from end to the end of its line when
+// it is a comment, including the whitespace before it.
+func trailingComment(body []byte, end uint) string {
+	i := end
+	for i < uint(len(body)) && (body[i] == ' ' || body[i] == '\t') {
+		i++
+	}
+	if i == uint(len(body)) || body[i] != '#' {
+		return ""
+	}
+	for i < uint(len(body)) && body[i] != '\n' && body[i] != '\r' {
+		i++
+	}
+	return string(body[end:i])
+}
+
+// provenance returns the comment that precedes an inlined definition.
+func provenance(in Inlined) string {
+	var b strings.Builder
+	b.WriteString("# ")
+	if in.Original != "" {
+		b.WriteString(in.Original + " ")
+	}
+	fmt.Fprintf(&b, "from %q", in.From)
+	if in.Source != in.From {
+		b.WriteString(" (" + in.Source + ")")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+type bundler struct {
+	opts Options
+	// stack is the sources being expanded, outermost first.
+	stack []string
+	// seen are the definitions already inlined.
+	seen map[origin]bool
+}
+
+// origin identifies a definition by the source that declares it, its
+// declared name and its name in the bundle.
+type origin struct {
+	source   string
+	kind     string
+	original string
+	name     string
+}
+
+// definition is a definition to inline and where it comes from.
+type definition struct {
+	node    ast.Node
+	inlined Inlined
+}
+
+func (d *definition) origin() origin {
+	o := origin{
+		source:   d.inlined.Source,
+		kind:     d.inlined.Kind,
+		original: d.inlined.Original,
+		name:     d.inlined.Name,
+	}
+	if o.original == "" {
+		o.original = o.name
+	}
+	return o
+}
+
+// imported returns the definitions that imp, in the source named from,
+// brings in.
+func (b *bundler) imported(imp *ast.ImportDefinition, from string) ([]definition, error) {
+	location := imp.From.Value
+	if imp.Alias != nil {
+		// Qualified references cannot refer to inlined definitions.
+		return nil, fmt.Errorf("%s: namespace import %q as %s cannot be bundled", from, location, imp.Alias.Value)
+	}
+	contents, err := b.opts.Resolver(location, from)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(contents, "error:") {
+		return nil, stderrs.New(contents)
+	}
+	name := parser.ImportSourceName(location, from)
+	if b.opts.Locator != nil {
+		if name, err = b.opts.Locator(location, from); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range b.stack {
+		if s == name {
+			return nil, fmt.Errorf("import cycle: %s imports %s", from, name)
+		}
+	}
+	b.stack = append(b.stack, name)
+	defer func() { b.stack = b.stack[:len(b.stack)-1] }()
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(name, []byte(contents)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var defs []definition
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.NamespaceDefinition:
+		case *ast.ImportDefinition:
+			nested, err := b.imported(d, name)
+			if err != nil {
+				return nil, err
+			}
+			defs = append(defs, nested...)
+		default:
+			defs = append(defs, definition{
+				node: def,
+				inlined: Inlined{
+					Kind:     kindOf(def),
+					Name:     query.NameOf(def).Value,
+					Source:   name,
+					From:     location,
+					Importer: from,
+				},
+			})
+		}
+	}
+	if imp.All {
+		return defs, nil
+	}
+	return selectNames(imp, defs)
+}
+
+// selectNames returns the definitions that a selective import names,
+// renamed by their aliases. As with the parser, references to renamed
+// definitions are left as written.
+func selectNames(imp *ast.ImportDefinition, defs []definition) ([]definition, error) {
+	named := make(map[string]definition)
+	for _, def := range defs {
+		if _, ok := named[def.inlined.Name]; !ok && def.inlined.Kind != KindFunction {
+			named[def.inlined.Name] = def
+		}
+	}
+
+	selected := make([]definition, 0, len(imp.Names))
+	for _, n := range imp.Names {
+		def, ok := named[n.Name.Value]
+		if !ok {
+			return nil, fmt.Errorf(
+				"could not find %q in %q", n.Name.Value, imp.From.Value)
+		}
+		if n.Alias != nil && n.Alias.Value != n.Name.Value {
+			if def.inlined.Original == "" {
+				def.inlined.Original = def.inlined.Name
+			}
+			def.inlined.Name = n.Alias.Value
+			def.node = renamed(def.node, n.Alias)
+		}
+		selected = append(selected, def)
+	}
+	return selected, nil
+}
+
+// renamed returns a copy of def named name.
+func renamed(def ast.Node, name *ast.Name) ast.Node {
+	switch v := def.(type) {
+	case *ast.InterfaceDefinition:
+		c := *v
+		c.Name = name
+		return &c
+	case *ast.TypeDefinition:
+		c := *v
+		c.Name = name
+		return &c
+	case *ast.EnumDefinition:
+		c := *v
+		c.Name = name
+		return &c
+	case *ast.UnionDefinition:
+		c := *v
+		c.Name = name
+		return &c
+	case *ast.DirectiveDefinition:
+		c := *v
+		c.Name = name
+		return &c
+	case *ast.AliasDefinition:
+		c := *v
+		c.Name = name
+		return &c
+	}
+	return def
+}
+
+// kindOf returns the kind of a top level definition.
+func kindOf(def ast.Node) string {
+	switch def.(type) {
+	case *ast.DirectiveDefinition:
+		return KindDirective
+	case *ast.AliasDefinition:
+		return KindAlias
+	case *ast.OperationDefinition:
+		return KindFunction
+	case *ast.InterfaceDefinition:
+		return KindInterface
+	case *ast.UnionDefinition:
+		return KindUnion
+	case *ast.EnumDefinition:
+		return KindEnum
+	}
+	return KindType
+}
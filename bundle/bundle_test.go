@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/bundle"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/source"
+)
+
+// files is a resolver reading imports from a map.
+type files map[string]string
+
+func (f files) resolve(location, from string) (string, error) {
+	contents, ok := f[location]
+	if !ok {
+		return "error: could not find " + location, nil
+	}
+	return contents, nil
+}
+
+func run(t *testing.T, fs files, spec string) (*bundle.Result, error) {
+	t.Helper()
+	return bundle.Bundle(source.NewSource("main.apex", []byte(spec)), bundle.Options{
+		Resolver: fs.resolve,
+	})
+}
+
+var common = files{
+	"common.apex": `namespace "common"
+import * from "ids.apex"
+
+"A price."
+type Money {
+  amount: f64
+}
+
+enum Currency {
+  USD = 0
+}
+
+func now(): datetime
+`,
+	"ids.apex": `alias ID = string
+`,
+}
+
+func TestBundle(t *testing.T) {
+	result, err := run(t, common, `namespace "shop"
+
+import { Money, Currency as Cur } from "common.apex" # money
+import * from "common.apex"
+
+type Order {
+  id: ID
+  total: Money
+  currency: Cur
+}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `namespace "shop"
+
+# money
+# from "common.apex"
+"A price."
+type Money {
+  amount: f64
+}
+
+# Currency from "common.apex"
+enum Cur {
+  USD = 0
+}
+
+# from "ids.apex"
+alias ID = string
+
+# from "common.apex"
+enum Currency {
+  USD = 0
+}
+
+# from "common.apex"
+func now(): datetime
+
+type Order {
+  id: ID
+  total: Money
+  currency: Cur
+}
+`
+	if got := string(result.Source); got != want {
+		t.Errorf("source:\n%s\nwant:\n%s", got, want)
+	}
+	if _, err := parser.Parse(parser.ParseParams{Source: source.NewSource("bundle.apex", result.Source)}); err != nil {
+		t.Errorf("bundle does not parse: %v", err)
+	}
+
+	manifest := bundle.Manifest{
+		Namespace: "shop",
+		Source:    "main.apex",
+		Inlined: []bundle.Inlined{
+			{Kind: bundle.KindType, Name: "Money", Source: "common.apex", From: "common.apex", Importer: "main.apex"},
+			{Kind: bundle.KindEnum, Name: "Cur", Original: "Currency", Source: "common.apex", From: "common.apex", Importer: "main.apex"},
+			{Kind: bundle.KindAlias, Name: "ID", Source: "ids.apex", From: "ids.apex", Importer: "common.apex"},
+			{Kind: bundle.KindEnum, Name: "Currency", Source: "common.apex", From: "common.apex", Importer: "main.apex"},
+			{Kind: bundle.KindFunction, Name: "now", Source: "common.apex", From: "common.apex", Importer: "main.apex"},
+		},
+	}
+	if !reflect.DeepEqual(result.Manifest, manifest) {
+		t.Errorf("manifest = %+v, want %+v", result.Manifest, manifest)
+	}
+}
+
+func TestBundleLocator(t *testing.T) {
+	result, err := bundle.Bundle(source.NewSource("main.apex", []byte(`import * from "ids.apex"`)), bundle.Options{
+		Resolver: common.resolve,
+		Locator: func(location, from string) (string, error) {
+			return "/specs/" + location, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# from \"ids.apex\" (/specs/ids.apex)\nalias ID = string\n"; string(result.Source) != want {
+		t.Errorf("source:\n%s\nwant:\n%s", result.Source, want)
+	}
+	if got := result.Manifest.Inlined[0].Source; got != "/specs/ids.apex" {
+		t.Errorf("inlined source = %q, want /specs/ids.apex", got)
+	}
+}
+
+func TestBundleErrors(t *testing.T) {
+	cycle := files{
+		"a.apex": `import * from "b.apex"`,
+		"b.apex": `import * from "a.apex"`,
+	}
+	tests := []struct {
+		name string
+		fs   files
+		spec string
+		want string
+	}{
+		{"missing", common, `import * from "nowhere.apex"`, "error: could not find nowhere.apex"},
+		{"unknown name", common, `import { Missing } from "common.apex"`, `could not find "Missing" in "common.apex"`},
+		{"function name", common, `import { now } from "common.apex"`, `could not find "now" in "common.apex"`},
+		{"cycle", cycle, `import * from "a.apex"`, "import cycle: b.apex imports a.apex"},
+		{"namespace alias", common, `import * as c from "common.apex"`, `namespace import "common.apex" as c cannot be bundled`},
+		{"syntax", common, `type {`, "Syntax Error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := run(t, tt.fs, tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Bundle() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := bundle.Bundle(source.NewSource("main.apex", nil), bundle.Options{}); err == nil {
+		t.Error("Bundle without a resolver succeeded")
+	}
+}
+
+func TestManifestJSON(t *testing.T) {
+	m := bundle.Manifest{
+		Namespace: "shop",
+		Source:    "main.apex",
+		Inlined: []bundle.Inlined{
+			{Kind: bundle.KindEnum, Name: "Cur", Original: "Currency", Source: "common.apex", From: "common.apex", Importer: "main.apex"},
+			{Kind: bundle.KindType, Name: "Money", Source: "common.apex", From: "common.apex", Importer: "main.apex"},
+		},
+	}
+	data, err := m.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"namespace":"shop","source":"main.apex","inlined":[` +
+		`{"kind":"enum","name":"Cur","original":"Currency","source":"common.apex","from":"common.apex","importer":"main.apex"},` +
+		`{"kind":"type","name":"Money","source":"common.apex","from":"common.apex","importer":"main.apex"}]}`
+	if string(data) != want {
+		t.Errorf("json = %s, want %s", data, want)
+	}
+
+	var decoded bundle.Manifest
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, m) {
+		t.Errorf("decoded = %+v, want %+v", decoded, m)
+	}
+}
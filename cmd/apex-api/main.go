@@ -35,10 +35,33 @@ func Resolve(
 
 //go:wasmexport parse
 func Parse(ptr uintptr, size uint32) (ptrSize uint64) {
-	source := tinymem.PtrToString(ptr, size)
+	return convert(tinymem.PtrToString(ptr, size), model.FormatJSON)
+}
+
+// Convert is parse with the model encoded in format, a model.Format value.
+//
+//go:wasmexport convert
+func Convert(ptr uintptr, size uint32, format uint32) (ptrSize uint64) {
+	return convert(tinymem.PtrToString(ptr, size), model.Format(format))
+}
+
+// Load decodes a model previously encoded in format and returns it as JSON.
+//
+//go:wasmexport load
+func Load(ptr uintptr, size uint32, format uint32) (ptrSize uint64) {
+	data := []byte(tinymem.PtrToString(ptr, size))
+	ns, err := model.UnmarshalNamespace(data, model.Format(format))
+	if err != nil {
+		return errors.Return(err)
+	}
+	return encode(ns, model.FormatJSON)
+}
 
+func convert(source string, format model.Format) (ptrSize uint64) {
 	doc, err := parser.Parse(parser.ParseParams{
 		Source: source,
+		// The zero Limits bound the untrusted source and its imports
+		// with the default limits.
 		Options: parser.ParseOptions{
 			NoSource: true,
 			Resolver: func(location, from string) (string, error) {
@@ -71,12 +94,15 @@ func Parse(ptr uintptr, size uint32) (ptrSize uint64) {
 		return errors.Return(errs...)
 	}
 
-	jsonBytes, err := ns.MarshalJSON()
+	return encode(ns, format)
+}
+
+func encode(ns *model.Namespace, format model.Format) (ptrSize uint64) {
+	data, err := model.MarshalNamespace(ns, format)
 	if err != nil {
 		return errors.Return(err)
 	}
 
-	jsonString := string(jsonBytes)
-	ptr, size = tinymem.StringToPtr(jsonString)
+	ptr, size := tinymem.StringToPtr(string(data))
 	return (uint64(ptr) << uint64(32)) | uint64(size)
 }
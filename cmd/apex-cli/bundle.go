@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/apexlang/apex-go/bundle"
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/resolver"
+	"github.com/apexlang/apex-go/rules"
+)
+
+// bundleSpec writes a specification with the definitions it imports in place
+// of its import statements, and optionally a manifest of what was inlined.
+func bundleSpec(args []string) {
+	flags := flag.NewFlagSet("bundle", flag.ExitOnError)
+	roots := flags.String("roots", "", "comma separated import roots (default: the directory of the file)")
+	output := flags.String("o", "", "write the bundle to a file instead of stdout")
+	manifest := flags.String("manifest", "", "write a JSON manifest of the inlined definitions to a file")
+	out := outputFlags(flags)
+	usage(flags, "file")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(exitFailure)
+	}
+
+	ws, file := loadSpec(flags.Arg(0), *roots, out)
+	fs := resolver.NewFileSystem(ws.Roots()...)
+	result, err := bundle.Bundle(ws.Document(file).Loc.Source, bundle.Options{
+		Resolver: fs.Resolve,
+		Locator:  fs.Locate,
+	})
+	if err != nil {
+		fail(err)
+	}
+
+	// The bundle must stand on its own, so it is checked without a
+	// resolver before it is written.
+	name := file
+	if *output != "" {
+		name = *output
+	}
+	doc, err := parser.Parse(parser.ParseParams{Source: result.Source})
+	var errs []error
+	if err != nil {
+		errs = []error{err}
+	} else {
+		errs = rules.Validate(doc, rules.Rules...)
+	}
+	if errors.HasErrors(errs) {
+		diags := make([]fileError, len(errs))
+		for i, err := range errs {
+			diags[i] = fileError{name, err}
+		}
+		out.diagnostics(os.Stderr, diags)
+		os.Exit(exitProblems)
+	}
+
+	if *manifest != "" {
+		data, err := result.Manifest.MarshalJSON()
+		if err == nil {
+			err = os.WriteFile(*manifest, append(data, '\n'), 0o644)
+		}
+		if err != nil {
+			fail(err)
+		}
+	}
+	if *output != "" {
+		err = os.WriteFile(*output, result.Source, 0o644)
+	} else if !out.quiet {
+		_, err = os.Stdout.Write(result.Source)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
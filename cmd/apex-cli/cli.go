@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CosmWasm/tinyjson/jwriter"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/workspace"
+)
+
+// Exit codes of the commands.
+const (
+	exitOK = 0
+	// exitProblems means the specifications have errors, or differ or are
+	// not formatted for diff and fmt.
+	exitProblems = 1
+	// exitFailure means the command was used incorrectly or a file could
+	// not be read or written.
+	exitFailure = 2
+)
+
+// stdin is the file argument that reads a specification from stdin.
+const stdin = "-"
+
+// output holds the flags selecting how a command reports its results.
+type output struct {
+	quiet bool
+	json  bool
+}
+
+func outputFlags(flags *flag.FlagSet) *output {
+	o := output{}
+	flags.BoolVar(&o.quiet, "quiet", false, "print nothing and only report the result with the exit code")
+	flags.BoolVar(&o.quiet, "q", false, "shorthand for -quiet")
+	flags.BoolVar(&o.json, "json", false, "print results as JSON")
+	return &o
+}
+
+// usage sets the usage message of a command.
+func usage(flags *flag.FlagSet, args string) {
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "usage: apex-cli %s [flags] %s\n", flags.Name(), args)
+		flags.PrintDefaults()
+	}
+}
+
+// fail reports an error that stops a command from running.
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "apex-cli:", err)
+	os.Exit(exitFailure)
+}
+
+// problems writes errors in a specification to stderr as a JSON array, as
+// errors.Write does, and exits with exitProblems.
+func problems(errs ...error) {
+	out := jwriter.Writer{}
+	out.RawByte('[')
+	for i, e := range errors.Convert(errs...) {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		e.MarshalTinyJSON(&out)
+	}
+	out.RawString("]\n")
+	os.Stderr.Write(out.Buffer.BuildBytes())
+	os.Exit(exitProblems)
+}
+
+// specFiles expands the directories in paths to the .apex files they
+// contain.
+func specFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		if path == stdin {
+			files = append(files, path)
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, filepath.Clean(path))
+			continue
+		}
+		err = filepath.WalkDir(path, func(path string, entry fs.DirEntry, err error) error {
+			if err == nil && !entry.IsDir() && filepath.Ext(path) == ".apex" {
+				files = append(files, path)
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// load returns a workspace with files loaded. Imports are resolved from
+// roots, a comma separated list, or from the directories of the files and
+// the shared definitions in ~/.apex/definitions when roots is empty.
+func load(files []string, roots string, validation []rules.ValidationRule) (*workspace.Workspace, error) {
+	var dirs []string
+	if roots != "" {
+		dirs = strings.Split(roots, ",")
+	} else {
+		dirs = defaultRoots(files)
+	}
+	ws := workspace.New(dirs...)
+	ws.Rules = validation
+	for _, file := range files {
+		if file != stdin {
+			if err := ws.Load(file); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		content, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		ws.Open(stdin, content)
+	}
+	return ws, nil
+}
+
+func defaultRoots(files []string) []string {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, file := range files {
+		dir := "."
+		if file != stdin {
+			dir = filepath.Dir(file)
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		definitions := filepath.Join(home, ".apex", "definitions")
+		if info, err := os.Stat(definitions); err == nil && info.IsDir() {
+			dirs = append(dirs, definitions)
+		}
+	}
+	return dirs
+}
+
+// loadSpec loads a single specification, validated with the default
+// rules, and returns its workspace and file name. When it has errors, they
+// are printed to stderr and the command exits.
+func loadSpec(path, roots string, out *output) (*workspace.Workspace, string) {
+	files, err := specFiles([]string{path})
+	if err != nil {
+		fail(err)
+	}
+	if len(files) != 1 {
+		fail(fmt.Errorf("%s is not a specification file", path))
+	}
+	ws, err := load(files, roots, nil)
+	if err != nil {
+		fail(err)
+	}
+	if diags, failed := collect(ws, files); failed {
+		out.diagnostics(os.Stderr, diags)
+		os.Exit(exitProblems)
+	}
+	return ws, files[0]
+}
+
+// fileError is a diagnostic reported for a file.
+type fileError struct {
+	path string
+	err  error
+}
+
+// collect returns the diagnostics of files in ws and whether any of them
+// is an error.
+func collect(ws *workspace.Workspace, files []string) ([]fileError, bool) {
+	var diags []fileError
+	failed := false
+	for _, path := range files {
+		errs := ws.Diagnostics(path)
+		failed = failed || errors.HasErrors(errs)
+		for _, err := range errs {
+			diags = append(diags, fileError{path, err})
+		}
+	}
+	return diags, failed
+}
+
+// diagnostics prints diags as path:line:column: message lines, or as a
+// JSON array with -json.
+func (o *output) diagnostics(w io.Writer, diags []fileError) {
+	if o.quiet {
+		return
+	}
+	if !o.json {
+		for _, d := range diags {
+			fmt.Fprintln(w, diagnostic(d.path, d.err))
+		}
+		return
+	}
+
+	out := jwriter.Writer{}
+	out.RawByte('[')
+	for i, d := range diags {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		out.RawString(`{"file":`)
+		out.String(d.path)
+		severity := errors.SeverityError
+		message := d.err.Error()
+		if e, ok := d.err.(*errors.Error); ok {
+			severity = e.Severity
+			message, _, _ = strings.Cut(e.Message, "\n\n")
+			if len(e.Locations) > 0 {
+				out.RawString(`,"line":`)
+				out.Uint(e.Locations[0].Line)
+				out.RawString(`,"column":`)
+				out.Uint(e.Locations[0].Column)
+			}
+		}
+		out.RawString(`,"severity":`)
+		out.String(severity.String())
+		out.RawString(`,"message":`)
+		out.String(message)
+		out.RawByte('}')
+	}
+	out.RawString("]\n")
+	w.Write(out.Buffer.BuildBytes())
+}
+
+// diagnostic formats err as path:line:column: message.
+func diagnostic(path string, err error) string {
+	e, ok := err.(*errors.Error)
+	if !ok {
+		return path + ": " + err.Error()
+	}
+	// Syntax errors append a highlighted excerpt of the source.
+	message, _, _ := strings.Cut(e.Message, "\n\n")
+	if len(e.Locations) > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s", path, e.Locations[0].Line, e.Locations[0].Column, message)
+	}
+	return path + ": " + message
+}
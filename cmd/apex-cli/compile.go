@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apexlang/apex-go/model"
+)
+
+// artifactExt is the extension of compiled model artifacts.
+const artifactExt = ".apexc"
+
+// compile writes the model of a specification as an artifact that
+// model.Load reads without parsing the specification.
+func compile(args []string) {
+	flags := flag.NewFlagSet("compile", flag.ExitOnError)
+	roots := flags.String("roots", "", "comma separated import roots (default: the directory of the file)")
+	output := flags.String("o", "", "write the artifact to this file (default: the file with the "+artifactExt+" extension)")
+	noSourceMap := flags.Bool("no-source-map", false, "leave the source locations of definitions out of the artifact")
+	out := outputFlags(flags)
+	usage(flags, "file")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(exitFailure)
+	}
+
+	ws, file := loadSpec(flags.Arg(0), *roots, out)
+	namespaces := ws.Namespaces()
+	if len(namespaces) == 0 {
+		fail(fmt.Errorf("%s does not define a namespace", file))
+	}
+	artifact := model.Artifact{Namespace: namespaces[0].Model}
+	if !*noSourceMap {
+		artifact.SourceMap = model.NewSourceMap(ws.Document(file))
+	}
+	data, err := artifact.MarshalBinary()
+	if err != nil {
+		fail(err)
+	}
+
+	path := *output
+	if path == "" {
+		if file == stdin {
+			fail(fmt.Errorf("-o is required when reading from stdin"))
+		}
+		path = strings.TrimSuffix(file, filepath.Ext(file)) + artifactExt
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		fail(err)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/apexlang/apex-go/model"
+)
+
+// convert writes the model of a specification, or converts a model
+// written by convert to another format.
+func convert(args []string) {
+	flags := flag.NewFlagSet("convert", flag.ExitOnError)
+	roots := flags.String("roots", "", "comma separated import roots (default: the directory of the file)")
+	format := flags.String("format", "json", "output format: json, yaml, msgpack or apex")
+	from := flags.String("from", "", "input format: apex, artifact, json, yaml or msgpack (default: from the file extension)")
+	output := flags.String("o", "", "write the output to a file instead of stdout")
+	out := outputFlags(flags)
+	usage(flags, "file")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(exitFailure)
+	}
+	var to model.Format
+	var err error
+	if *format != "apex" {
+		if to, err = model.ParseFormat(*format); err != nil {
+			fail(err)
+		}
+	}
+
+	path := flags.Arg(0)
+	if *from == "" {
+		*from = inputFormat(path)
+	}
+	var ns *model.Namespace
+	if *from == "apex" {
+		ws, _ := loadSpec(path, *roots, out)
+		namespaces := ws.Namespaces()
+		if len(namespaces) == 0 {
+			fail(fmt.Errorf("%s does not define a namespace", path))
+		}
+		ns = namespaces[0].Model
+	} else {
+		ns, err = loadModel(path, *from)
+		if err != nil {
+			fail(err)
+		}
+	}
+
+	var data []byte
+	if *format == "apex" {
+		data, err = model.Print(ns)
+	} else {
+		data, err = model.MarshalNamespace(ns, to)
+	}
+	if err != nil {
+		fail(err)
+	}
+	if *output != "" {
+		err = os.WriteFile(*output, data, 0o644)
+	} else if !out.quiet {
+		_, err = os.Stdout.Write(data)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+// inputFormat returns the format of a file from its extension.
+func inputFormat(path string) string {
+	switch filepath.Ext(path) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".msgpack":
+		return "msgpack"
+	case artifactExt:
+		return "artifact"
+	}
+	return "apex"
+}
+
+// loadModel reads a model written by convert in format, or by compile.
+func loadModel(path, format string) (*model.Namespace, error) {
+	var f model.Format
+	var err error
+	if format != "artifact" {
+		if f, err = model.ParseFormat(format); err != nil {
+			return nil, err
+		}
+	}
+	var data []byte
+	if path == stdin {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if format == "artifact" {
+		artifact, err := model.Load(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return artifact.Namespace, nil
+	}
+	ns, err := model.UnmarshalNamespace(data, f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return ns, nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/apexlang/apex-go/analysis"
+)
+
+// diff compares two versions of a specification.
+func diff(args []string) {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	roots := flags.String("roots", "", "comma separated import roots (default: the directory of each file)")
+	breaking := flags.Bool("breaking", false, "only fail on breaking changes")
+	out := outputFlags(flags)
+	usage(flags, "old new")
+	flags.Parse(args)
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(exitFailure)
+	}
+
+	from, fromFile := loadSpec(flags.Arg(0), *roots, out)
+	to, toFile := loadSpec(flags.Arg(1), *roots, out)
+	report := analysis.Diff(from.Document(fromFile), to.Document(toFile))
+
+	switch {
+	case out.quiet:
+	case out.json:
+		data, err := report.MarshalJSON()
+		if err != nil {
+			fail(err)
+		}
+		os.Stdout.Write(append(data, '\n'))
+	default:
+		report.WriteText(os.Stdout)
+	}
+	if (*breaking && report.Breaking()) || (!*breaking && len(report.Changes) > 0) {
+		os.Exit(exitProblems)
+	}
+}
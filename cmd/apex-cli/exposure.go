@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/apexlang/apex-go/analysis"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+)
+
+// exposure reads a spec from stdin and reports which operations return or
+// accept classified data.
+func exposure(args []string) {
+	flags := flag.NewFlagSet("exposure", flag.ExitOnError)
+	format := flags.String("format", "text", "report format: text or json")
+	classes := flags.String("classifications", strings.Join(analysis.DefaultClassifications, ","),
+		"comma separated annotations that mark sensitive data")
+	check := flags.Bool("check", false, "fail when @public interfaces expose @secret data")
+	flags.Parse(args)
+
+	specBytes, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fail(err)
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: string(specBytes),
+	})
+	if err != nil {
+		problems(err)
+	}
+
+	report := analysis.Exposures(doc, strings.Split(*classes, ",")...)
+	switch *format {
+	case "json":
+		jsonBytes, err := report.MarshalJSON()
+		if err != nil {
+			fail(err)
+		}
+		os.Stdout.Write(jsonBytes)
+	case "text":
+		report.WriteText(os.Stdout)
+	default:
+		fail(fmt.Errorf("unknown format %q", *format))
+	}
+
+	if *check {
+		if errs := rules.Validate(doc, rules.ClassificationPolicy()); len(errs) > 0 {
+			problems(errs...)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+
+	"github.com/CosmWasm/tinyjson/jwriter"
+
+	"github.com/apexlang/apex-go/format"
+	"github.com/apexlang/apex-go/source"
+)
+
+// formatFiles prints specifications in canonical form.
+func formatFiles(args []string) {
+	flags := flag.NewFlagSet("fmt", flag.ExitOnError)
+	write := flags.Bool("w", false, "write the result to the files instead of stdout")
+	list := flags.Bool("l", false, "list the files whose formatting differs")
+	check := flags.Bool("check", false, "fail when a file is not formatted, without printing it")
+	out := outputFlags(flags)
+	usage(flags, "[file or directory...]")
+	flags.Parse(args)
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{stdin}
+	}
+	files, err := specFiles(paths)
+	if err != nil {
+		fail(err)
+	}
+
+	var unformatted []string
+	var diags []fileError
+	for _, path := range files {
+		var body []byte
+		if path == stdin {
+			body, err = io.ReadAll(os.Stdin)
+		} else {
+			body, err = os.ReadFile(path)
+		}
+		if err != nil {
+			fail(err)
+		}
+		formatted, err := format.Source(source.NewSource(path, body))
+		if err != nil {
+			diags = append(diags, fileError{path, err})
+			continue
+		}
+		changed := !bytes.Equal(body, formatted)
+		if changed {
+			unformatted = append(unformatted, path)
+		}
+		switch {
+		case *write && path != stdin:
+			if changed {
+				if err := os.WriteFile(path, formatted, 0o644); err != nil {
+					fail(err)
+				}
+			}
+		case *list || *check || out.quiet:
+		default:
+			os.Stdout.Write(formatted)
+		}
+	}
+
+	if len(diags) > 0 {
+		out.diagnostics(os.Stderr, diags)
+	}
+	if *list && !out.quiet {
+		if out.json {
+			w := jwriter.Writer{}
+			w.RawByte('[')
+			for i, path := range unformatted {
+				if i > 0 {
+					w.RawByte(',')
+				}
+				w.String(path)
+			}
+			w.RawString("]\n")
+			os.Stdout.Write(w.Buffer.BuildBytes())
+		} else {
+			for _, path := range unformatted {
+				os.Stdout.WriteString(path + "\n")
+			}
+		}
+	}
+	if len(diags) > 0 || ((*check || *list) && len(unformatted) > 0) {
+		os.Exit(exitProblems)
+	}
+}
@@ -17,50 +17,103 @@ limitations under the License.
 package main
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"text/tabwriter"
 
-	"github.com/apexlang/apex-go/errors"
 	"github.com/apexlang/apex-go/model"
 	"github.com/apexlang/apex-go/parser"
 	"github.com/apexlang/apex-go/rules"
 )
 
+var commands = []struct {
+	name        string
+	description string
+	run         func(args []string)
+}{
+	{"validate", "report the errors and warnings of specifications", validate},
+	{"convert", "write the model of a specification as JSON, YAML or msgpack, or a model as Apex", convert},
+	{"compile", "write the model of a specification as a precompiled artifact", compile},
+	{"fmt", "print specifications in canonical form", formatFiles},
+	{"diff", "compare two versions of a specification", diff},
+	{"bundle", "inline the imports of a specification into one file", bundleSpec},
+	{"rules", "list the validation rules", listRules},
+	{"coverage", "report the documentation coverage of a specification", coverage},
+	{"exposure", "report the classified data operations expose", exposure},
+	{"rename", "rename a definition across specifications", rename},
+	{"watch", "validate specifications whenever they change", watch},
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "usage: apex-cli <command> [flags] [arguments]")
+	fmt.Fprintln(os.Stderr, "\nWithout a command, a specification is read from stdin and its model")
+	fmt.Fprintln(os.Stderr, "is written to stdout as JSON.\n\nCommands:")
+	tw := tabwriter.NewWriter(os.Stderr, 0, 4, 2, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(tw, "  %s\t%s\n", c.name, c.description)
+	}
+	tw.Flush()
+	fmt.Fprintln(os.Stderr, "\nRun apex-cli <command> -h for the flags of a command. Commands exit with")
+	fmt.Fprintln(os.Stderr, "status 1 when specifications have errors, differ or are not formatted, and")
+	fmt.Fprintln(os.Stderr, "status 2 on bad usage or files that cannot be read or written.")
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		switch name := os.Args[1]; name {
+		case "help", "-h", "-help", "--help":
+			printUsage()
+			return
+		default:
+			for _, c := range commands {
+				if c.name == name {
+					c.run(os.Args[2:])
+					os.Exit(exitOK)
+				}
+			}
+			fmt.Fprintf(os.Stderr, "apex-cli: unknown command %q\n", name)
+			printUsage()
+			os.Exit(exitFailure)
+		}
+	}
+
 	specBytes, err := io.ReadAll(os.Stdin)
 	if err != nil {
-		errors.Write(err)
-		return
+		fail(err)
 	}
+	jsonBytes, errs := modelJSON(specBytes)
+	if len(errs) > 0 {
+		problems(errs...)
+	}
+	os.Stdout.Write(jsonBytes)
+}
 
+// modelJSON validates a specification and returns its model as JSON.
+func modelJSON(spec []byte) ([]byte, []error) {
 	doc, err := parser.Parse(parser.ParseParams{
-		Source: string(specBytes),
+		Source: string(spec),
 		Options: parser.ParseOptions{
 			NoSource: true,
 		},
 	})
 	if err != nil {
-		errors.Write(err)
-		return
+		return nil, []error{err}
 	}
 
 	errs := rules.Validate(doc, rules.Rules...)
 	if len(errs) > 0 {
-		errors.Write(errs...)
-		return
+		return nil, errs
 	}
 
 	ns, errs := model.Convert(doc)
 	if len(errs) > 0 {
-		errors.Write(errs...)
-		return
+		return nil, errs
 	}
 
 	jsonBytes, err := ns.MarshalJSON()
 	if err != nil {
-		errors.Write(err)
-		return
+		return nil, []error{err}
 	}
-
-	os.Stdout.Write(jsonBytes)
+	return jsonBytes, nil
 }
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain runs the command instead of the tests when the test binary is
+// started by run.
+func TestMain(m *testing.M) {
+	if args, ok := os.LookupEnv("APEX_CLI_ARGS"); ok {
+		os.Args = append([]string{"apex-cli"}, strings.Fields(args)...)
+		main()
+		os.Exit(exitOK)
+	}
+	os.Exit(m.Run())
+}
+
+// run runs the command with args and stdin and returns its output and
+// exit code.
+func run(t *testing.T, stdin string, args ...string) (string, string, int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), "APEX_CLI_ARGS="+strings.Join(args, " "))
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exit *exec.ExitError
+	if err != nil && !errors.As(err, &exit) {
+		t.Fatal(err)
+	}
+	return stdout.String(), stderr.String(), cmd.ProcessState.ExitCode()
+}
+
+func TestModelJSON(t *testing.T) {
+	data, errs := modelJSON([]byte(`namespace "shop"
+
+type Item {
+  name: string
+}
+`))
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if !strings.Contains(string(data), `"name":"shop"`) {
+		t.Errorf("model = %s", data)
+	}
+
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{`type {`, "Syntax Error"},
+		{`type Item { name: string }`, "no namespace found"},
+		{`namespace "shop"
+alias Item = Missing`, `unknown type "Missing"`},
+	}
+	for _, tt := range tests {
+		_, errs := modelJSON([]byte(tt.spec))
+		if len(errs) == 0 || !strings.Contains(errs[0].Error(), tt.want) {
+			t.Errorf("modelJSON(%q) errors = %v, want %q", tt.spec, errs, tt.want)
+		}
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdin  string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{"stdin", `namespace "shop"`, nil, exitOK, ""},
+		{"stdin syntax error", `type {`, nil, exitProblems, `"message":"Syntax Error`},
+		{"stdin invalid", `type Item { name: string }`, nil, exitProblems, "no namespace found"},
+		{"coverage", `namespace "shop"`, []string{"coverage"}, exitOK, ""},
+		{"coverage required", `namespace "shop"
+interface Store { get(): string }`, []string{"coverage", "-require"}, exitProblems, "Store"},
+		{"coverage format", `namespace "shop"`, []string{"coverage", "-format", "xml"}, exitFailure, `unknown format "xml"`},
+		{"fmt list", "namespace \"shop\"\n", []string{"fmt", "-l"}, exitOK, ""},
+		{"fmt list unformatted", `namespace   "shop"`, []string{"fmt", "-l"}, exitProblems, ""},
+		{"fmt check unformatted", `namespace   "shop"`, []string{"fmt", "-check"}, exitProblems, ""},
+		{"exposure syntax error", `type {`, []string{"exposure"}, exitProblems, "Syntax Error"},
+		{"rename position", ``, []string{"rename", "spec.apex", "Name"}, exitFailure, "expected file:line:column"},
+		{"unknown command", ``, []string{"nope"}, exitFailure, `unknown command "nope"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, code := run(t, tt.stdin, tt.args...)
+			if code != tt.code {
+				t.Errorf("exit code = %d, want %d; stderr: %s", code, tt.code, stderr)
+			}
+			if !strings.Contains(stderr, tt.stderr) {
+				t.Errorf("stderr = %q, want %q", stderr, tt.stderr)
+			}
+		})
+	}
+}
+
+func TestImportedErrors(t *testing.T) {
+	dir := t.TempDir()
+	main := filepath.Join(dir, "a.apex")
+	writeFile(t, main, `namespace "a"
+import * from "./common.apex"
+
+type Item {
+  id: ID
+}
+`)
+	writeFile(t, filepath.Join(dir, "common.apex"), `alias ID = string`)
+
+	// common.apex is not named on the command line.
+	want := "imported specification " + filepath.Join(dir, "common.apex") + " has errors"
+	for _, command := range []string{"validate", "convert"} {
+		stdout, stderr, code := run(t, "", command, main)
+		if code != exitProblems {
+			t.Errorf("%s exit code = %d, want %d; stderr: %s", command, code, exitProblems, stderr)
+		}
+		if out := stdout + stderr; !strings.Contains(out, want) {
+			t.Errorf("%s output = %q, want %q", command, out, want)
+		}
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.apex")
+	writeFile(t, path, `namespace "shop"
+enum Level { low = 0 }
+`)
+	stdout, stderr, code := run(t, "", "validate", "-lint", path)
+	if code != exitOK {
+		t.Errorf("exit code = %d, want %d; stderr: %s", code, exitOK, stderr)
+	}
+	want := path + `:2:14: Validation Warning: enum value "low" should be screaming snake case or pascal case: "LOW"` + "\n"
+	if stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+}
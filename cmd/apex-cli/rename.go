@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/refactor"
+	"github.com/apexlang/apex-go/resolver"
+	"github.com/apexlang/apex-go/source"
+)
+
+// rename renames the definition at file:line:column to a new name across
+// the specifications in a directory.
+func rename(args []string) {
+	flags := flag.NewFlagSet("rename", flag.ExitOnError)
+	dir := flags.String("dir", ".", "workspace directory containing the specifications")
+	write := flags.Bool("w", false, "write the changes instead of listing them")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: apex-cli rename [-dir dir] [-w] file:line:column name")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() != 2 {
+		flags.Usage()
+		os.Exit(exitFailure)
+	}
+
+	filename, line, column, err := parsePosition(flags.Arg(0))
+	if err != nil {
+		fail(err)
+	}
+	root, err := filepath.Abs(*dir)
+	if err != nil {
+		fail(err)
+	}
+	if filename, err = filepath.Abs(filename); err != nil {
+		fail(err)
+	}
+
+	docs, err := parseWorkspace(root)
+	if err != nil {
+		problems(err)
+	}
+	var target *ast.Document
+	for _, doc := range docs {
+		if doc.GetLoc().Source.Name == filename {
+			target = doc
+		}
+	}
+	if target == nil {
+		fail(fmt.Errorf("%s is not in %s", filename, root))
+	}
+
+	offset, ok := target.GetLoc().Source.Offset(line, column, source.Bytes)
+	if !ok {
+		fail(fmt.Errorf("%s has no position %d:%d", filename, line, column))
+	}
+	files, err := refactor.Rename(docs, filename, offset, flags.Arg(1))
+	if err != nil {
+		problems(err)
+	}
+
+	if !*write {
+		for _, f := range files {
+			for _, e := range f.Edits {
+				l, c := f.Source.LineColumn(e.Start, source.Bytes)
+				fmt.Printf("%s:%d:%d: %s -> %s\n", relative(f.Source.Name), l, c, f.Source.Body[e.Start:e.End], e.NewText)
+			}
+		}
+		return
+	}
+	bodies, err := refactor.Apply(files)
+	if err != nil {
+		fail(err)
+	}
+	for name, body := range bodies {
+		if err := os.WriteFile(name, body, 0o644); err != nil {
+			fail(err)
+		}
+	}
+}
+
+// parseWorkspace parses every .apex file under root, resolving imports
+// from root.
+func parseWorkspace(root string) ([]*ast.Document, error) {
+	resolve := resolver.NewFileSystem(root)
+	var docs []*ast.Document
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".apex" {
+			return err
+		}
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		doc, err := parser.Parse(parser.ParseParams{
+			Source: source.NewSource(path, body),
+			Options: parser.ParseOptions{
+				Resolver: resolve.Resolve,
+				Locator:  resolve.Locate,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+		return nil
+	})
+	return docs, err
+}
+
+// parsePosition splits file:line:column.
+func parsePosition(s string) (string, uint, uint, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 3 {
+		return "", 0, 0, fmt.Errorf("expected file:line:column, got %q", s)
+	}
+	n := len(parts)
+	line, err := strconv.ParseUint(parts[n-2], 10, 32)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("invalid line in %q", s)
+	}
+	column, err := strconv.ParseUint(parts[n-1], 10, 32)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("invalid column in %q", s)
+	}
+	return strings.Join(parts[:n-2], ":"), uint(line), uint(column), nil
+}
+
+func relative(path string) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "testing"
+
+func TestParsePosition(t *testing.T) {
+	tests := []struct {
+		arg          string
+		file         string
+		line, column uint
+		err          string
+	}{
+		{arg: "spec.apex:3:14", file: "spec.apex", line: 3, column: 14},
+		{arg: `C:\specs\spec.apex:1:2`, file: `C:\specs\spec.apex`, line: 1, column: 2},
+		{arg: "spec.apex:3", err: `expected file:line:column, got "spec.apex:3"`},
+		{arg: "spec.apex:x:1", err: `invalid line in "spec.apex:x:1"`},
+		{arg: "spec.apex:1:-1", err: `invalid column in "spec.apex:1:-1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			file, line, column, err := parsePosition(tt.arg)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if file != tt.file || line != tt.line || column != tt.column {
+				t.Errorf("parsePosition = %q, %d, %d", file, line, column)
+			}
+		})
+	}
+}
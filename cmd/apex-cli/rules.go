@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/CosmWasm/tinyjson/jwriter"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+// Rule sets. Default rules always run, lints run with -lint and optional
+// rules only when enabled by name.
+const (
+	setDefault  = "default"
+	setLint     = "lint"
+	setOptional = "optional"
+)
+
+// ruleInfo describes a validation rule that can be selected by name.
+type ruleInfo struct {
+	name        string
+	set         string
+	description string
+	rule        rules.ValidationRule
+}
+
+var ruleCatalog = []ruleInfo{
+	{"camel-case-directive-names", setDefault, "directive names are camelCase", rules.CamelCaseDirectiveNames},
+	{"known-types", setDefault, "referenced types are defined or imported", rules.KnownTypes},
+	{"namespace-first", setDefault, "the namespace is the first definition", rules.NamespaceFirst},
+	{"pascal-case-type-names", setDefault, "type names are PascalCase", rules.PascalCaseTypeNames},
+	{"single-namespace-defined", setDefault, "a specification defines one namespace", rules.SingleNamespaceDefined},
+	{"unique-enum-value-indexes", setDefault, "enum value indexes are unique", rules.UniqueEnumValueIndexes},
+	{"unique-enum-value-names", setDefault, "enum value names are unique", rules.UniqueEnumValueNames},
+	{"unique-function-names", setDefault, "function names are unique", rules.UniqueFunctionNames},
+	{"unique-object-names", setDefault, "interface, type, union, enum and alias names are unique", rules.UniqueObjectNames},
+	{"unique-operation-names", setDefault, "operation names are unique within an interface", rules.UniqueOperationNames},
+	{"unique-parameter-names", setDefault, "parameter names are unique within an operation", rules.UniqueParameterNames},
+	{"unique-type-field-names", setDefault, "field names are unique within a type", rules.UniqueTypeFieldNames},
+	{"valid-annotation-arguments", setDefault, "annotation arguments match their directive", rules.ValidAnnotationArguments},
+	{"valid-annotation-locations", setDefault, "annotations are used where their directive allows", rules.ValidAnnotationLocations},
+	{"valid-directive-locations", setDefault, "directive locations are known", rules.ValidDirectiveLocation},
+	{"valid-directive-parameter-types", setDefault, "directive parameters have supported types", rules.ValidDirectiveParameterTypes},
+	{"valid-directive-requires", setDefault, "directives required by a directive are defined", rules.ValidDirectiveRequires},
+	{"valid-enum-value-indexes", setDefault, "enum value indexes are not negative", rules.ValidEnumValueIndexes},
+	{"valid-imports", setDefault, "imported names are defined and namespace aliases are unique", rules.ValidImports},
+	{"deprecated-usage", setLint, "warns about references to @deprecated elements", rules.DeprecatedUsage},
+	{"import-shadowing", setLint, "warns about imported definitions with the name of a local one", rules.ImportShadowing},
+	{"naming-conventions", setLint, "warns about names that do not follow the conventions", rules.NamingConventions(rules.DefaultNamingConfig())},
+	{"reserved-words", setLint, "warns about names reserved in target languages", rules.ReservedWordCollisions()},
+	{"unique-imports", setLint, "warns about repeated imports and names imported twice", rules.UniqueImports},
+	{"unused-imports", setLint, "warns about imports that are never used", rules.UnusedImports},
+	{"documented-interfaces", setOptional, "public interfaces and operations have descriptions", rules.DocumentedInterfaces},
+	{"classification-policy", setOptional, "operations do not expose forbidden classified data", rules.ClassificationPolicy()},
+	{"unique-directive-names", setOptional, "directive names are unique", rules.UniqueDirectiveNames},
+}
+
+// selectRules returns the default rules, the lints when lint is true, and
+// the rules named in enable, less those named in disable. Both are comma
+// separated.
+func selectRules(lint bool, enable, disable string) ([]rules.ValidationRule, error) {
+	enabled, err := ruleNames(enable)
+	if err != nil {
+		return nil, err
+	}
+	disabled, err := ruleNames(disable)
+	if err != nil {
+		return nil, err
+	}
+	var selected []rules.ValidationRule
+	for _, r := range ruleCatalog {
+		on := r.set == setDefault || (lint && r.set == setLint) || enabled[r.name]
+		if on && !disabled[r.name] {
+			selected = append(selected, r.rule)
+		}
+	}
+	return selected, nil
+}
+
+func ruleNames(list string) (map[string]bool, error) {
+	names := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		known := false
+		for _, r := range ruleCatalog {
+			known = known || r.name == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown rule %q", name)
+		}
+		names[name] = true
+	}
+	return names, nil
+}
+
+// listRules prints the rules validate can run.
+func listRules(args []string) {
+	flags := flag.NewFlagSet("rules", flag.ExitOnError)
+	out := outputFlags(flags)
+	usage(flags, "")
+	flags.Parse(args)
+	if flags.NArg() != 0 {
+		flags.Usage()
+		os.Exit(exitFailure)
+	}
+	if out.quiet {
+		return
+	}
+
+	if out.json {
+		w := jwriter.Writer{}
+		w.RawByte('[')
+		for i, r := range ruleCatalog {
+			if i > 0 {
+				w.RawByte(',')
+			}
+			w.RawString(`{"name":`)
+			w.String(r.name)
+			w.RawString(`,"set":`)
+			w.String(r.set)
+			w.RawString(`,"description":`)
+			w.String(r.description)
+			w.RawByte('}')
+		}
+		w.RawString("]\n")
+		os.Stdout.Write(w.Buffer.BuildBytes())
+		return
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSET\tDESCRIPTION")
+	for _, r := range ruleCatalog {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.name, r.set, r.description)
+	}
+	tw.Flush()
+}
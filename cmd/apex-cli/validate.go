@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"os"
+)
+
+// validate reports the errors and warnings of specifications.
+func validate(args []string) {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	roots := flags.String("roots", "", "comma separated import roots (default: the directories of the files)")
+	lint := flags.Bool("lint", false, "also run the lint rules")
+	enable := flags.String("enable", "", "comma separated rules to run in addition, see apex-cli rules")
+	disable := flags.String("disable", "", "comma separated rules not to run")
+	strict := flags.Bool("strict", false, "fail on warnings as well as errors")
+	out := outputFlags(flags)
+	usage(flags, "file or directory...")
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(exitFailure)
+	}
+
+	validation, err := selectRules(*lint, *enable, *disable)
+	if err != nil {
+		fail(err)
+	}
+	files, err := specFiles(flags.Args())
+	if err != nil {
+		fail(err)
+	}
+	ws, err := load(files, *roots, validation)
+	if err != nil {
+		fail(err)
+	}
+
+	diags, failed := collect(ws, files)
+	out.diagnostics(os.Stdout, diags)
+	if failed || (*strict && len(diags) > 0) {
+		os.Exit(exitProblems)
+	}
+}
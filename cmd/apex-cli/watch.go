@@ -0,0 +1,270 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/workspace"
+)
+
+// watch validates specifications and everything they import whenever one
+// of the files changes, printing the diagnostics of the affected files.
+func watch(args []string) {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	roots := flags.String("roots", "", "comma separated import roots (default: the watched directories)")
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often to check the files for changes")
+	debounce := flags.Duration("debounce", 200*time.Millisecond, "how long the files must be unchanged before validating")
+	lint := flags.Bool("lint", false, "also report lint warnings")
+	var gens generators
+	flags.Var(&gens, "gen", "`spec=command` to run when spec or its imports change without errors; may be repeated")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: apex-cli watch [flags] file or directory...")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(exitFailure)
+	}
+
+	w := newWatcher(flags.Args(), *roots, gens)
+	if *lint {
+		w.ws.Rules = append(append([]rules.ValidationRule{}, rules.Rules...), rules.Lints...)
+	}
+	if err := w.start(os.Stdout); err != nil {
+		fail(err)
+	}
+
+	var pending map[string]bool
+	var last time.Time
+	for {
+		time.Sleep(*interval)
+		states := w.scan()
+		changed := changedFiles(w.states, states)
+		w.states = states
+		if len(changed) > 0 {
+			if pending == nil {
+				pending = make(map[string]bool)
+			}
+			for _, path := range changed {
+				pending[path] = true
+			}
+			last = time.Now()
+			continue
+		}
+		if pending != nil && time.Since(last) >= *debounce {
+			w.update(os.Stdout, pending)
+			pending = nil
+		}
+	}
+}
+
+// watcher polls the files of a workspace for changes.
+type watcher struct {
+	ws     *workspace.Workspace
+	paths  []string
+	gens   generators
+	states map[string]fileState
+	// failing are the files that had diagnostics when last checked.
+	failing map[string]bool
+}
+
+// fileState is what a poll records of a file to notice changes.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func newWatcher(paths []string, roots string, gens generators) *watcher {
+	var dirs []string
+	if roots != "" {
+		dirs = strings.Split(roots, ",")
+	} else {
+		for _, path := range paths {
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				path = filepath.Dir(path)
+			}
+			dirs = append(dirs, path)
+		}
+	}
+	return &watcher{
+		ws:      workspace.New(dirs...),
+		paths:   paths,
+		gens:    gens,
+		failing: make(map[string]bool),
+	}
+}
+
+// start loads the watched files, checks them and their imports and
+// records their state.
+func (w *watcher) start(out io.Writer) error {
+	if err := w.ws.Load(w.paths...); err != nil {
+		return err
+	}
+	// Compiling the loaded files adds their imports to the workspace.
+	for _, path := range w.ws.Files() {
+		w.ws.Imports(path)
+	}
+	w.check(out, w.ws.Files())
+	w.states = w.scan()
+	return nil
+}
+
+// scan records the state of the watched files, their imports and any new
+// specifications in the watched directories.
+func (w *watcher) scan() map[string]fileState {
+	// Loading again picks up new files.
+	w.ws.Load(w.paths...)
+	states := make(map[string]fileState)
+	for _, path := range w.ws.Files() {
+		if info, err := os.Stat(path); err == nil {
+			states[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return states
+}
+
+// changedFiles returns the files that were added, changed or removed
+// between two scans.
+func changedFiles(before, after map[string]fileState) []string {
+	var changed []string
+	for path, state := range after {
+		if prev, ok := before[path]; !ok || prev != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// update validates the files affected by changes and runs the generators
+// of the affected specifications.
+func (w *watcher) update(out io.Writer, changed map[string]bool) {
+	affected := make(map[string]bool)
+	added := false
+	for path := range changed {
+		var paths []string
+		if _, err := os.Stat(path); err != nil && w.loaded(path) {
+			paths = w.ws.Remove(path)
+			delete(w.failing, path)
+			fmt.Fprintf(out, "%s: removed\n", path)
+		} else if paths = w.ws.Changed(path); paths == nil {
+			// New to the workspace or unchanged content.
+			paths = []string{path}
+			added = true
+		}
+		for _, p := range paths {
+			affected[p] = true
+		}
+	}
+	if added {
+		// A new file may be an import that could not be resolved before.
+		for path := range w.failing {
+			affected[path] = true
+		}
+	}
+
+	var paths []string
+	for _, path := range w.ws.Files() {
+		if affected[path] {
+			paths = append(paths, path)
+		}
+	}
+	w.check(out, paths)
+	for _, g := range w.gens {
+		if affected[g.spec] && !errors.HasErrors(w.ws.Diagnostics(g.spec)) {
+			g.run(out)
+		}
+	}
+}
+
+func (w *watcher) loaded(path string) bool {
+	for _, p := range w.paths {
+		if rel, err := filepath.Rel(p, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return true
+		}
+	}
+	return false
+}
+
+// check prints the diagnostics of files.
+func (w *watcher) check(out io.Writer, paths []string) {
+	problems := 0
+	for _, path := range paths {
+		diags := w.ws.Diagnostics(path)
+		if len(diags) == 0 {
+			delete(w.failing, path)
+			fmt.Fprintf(out, "%s: ok\n", path)
+			continue
+		}
+		w.failing[path] = true
+		problems += len(diags)
+		for _, err := range diags {
+			fmt.Fprintln(out, diagnostic(path, err))
+		}
+	}
+	fmt.Fprintf(out, "%s: checked %d files, %d problems\n", time.Now().Format(time.TimeOnly), len(paths), problems)
+}
+
+// generator is a command to run when a specification changes.
+type generator struct {
+	spec    string
+	command []string
+}
+
+func (g generator) run(out io.Writer) {
+	fmt.Fprintf(out, "%s: running %s\n", g.spec, strings.Join(g.command, " "))
+	cmd := exec.Command(g.command[0], g.command[1:]...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(out, "%s: %s: %v\n", g.spec, g.command[0], err)
+	}
+}
+
+// generators implements flag.Value for repeated -gen flags.
+type generators []generator
+
+func (g *generators) String() string {
+	return ""
+}
+
+func (g *generators) Set(value string) error {
+	spec, command, ok := strings.Cut(value, "=")
+	fields := strings.Fields(command)
+	if !ok || spec == "" || len(fields) == 0 {
+		return fmt.Errorf("expected spec=command, got %q", value)
+	}
+	*g = append(*g, generator{spec: filepath.Clean(spec), command: fields})
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// printed returns the lines written by the watcher with the paths made
+// relative to dir and the time removed.
+func printed(dir string, out *bytes.Buffer) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		line = strings.ReplaceAll(line, dir+string(filepath.Separator), "")
+		if i := strings.Index(line, ": checked "); i >= 0 {
+			line = line[i+2:]
+		}
+		lines = append(lines, line)
+	}
+	out.Reset()
+	return lines
+}
+
+func writeFile(t *testing.T, path, text string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatcherUpdate(t *testing.T) {
+	dir := t.TempDir()
+	main := filepath.Join(dir, "main.apex")
+	types := filepath.Join(dir, "types.apex")
+	writeFile(t, main, `namespace "main"
+import * from "types.apex"
+
+type Order { item: Item }
+`)
+	writeFile(t, types, `namespace "types"
+type Item { name: string }`)
+
+	var gens generators
+	if _, err := exec.LookPath("echo"); err == nil {
+		if err := gens.Set(main + "=echo generated"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w := newWatcher([]string{main}, "", gens)
+	var out bytes.Buffer
+	if err := w.start(&out); err != nil {
+		t.Fatal(err)
+	}
+	// Imports are watched from the start.
+	if _, ok := w.states[types]; !ok {
+		t.Error("import is not watched")
+	}
+	want := []string{"main.apex: ok", "types.apex: ok", "checked 2 files, 0 problems"}
+	if got := printed(dir, &out); !reflect.DeepEqual(got, want) {
+		t.Errorf("initial check = %q, want %q", got, want)
+	}
+
+	// A change to an import checks its importers, which fail while it
+	// has errors.
+	writeFile(t, types, `namespace "types"
+type Item { name: }`)
+	w.update(&out, map[string]bool{types: true})
+	got := printed(dir, &out)
+	if len(got) != 3 ||
+		got[0] != "main.apex: imported specification types.apex has errors" ||
+		!strings.HasPrefix(got[1], "types.apex:2:20: Syntax Error") ||
+		got[2] != "checked 2 files, 2 problems" {
+		t.Errorf("update with errors = %q", got)
+	}
+
+	writeFile(t, types, `namespace "types"
+type Item { name: string }`)
+	w.update(&out, map[string]bool{types: true})
+	want = []string{"main.apex: ok", "types.apex: ok", "checked 2 files, 0 problems"}
+	if len(gens) > 0 {
+		want = append(want, "main.apex: running echo generated", "generated")
+	}
+	if got := printed(dir, &out); !reflect.DeepEqual(got, want) {
+		t.Errorf("update = %q, want %q", got, want)
+	}
+
+	// Saving a file without changing it checks it again.
+	w.update(&out, map[string]bool{main: true})
+	want = []string{"main.apex: ok", "checked 1 files, 0 problems"}
+	if len(gens) > 0 {
+		want = append(want, "main.apex: running echo generated", "generated")
+	}
+	if got := printed(dir, &out); !reflect.DeepEqual(got, want) {
+		t.Errorf("update without changes = %q, want %q", got, want)
+	}
+}
+
+func TestWatcherAddRemove(t *testing.T) {
+	dir := t.TempDir()
+	main := filepath.Join(dir, "main.apex")
+	types := filepath.Join(dir, "types.apex")
+	writeFile(t, main, `namespace "main"
+import * from "./types.apex"
+`)
+
+	w := newWatcher([]string{dir}, "", nil)
+	if err := w.start(io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if !w.failing[main] {
+		t.Fatal("unresolved import was not reported")
+	}
+
+	// Adding the missing import checks the files that failed.
+	writeFile(t, types, `namespace "types"`)
+	states := w.scan()
+	changed := changedFiles(w.states, states)
+	w.states = states
+	if want := []string{types}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("changedFiles() = %q, want %q", changed, want)
+	}
+	var out bytes.Buffer
+	w.update(&out, map[string]bool{types: true})
+	want := []string{"main.apex: ok", "types.apex: ok", "checked 2 files, 0 problems"}
+	if got := printed(dir, &out); !reflect.DeepEqual(got, want) {
+		t.Errorf("update after adding = %q, want %q", got, want)
+	}
+
+	if err := os.Remove(types); err != nil {
+		t.Fatal(err)
+	}
+	w.update(&out, map[string]bool{types: true})
+	got := printed(dir, &out)
+	if len(got) != 3 || got[0] != "types.apex: removed" ||
+		!strings.HasPrefix(got[1], "main.apex: ") || got[2] != "checked 1 files, 1 problems" {
+		t.Errorf("update after removing = %q", got)
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	now := time.Now()
+	before := map[string]fileState{
+		"a": {modTime: now, size: 1},
+		"b": {modTime: now, size: 1},
+		"c": {modTime: now, size: 1},
+	}
+	after := map[string]fileState{
+		"a": {modTime: now, size: 1},
+		"b": {modTime: now.Add(time.Second), size: 1},
+		"d": {modTime: now, size: 1},
+	}
+	if got, want := changedFiles(before, after), []string{"b", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("changedFiles() = %q, want %q", got, want)
+	}
+}
+
+func TestGeneratorsSet(t *testing.T) {
+	var gens generators
+	if err := gens.Set("specs/../spec.apex=apex generate  -v"); err != nil {
+		t.Fatal(err)
+	}
+	want := generators{{spec: "spec.apex", command: []string{"apex", "generate", "-v"}}}
+	if !reflect.DeepEqual(gens, want) {
+		t.Errorf("generators = %v, want %v", gens, want)
+	}
+	for _, value := range []string{"spec.apex", "=apex", "spec.apex= "} {
+		if err := gens.Set(value); err == nil {
+			t.Errorf("Set(%q) succeeded", value)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/query"
+	"github.com/apexlang/apex-go/resolver"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+// document is an open text document and the result of parsing it.
+type document struct {
+	uri    string
+	path   string
+	source *source.Source
+
+	// doc and idx are nil when the text does not parse.
+	doc *ast.Document
+	idx *query.Index
+	// lastIdx is the index of the last text that parsed. It is used for
+	// completion while the text is being edited.
+	lastIdx *query.Index
+
+	diagnostics []Diagnostic
+}
+
+func newDocument(uri string) *document {
+	return &document{uri: uri, path: uriToPath(uri)}
+}
+
+// update replaces the text of d, parses and validates it.
+func (d *document) update(text string, fs *resolver.FileSystem) {
+	d.source = source.NewSource(d.path, []byte(text))
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  d.source,
+		Options: d.parseOptions(fs),
+	})
+	d.validate(doc, err, fs)
+}
+
+// change applies incremental changes to the text of d. While the text
+// parses, only the definitions touched by a change are parsed again.
+func (d *document) change(changes []TextDocumentContentChangeEvent, fs *resolver.FileSystem) {
+	doc := d.doc
+	src := d.source
+	for _, c := range changes {
+		if c.Range == nil {
+			src = source.NewSource(d.path, []byte(c.Text))
+			doc = nil
+			continue
+		}
+		start, _ := src.Offset(c.Range.Start.Line+1, c.Range.Start.Character+1, source.UTF16)
+		end, _ := src.Offset(c.Range.End.Line+1, c.Range.End.Character+1, source.UTF16)
+		edit := source.Edit{Start: start, End: end, NewText: c.Text}
+		if doc != nil {
+			next, _, err := parser.Reparse(parser.ReparseParams{
+				Document: doc,
+				Edit:     edit,
+				Options:  d.parseOptions(fs),
+			})
+			if err == nil {
+				doc, src = next, next.Loc.Source
+				continue
+			}
+			doc = nil
+		}
+		body, err := source.ApplyEdits(src.Body, []source.Edit{edit})
+		if err != nil {
+			// The client is out of sync. Keep the text it last sent in full.
+			return
+		}
+		src = source.NewSource(d.path, body)
+	}
+	if doc == nil {
+		d.update(string(src.Body), fs)
+		return
+	}
+	d.source = src
+	d.validate(doc, nil, fs)
+}
+
+func (d *document) parseOptions(fs *resolver.FileSystem) parser.ParseOptions {
+	return parser.ParseOptions{
+		Resolver: fs.Resolve,
+		Locator:  fs.Locate,
+	}
+}
+
+// validate records the result of parsing the text of d and validates it.
+func (d *document) validate(doc *ast.Document, err error, fs *resolver.FileSystem) {
+	d.doc, d.idx = nil, nil
+	d.diagnostics = []Diagnostic{}
+	if err != nil {
+		d.diagnostics = append(d.diagnostics, d.diagnostic(err, fs))
+		return
+	}
+	d.doc = doc
+	d.idx = query.NewIndex(doc)
+	d.lastIdx = d.idx
+
+	for _, err := range rules.Validate(doc, append(rules.Rules, rules.Lints...)...) {
+		if e, ok := err.(*errors.Error); ok && e.Source != nil && e.Source != d.source {
+			// Imported specifications report their own diagnostics.
+			continue
+		}
+		d.diagnostics = append(d.diagnostics, d.diagnostic(err, fs))
+	}
+}
+
+func (d *document) diagnostic(err error, fs *resolver.FileSystem) Diagnostic {
+	diag := Diagnostic{
+		Severity: 1,
+		Source:   "apex",
+		Message:  err.Error(),
+	}
+	e, ok := err.(*errors.Error)
+	if !ok {
+		return diag
+	}
+	// Syntax errors append a highlighted excerpt of the source.
+	diag.Message, _, _ = strings.Cut(e.Message, "\n\n")
+	diag.Severity = DiagnosticSeverity(e.Severity) + 1
+
+	if e.Source != nil && e.Source != d.source {
+		// The error is in an imported specification. Report it on the import.
+		for _, def := range importsOf(d.source) {
+			if name, err := fs.Locate(def.From.Value, d.path); err == nil && name == e.Source.Name {
+				diag.Range = d.rangeOf(def.GetLoc())
+				diag.Message = e.Source.Name + ": " + diag.Message
+				return diag
+			}
+		}
+		return diag
+	}
+
+	start, end := uint(0), uint(0)
+	if len(e.Positions) > 0 {
+		start, end = e.Positions[0], e.Positions[0]
+	}
+	for _, node := range e.Nodes {
+		if loc := node.GetLoc(); loc != nil && loc.Start == start {
+			end = loc.End
+			break
+		}
+	}
+	diag.Range = Range{Start: d.position(start), End: d.position(end)}
+	return diag
+}
+
+// importsOf parses src without resolving imports and returns its imports.
+func importsOf(src *source.Source) []*ast.ImportDefinition {
+	doc, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		return nil
+	}
+	var imports []*ast.ImportDefinition
+	for _, def := range doc.Definitions {
+		if imp, ok := def.(*ast.ImportDefinition); ok {
+			imports = append(imports, imp)
+		}
+	}
+	return imports
+}
+
+func (d *document) rangeOf(loc *ast.Location) Range {
+	if loc == nil {
+		return Range{}
+	}
+	return Range{Start: d.position(loc.Start), End: d.position(loc.End)}
+}
+
+// position converts a byte offset into a zero based line and UTF-16
+// character offset.
+func (d *document) position(offset uint) Position {
+	return offsetToPosition(d.source, offset)
+}
+
+// offset converts an LSP position to a byte offset.
+func (d *document) offset(pos Position) uint {
+	offset, _ := d.source.Offset(pos.Line+1, pos.Character+1, source.UTF16)
+	return offset
+}
+
+func offsetToPosition(src *source.Source, offset uint) Position {
+	line, column := src.LineColumn(offset, source.UTF16)
+	return Position{Line: line - 1, Character: column - 1}
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func pathToURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
@@ -0,0 +1,362 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/format"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/query"
+	"github.com/apexlang/apex-go/source"
+)
+
+var builtinTypes = []string{
+	"i8", "u8", "i16", "u16", "i32", "u32", "i64", "u64", "f32", "f64",
+	"bool", "string", "datetime", "bytes", "any", "raw", "value",
+}
+
+var keywords = []string{
+	"namespace", "import", "directive", "alias", "func", "interface",
+	"type", "union", "enum",
+}
+
+func (s *server) hover(d *document, pos Position) *Hover {
+	if d.idx == nil {
+		return nil
+	}
+	offset := d.offset(pos)
+	path := query.NodeAt(d.doc, offset)
+	name, ok := path.Node().(*ast.Name)
+	if !ok {
+		return nil
+	}
+	def, _ := d.idx.DefinitionAt(offset)
+	var sig string
+	var description *ast.StringValue
+	if def != nil {
+		sig, description = signature(query.Path{def})
+	} else {
+		// Members are not referenced elsewhere; describe their declaration.
+		sig, description = signature(path[:len(path)-1])
+		if sig == "" || query.NameOf(path.Parent()) != name {
+			return nil
+		}
+	}
+	r := d.rangeOf(name.GetLoc())
+	return &Hover{
+		Contents: markdown(sig, description),
+		Range:    &r,
+	}
+}
+
+func markdown(signature string, description *ast.StringValue) MarkupContent {
+	value := "```apex\n" + signature + "\n```"
+	if description != nil {
+		value += "\n\n" + description.Value
+	}
+	return MarkupContent{Kind: "markdown", Value: value}
+}
+
+func (s *server) definition(d *document, pos Position) []Location {
+	if d.idx == nil {
+		return nil
+	}
+	def, path := d.idx.DefinitionAt(d.offset(pos))
+	if def == nil {
+		return nil
+	}
+	// Selective imports define their names in the import statement. Follow
+	// them to the imported specification.
+	if n, ok := path.Node().(*ast.ImportName); ok {
+		return s.importedDefinition(d, path.Parent().(*ast.ImportDefinition), n)
+	}
+	if imp, n := d.idx.ImportOf(def); imp != nil {
+		return s.importedDefinition(d, imp, n)
+	}
+	return []Location{s.location(d, query.NameOf(def).GetLoc())}
+}
+
+// importedDefinition locates the definition of an imported name by parsing
+// the imported specification.
+func (s *server) importedDefinition(d *document, imp *ast.ImportDefinition, name *ast.ImportName) []Location {
+	fs := s.resolver(d)
+	path, err := fs.Locate(imp.From.Value, d.path)
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  source.NewSource(path, data),
+		Options: parser.ParseOptions{Resolver: fs.Resolve, Locator: fs.Locate},
+	})
+	if err != nil {
+		return nil
+	}
+	idx := query.NewIndex(doc)
+	var def ast.Node = idx.Lookup(name.Name.Value)
+	if def == nil {
+		if directive := idx.LookupDirective(name.Name.Value); directive != nil {
+			def = directive
+		}
+	}
+	if def == nil {
+		return nil
+	}
+	return []Location{s.location(d, query.NameOf(def).GetLoc())}
+}
+
+func (s *server) references(d *document, pos Position, includeDeclaration bool) []Location {
+	if d.idx == nil {
+		return nil
+	}
+	def, _ := d.idx.DefinitionAt(d.offset(pos))
+	if def == nil {
+		return nil
+	}
+	locations := []Location{}
+	if includeDeclaration {
+		locations = append(locations, s.location(d, query.NameOf(def).GetLoc()))
+	}
+	for _, ref := range d.idx.ReferencesTo(def) {
+		if loc := ref.Name.GetLoc(); loc != nil {
+			locations = append(locations, s.location(d, loc))
+		}
+	}
+	return locations
+}
+
+// location converts loc, which may be in an imported specification, to an
+// LSP location. Imported sources are named with their paths.
+func (s *server) location(d *document, loc *ast.Location) Location {
+	if loc.Source == nil || loc.Source == d.source {
+		return Location{URI: d.uri, Range: d.rangeOf(loc)}
+	}
+	return Location{
+		URI: pathToURI(loc.Source.Name),
+		Range: Range{
+			Start: offsetToPosition(loc.Source, loc.Start),
+			End:   offsetToPosition(loc.Source, loc.End),
+		},
+	}
+}
+
+func (s *server) documentSymbols(d *document) []DocumentSymbol {
+	symbols := []DocumentSymbol{}
+	if d.doc == nil {
+		return symbols
+	}
+	sym := func(name *ast.Name, kind SymbolKind, node ast.Node, detail string) DocumentSymbol {
+		return DocumentSymbol{
+			Name:           name.Value,
+			Detail:         detail,
+			Kind:           kind,
+			Range:          d.rangeOf(node.GetLoc()),
+			SelectionRange: d.rangeOf(name.GetLoc()),
+		}
+	}
+	for _, def := range d.doc.Definitions {
+		loc := def.GetLoc()
+		if loc == nil || loc.Source != d.source || d.imported(loc) {
+			continue
+		}
+		switch v := def.(type) {
+		case *ast.NamespaceDefinition:
+			symbols = append(symbols, sym(v.Name, SymbolKindNamespace, v, ""))
+		case *ast.DirectiveDefinition:
+			symbols = append(symbols, sym(v.Name, SymbolKindEvent, v, "directive"))
+		case *ast.AliasDefinition:
+			symbols = append(symbols, sym(v.Name, SymbolKindTypeParameter, v, format.Type(v.Type)))
+		case *ast.OperationDefinition:
+			symbols = append(symbols, sym(v.Name, SymbolKindFunction, v, format.Type(v.Type)))
+		case *ast.InterfaceDefinition:
+			parent := sym(v.Name, SymbolKindInterface, v, "")
+			for _, oper := range v.Operations {
+				parent.Children = append(parent.Children, sym(oper.Name, SymbolKindMethod, oper, format.Type(oper.Type)))
+			}
+			symbols = append(symbols, parent)
+		case *ast.TypeDefinition:
+			parent := sym(v.Name, SymbolKindStruct, v, "")
+			for _, field := range v.Fields {
+				parent.Children = append(parent.Children, sym(field.Name, SymbolKindField, field, format.Type(field.Type)))
+			}
+			symbols = append(symbols, parent)
+		case *ast.UnionDefinition:
+			symbols = append(symbols, sym(v.Name, SymbolKindClass, v, "union"))
+		case *ast.EnumDefinition:
+			parent := sym(v.Name, SymbolKindEnum, v, "")
+			for _, value := range v.Values {
+				parent.Children = append(parent.Children, sym(value.Name, SymbolKindEnumMember, value, ""))
+			}
+			symbols = append(symbols, parent)
+		}
+	}
+	return symbols
+}
+
+// imported reports whether loc is inside an import statement, which is
+// where selectively imported definitions are located.
+func (d *document) imported(loc *ast.Location) bool {
+	for _, def := range d.doc.Definitions {
+		imp, ok := def.(*ast.ImportDefinition)
+		if !ok {
+			continue
+		}
+		if l := imp.GetLoc(); l != nil && l.Source == loc.Source && l.Start <= loc.Start && loc.End <= l.End {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *server) completion(d *document, pos Position) []CompletionItem {
+	items := []CompletionItem{}
+	if d.source == nil {
+		return items
+	}
+	body := d.source.Body
+	offset := d.offset(pos)
+	start := offset
+	for start > 0 && isNameByte(body[start-1]) {
+		start--
+	}
+	idx := d.lastIdx
+	if idx == nil {
+		idx = query.NewIndex(&ast.Document{})
+	}
+
+	if start > 0 && body[start-1] == '@' {
+		for _, name := range idx.Directives() {
+			_, description := signature(query.Path{idx.LookupDirective(name)})
+			items = append(items, CompletionItem{
+				Label:         name,
+				Kind:          CompletionItemKindFunction,
+				Detail:        "directive",
+				Documentation: text(description),
+			})
+		}
+		return items
+	}
+
+	if name := openAnnotation(body[:start]); name != "" {
+		if directive := idx.LookupDirective(name); directive != nil {
+			for _, param := range directive.Parameters {
+				items = append(items, CompletionItem{
+					Label:         param.Name.Value,
+					Kind:          CompletionItemKindProperty,
+					Detail:        format.Type(param.Type),
+					Documentation: text(param.Description),
+					InsertText:    param.Name.Value + ": ",
+				})
+			}
+		}
+		return items
+	}
+
+	lineStart := bytes.LastIndexByte(body[:start], '\n') + 1
+	if strings.TrimSpace(string(body[lineStart:start])) == "" {
+		for _, k := range keywords {
+			items = append(items, CompletionItem{Label: k, Kind: CompletionItemKindKeyword})
+		}
+	}
+	for _, t := range builtinTypes {
+		items = append(items, CompletionItem{Label: t, Kind: CompletionItemKindKeyword, Detail: "built-in"})
+	}
+	for _, name := range idx.Named() {
+		def := idx.Lookup(name)
+		sig, description := signature(query.Path{def})
+		items = append(items, CompletionItem{
+			Label:         name,
+			Kind:          completionKind(def),
+			Detail:        sig,
+			Documentation: text(description),
+		})
+	}
+	return items
+}
+
+// openAnnotation returns the name of the annotation whose argument list is
+// open at the end of text, e.g. `rest` for `@rest(path: "/", `.
+func openAnnotation(text []byte) string {
+	depth := 0
+	inString := false
+	for i := len(text) - 1; i >= 0; i-- {
+		c := text[i]
+		switch {
+		case c == '"' && (i == 0 || text[i-1] != '\\'):
+			inString = !inString
+		case inString:
+		case c == ')' || c == ']' || c == '}':
+			depth++
+		case c == '[' || c == '{':
+			depth--
+		case c == '(':
+			if depth > 0 {
+				depth--
+				continue
+			}
+			end := i
+			j := end
+			for j > 0 && isNameByte(text[j-1]) {
+				j--
+			}
+			if j > 0 && j < end && text[j-1] == '@' {
+				return string(text[j:end])
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+func text(v *ast.StringValue) string {
+	if v == nil {
+		return ""
+	}
+	return v.Value
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func (s *server) formatting(d *document) ([]TextEdit, error) {
+	if d.doc == nil {
+		// Only valid specifications are formatted.
+		return nil, nil
+	}
+	formatted, err := format.Source(d.source)
+	if err != nil {
+		return nil, err
+	}
+	if string(formatted) == string(d.source.Body) {
+		return []TextEdit{}, nil
+	}
+	return []TextEdit{{
+		Range: Range{
+			Start: Position{},
+			End:   d.position(uint(len(d.source.Body))),
+		},
+		NewText: string(formatted),
+	}}, nil
+}
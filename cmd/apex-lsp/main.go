@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command apex-lsp is a Language Server Protocol server for Apex
+// specifications. It communicates over stdin and stdout.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	roots := flag.String("roots", "", "comma separated directories to resolve imports from (default: the directory of each document)")
+	flag.Parse()
+
+	var dirs []string
+	if *roots != "" {
+		dirs = strings.Split(*roots, ",")
+	}
+
+	s := newServer(newConn(os.Stdin, os.Stdout), dirs)
+	if err := s.run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
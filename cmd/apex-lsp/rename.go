@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/refactor"
+	"github.com/apexlang/apex-go/source"
+)
+
+func (s *server) prepareRename(d *document, pos Position) *Range {
+	if d.doc == nil {
+		return nil
+	}
+	name, err := refactor.Prepare(d.doc, d.offset(pos))
+	if err != nil {
+		return nil
+	}
+	r := d.rangeOf(name.GetLoc())
+	return &r
+}
+
+func (s *server) rename(d *document, pos Position, newName string) (*WorkspaceEdit, error) {
+	if d.doc == nil {
+		return nil, &responseError{Code: codeInvalidParams, Message: "the document has errors"}
+	}
+	files, err := refactor.Rename(s.workspace(d), d.path, d.offset(pos), newName)
+	if err != nil {
+		return nil, &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	edit := WorkspaceEdit{Changes: make(map[string][]TextEdit, len(files))}
+	for _, f := range files {
+		uri := pathToURI(f.Source.Name)
+		for _, e := range f.Edits {
+			edit.Changes[uri] = append(edit.Changes[uri], TextEdit{
+				Range: Range{
+					Start: offsetToPosition(f.Source, e.Start),
+					End:   offsetToPosition(f.Source, e.End),
+				},
+				NewText: e.NewText,
+			})
+		}
+	}
+	return &edit, nil
+}
+
+// workspace parses the specifications under the import roots of d. Open
+// documents are used in place of the files on disk.
+func (s *server) workspace(d *document) []*ast.Document {
+	open := make(map[string]*document, len(s.documents))
+	for _, doc := range s.documents {
+		open[doc.path] = doc
+	}
+	docs := []*ast.Document{d.doc}
+	seen := map[string]bool{d.path: true}
+
+	resolve := s.resolver(d)
+	for _, root := range resolve.Roots {
+		filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || filepath.Ext(path) != ".apex" || seen[path] {
+				return nil
+			}
+			seen[path] = true
+			if o, ok := open[path]; ok {
+				if o.doc != nil {
+					docs = append(docs, o.doc)
+				}
+				return nil
+			}
+			body, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			doc, err := parser.Parse(parser.ParseParams{
+				Source: source.NewSource(path, body),
+				Options: parser.ParseOptions{
+					Resolver: resolve.Resolve,
+					Locator:  resolve.Locate,
+				},
+			})
+			if err == nil {
+				docs = append(docs, doc)
+			}
+			return nil
+		})
+	}
+	return docs
+}
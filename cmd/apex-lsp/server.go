@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/apexlang/apex-go/resolver"
+)
+
+type server struct {
+	conn      *conn
+	roots     []string
+	documents map[string]*document
+	shutdown  bool
+}
+
+func newServer(conn *conn, roots []string) *server {
+	return &server{
+		conn:      conn,
+		roots:     roots,
+		documents: make(map[string]*document),
+	}
+}
+
+// run serves requests until the client sends exit or closes the stream.
+func (s *server) run() error {
+	for {
+		msg, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if rerr, ok := err.(*responseError); ok {
+			s.conn.reply(nil, nil, rerr)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return fmt.Errorf("exit before shutdown")
+			}
+			return nil
+		}
+		result, err := s.handle(msg)
+		if msg.ID == nil {
+			// Notifications have no response.
+			continue
+		}
+		if err := s.conn.reply(msg.ID, result, err); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) handle(msg *message) (interface{}, error) {
+	switch msg.Method {
+	case "initialize":
+		return InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync:           2,
+				HoverProvider:              true,
+				DefinitionProvider:         true,
+				ReferencesProvider:         true,
+				DocumentSymbolProvider:     true,
+				DocumentFormattingProvider: true,
+				CompletionProvider: CompletionOptions{
+					TriggerCharacters: []string{"@", ":", "("},
+				},
+				RenameProvider: RenameOptions{PrepareProvider: true},
+			},
+			ServerInfo: ServerInfo{Name: "apex-lsp"},
+		}, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		d := newDocument(params.TextDocument.URI)
+		s.documents[d.uri] = d
+		return nil, s.update(d, params.TextDocument.Text)
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		d, ok := s.documents[params.TextDocument.URI]
+		if !ok || len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		d.change(params.ContentChanges, s.resolver(d))
+		return nil, s.publishDiagnostics(d)
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return nil, s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []Diagnostic{},
+		})
+
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		d, err := s.documentParams(msg.Params, &params, &params.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return s.hover(d, params.Position), nil
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		d, err := s.documentParams(msg.Params, &params, &params.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return s.definition(d, params.Position), nil
+	case "textDocument/references":
+		var params ReferenceParams
+		d, err := s.documentParams(msg.Params, &params, &params.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return s.references(d, params.Position, params.Context.IncludeDeclaration), nil
+	case "textDocument/documentSymbol":
+		var params DocumentSymbolParams
+		d, err := s.documentParams(msg.Params, &params, &params.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return s.documentSymbols(d), nil
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		d, err := s.documentParams(msg.Params, &params, &params.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return s.completion(d, params.Position), nil
+	case "textDocument/formatting":
+		var params DocumentFormattingParams
+		d, err := s.documentParams(msg.Params, &params, &params.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return s.formatting(d)
+	case "textDocument/prepareRename":
+		var params TextDocumentPositionParams
+		d, err := s.documentParams(msg.Params, &params, &params.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return s.prepareRename(d, params.Position), nil
+	case "textDocument/rename":
+		var params RenameParams
+		d, err := s.documentParams(msg.Params, &params, &params.TextDocument)
+		if err != nil {
+			return nil, err
+		}
+		return s.rename(d, params.Position, params.NewName)
+	}
+
+	if msg.ID == nil {
+		// Unknown notifications are ignored.
+		return nil, nil
+	}
+	return nil, &responseError{
+		Code:    codeMethodNotFound,
+		Message: fmt.Sprintf("method not found: %s", msg.Method),
+	}
+}
+
+func (s *server) update(d *document, text string) error {
+	d.update(text, s.resolver(d))
+	return s.publishDiagnostics(d)
+}
+
+func (s *server) publishDiagnostics(d *document) error {
+	return s.conn.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{
+		URI:         d.uri,
+		Diagnostics: d.diagnostics,
+	})
+}
+
+// resolver resolves imports relative to d and then from the configured
+// roots, or the directory of d when there are none.
+func (s *server) resolver(d *document) *resolver.FileSystem {
+	roots := s.roots
+	if len(roots) == 0 {
+		roots = []string{filepath.Dir(d.path)}
+	}
+	return resolver.NewFileSystem(roots...)
+}
+
+func (s *server) documentParams(raw json.RawMessage, params interface{}, id *TextDocumentIdentifier) (*document, error) {
+	if err := unmarshal(raw, params); err != nil {
+		return nil, err
+	}
+	d, ok := s.documents[id.URI]
+	if !ok {
+		return nil, &responseError{
+			Code:    codeInvalidParams,
+			Message: fmt.Sprintf("document is not open: %s", id.URI),
+		}
+	}
+	return d, nil
+}
+
+func unmarshal(raw json.RawMessage, v interface{}) error {
+	if err := json.Unmarshal(raw, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,475 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// session scripts the messages a client sends and collects what the
+// server writes back.
+type session struct {
+	t      *testing.T
+	dir    string
+	input  bytes.Buffer
+	nextID int
+
+	results       map[int]json.RawMessage
+	errors        map[int]*responseError
+	notifications []*message
+}
+
+// newSession returns a session whose documents are in a temporary
+// directory holding files.
+func newSession(t *testing.T, files map[string]string) *session {
+	dir := t.TempDir()
+	for name, text := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := &session{t: t, dir: dir}
+	s.request("initialize", map[string]interface{}{})
+	s.notify("initialized", map[string]interface{}{})
+	return s
+}
+
+func (s *session) uri(name string) string {
+	return pathToURI(filepath.Join(s.dir, name))
+}
+
+func (s *session) write(msg map[string]interface{}) {
+	msg["jsonrpc"] = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	fmt.Fprintf(&s.input, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+// request queues a request and returns its ID.
+func (s *session) request(method string, params interface{}) int {
+	s.nextID++
+	s.write(map[string]interface{}{"id": s.nextID, "method": method, "params": params})
+	return s.nextID
+}
+
+func (s *session) notify(method string, params interface{}) {
+	s.write(map[string]interface{}{"method": method, "params": params})
+}
+
+func (s *session) open(name, text string) {
+	s.notify("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{
+			"uri": s.uri(name), "languageId": "apex", "version": 1, "text": text,
+		},
+	})
+}
+
+// at returns the parameters of a request at a position in a document.
+func (s *session) at(name string, line, character int) map[string]interface{} {
+	return map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": s.uri(name)},
+		"position":     map[string]interface{}{"line": line, "character": character},
+	}
+}
+
+// run shuts the server down after the queued messages and collects its
+// output.
+func (s *session) run() {
+	s.request("shutdown", nil)
+	s.notify("exit", nil)
+
+	var output bytes.Buffer
+	if err := newServer(newConn(&s.input, &output), nil).run(); err != nil {
+		s.t.Fatal(err)
+	}
+	s.results = make(map[int]json.RawMessage)
+	s.errors = make(map[int]*responseError)
+	c := newConn(&output, io.Discard)
+	for {
+		msg, err := c.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		if msg.ID == nil {
+			s.notifications = append(s.notifications, msg)
+			continue
+		}
+		var id int
+		if err := json.Unmarshal(*msg.ID, &id); err != nil {
+			s.t.Fatal(err)
+		}
+		if msg.Error != nil {
+			s.errors[id] = msg.Error
+			continue
+		}
+		raw, _ := json.Marshal(msg.Result)
+		s.results[id] = raw
+	}
+}
+
+// result decodes the result of the request id into v.
+func (s *session) result(id int, v interface{}) {
+	s.t.Helper()
+	if err := s.errors[id]; err != nil {
+		s.t.Fatalf("request %d failed: %s", id, err.Message)
+	}
+	raw, ok := s.results[id]
+	if !ok {
+		s.t.Fatalf("no response to request %d", id)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		s.t.Fatal(err)
+	}
+}
+
+// diagnostics returns the diagnostics published for name, one list per
+// notification.
+func (s *session) diagnostics(name string) [][]Diagnostic {
+	var all [][]Diagnostic
+	for _, n := range s.notifications {
+		if n.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+		var params PublishDiagnosticsParams
+		if err := json.Unmarshal(n.Params, &params); err != nil {
+			s.t.Fatal(err)
+		}
+		if params.URI == s.uri(name) {
+			all = append(all, params.Diagnostics)
+		}
+	}
+	return all
+}
+
+const mainSpec = `namespace "test"
+
+import { Address } from "./address.apex"
+
+"A user."
+type User {
+  name: string
+  home: Address
+}
+
+interface Users {
+  get(id: string): User
+}
+`
+
+func TestServerInitialize(t *testing.T) {
+	s := newSession(t, nil)
+	s.run()
+	var result InitializeResult
+	s.result(1, &result)
+	caps := result.Capabilities
+	if !caps.HoverProvider || !caps.DefinitionProvider || !caps.ReferencesProvider ||
+		!caps.DocumentSymbolProvider || !caps.DocumentFormattingProvider || !caps.RenameProvider.PrepareProvider {
+		t.Errorf("capabilities = %+v", caps)
+	}
+	if result.ServerInfo.Name != "apex-lsp" {
+		t.Errorf("server name = %q", result.ServerInfo.Name)
+	}
+}
+
+func TestServerDiagnostics(t *testing.T) {
+	s := newSession(t, map[string]string{"address.apex": "type Address { line: string }\n"})
+	s.open("main.apex", mainSpec)
+	s.open("bad.apex", "namespace \"test\"\nalias T = Missing\n")
+	s.open("syntax.apex", "namespace \"test\"\ntype T {\n")
+	s.run()
+
+	if d := s.diagnostics("main.apex"); len(d) != 1 || len(d[0]) != 0 {
+		t.Errorf("main.apex diagnostics = %+v, want none", d)
+	}
+	d := s.diagnostics("bad.apex")
+	if len(d) != 1 || len(d[0]) != 1 {
+		t.Fatalf("bad.apex diagnostics = %+v, want one", d)
+	}
+	if got := d[0][0]; got.Severity != 1 || got.Range.Start != (Position{Line: 1, Character: 10}) ||
+		!strings.Contains(got.Message, `unknown type "Missing"`) {
+		t.Errorf("bad.apex diagnostic = %+v", got)
+	}
+	d = s.diagnostics("syntax.apex")
+	if len(d) != 1 || len(d[0]) != 1 || !strings.Contains(d[0][0].Message, "Syntax Error") {
+		t.Errorf("syntax.apex diagnostics = %+v", d)
+	}
+}
+
+func TestServerHover(t *testing.T) {
+	s := newSession(t, map[string]string{"address.apex": "type Address { line: string }\n"})
+	s.open("main.apex", mainSpec)
+	reference := s.request("textDocument/hover", s.at("main.apex", 11, 19))
+	keyword := s.request("textDocument/hover", s.at("main.apex", 4, 2))
+	s.run()
+
+	var hover *Hover
+	s.result(reference, &hover)
+	if hover == nil || !strings.Contains(hover.Contents.Value, "type User") ||
+		!strings.Contains(hover.Contents.Value, "A user.") {
+		t.Fatalf("hover = %+v", hover)
+	}
+	if hover.Range == nil || hover.Range.Start != (Position{Line: 11, Character: 19}) {
+		t.Errorf("hover range = %+v", hover.Range)
+	}
+	hover = nil
+	s.result(keyword, &hover)
+	if hover != nil {
+		t.Errorf("hover on a description = %+v, want none", hover)
+	}
+}
+
+func TestServerDefinition(t *testing.T) {
+	s := newSession(t, map[string]string{"address.apex": "type Address { line: string }\n"})
+	s.open("main.apex", mainSpec)
+	local := s.request("textDocument/definition", s.at("main.apex", 11, 19))
+	imported := s.request("textDocument/definition", s.at("main.apex", 7, 9))
+	s.run()
+
+	var locations []Location
+	s.result(local, &locations)
+	want := Location{URI: s.uri("main.apex"), Range: Range{Start: Position{5, 5}, End: Position{5, 9}}}
+	if len(locations) != 1 || locations[0] != want {
+		t.Errorf("definition of User = %+v, want %+v", locations, want)
+	}
+	locations = nil
+	s.result(imported, &locations)
+	want = Location{URI: s.uri("address.apex"), Range: Range{Start: Position{0, 5}, End: Position{0, 12}}}
+	if len(locations) != 1 || locations[0] != want {
+		t.Errorf("definition of Address = %+v, want %+v", locations, want)
+	}
+}
+
+func TestServerReferences(t *testing.T) {
+	s := newSession(t, map[string]string{"address.apex": "type Address { line: string }\n"})
+	s.open("main.apex", mainSpec)
+	params := s.at("main.apex", 5, 6)
+	params["context"] = map[string]interface{}{"includeDeclaration": true}
+	with := s.request("textDocument/references", params)
+	params = s.at("main.apex", 5, 6)
+	params["context"] = map[string]interface{}{"includeDeclaration": false}
+	without := s.request("textDocument/references", params)
+	s.run()
+
+	var locations []Location
+	s.result(with, &locations)
+	if len(locations) != 2 {
+		t.Errorf("references with declaration = %+v, want 2", locations)
+	}
+	locations = nil
+	s.result(without, &locations)
+	if len(locations) != 1 || locations[0].Range.Start != (Position{Line: 11, Character: 19}) {
+		t.Errorf("references = %+v", locations)
+	}
+}
+
+func TestServerDocumentSymbols(t *testing.T) {
+	s := newSession(t, map[string]string{"address.apex": "type Address { line: string }\n"})
+	s.open("main.apex", mainSpec)
+	id := s.request("textDocument/documentSymbol", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": s.uri("main.apex")},
+	})
+	s.run()
+
+	var symbols []DocumentSymbol
+	s.result(id, &symbols)
+	var got []string
+	var walk func(prefix string, symbols []DocumentSymbol)
+	walk = func(prefix string, symbols []DocumentSymbol) {
+		for _, sym := range symbols {
+			got = append(got, prefix+sym.Name)
+			walk(prefix+sym.Name+".", sym.Children)
+		}
+	}
+	walk("", symbols)
+	want := "test User User.name User.home Users Users.get"
+	if strings.Join(got, " ") != want {
+		t.Errorf("symbols = %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+func TestServerCompletion(t *testing.T) {
+	s := newSession(t, map[string]string{"address.apex": "type Address { line: string }\n"})
+	s.open("main.apex", strings.Replace(mainSpec, "name: string", "name: Us", 1))
+	id := s.request("textDocument/completion", s.at("main.apex", 6, 10))
+	s.run()
+
+	var items []CompletionItem
+	s.result(id, &items)
+	labels := make(map[string]bool)
+	for _, item := range items {
+		labels[item.Label] = true
+	}
+	for _, want := range []string{"User", "Address", "string"} {
+		if !labels[want] {
+			t.Errorf("completion is missing %q: %+v", want, items)
+		}
+	}
+}
+
+func TestServerFormatting(t *testing.T) {
+	s := newSession(t, nil)
+	s.open("messy.apex", "namespace   \"test\"\ntype T{a:string}\n")
+	s.open("formatted.apex", "namespace \"test\"\n\ntype T {\n  a: string\n}\n")
+	messy := s.request("textDocument/formatting", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": s.uri("messy.apex")},
+	})
+	formatted := s.request("textDocument/formatting", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": s.uri("formatted.apex")},
+	})
+	closed := s.request("textDocument/formatting", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": s.uri("closed.apex")},
+	})
+	s.run()
+
+	var edits []TextEdit
+	s.result(messy, &edits)
+	if len(edits) != 1 || edits[0].NewText != "namespace \"test\"\n\ntype T {\n  a: string\n}\n" ||
+		edits[0].Range.End != (Position{Line: 2, Character: 0}) {
+		t.Errorf("edits = %+v", edits)
+	}
+	edits = nil
+	s.result(formatted, &edits)
+	if len(edits) != 0 {
+		t.Errorf("edits of a formatted document = %+v", edits)
+	}
+	if err := s.errors[closed]; err == nil || err.Code != codeInvalidParams {
+		t.Errorf("formatting a closed document: error = %+v", err)
+	}
+}
+
+func TestServerUnknownMethod(t *testing.T) {
+	s := newSession(t, nil)
+	id := s.request("workspace/unknown", nil)
+	s.notify("$/unknown", nil)
+	s.run()
+	if err := s.errors[id]; err == nil || err.Code != codeMethodNotFound {
+		t.Errorf("error = %+v", err)
+	}
+}
+
+func TestServerRename(t *testing.T) {
+	s := newSession(t, map[string]string{"address.apex": "type Address { line: string }\n"})
+	s.open("main.apex", mainSpec)
+	prepare := s.request("textDocument/prepareRename", s.at("main.apex", 5, 6))
+	keyword := s.request("textDocument/prepareRename", s.at("main.apex", 5, 1))
+	params := s.at("main.apex", 7, 9)
+	params["newName"] = "Location"
+	rename := s.request("textDocument/rename", params)
+	params = s.at("main.apex", 5, 6)
+	params["newName"] = "Users"
+	collision := s.request("textDocument/rename", params)
+	s.run()
+
+	var r *Range
+	s.result(prepare, &r)
+	if r == nil || *r != (Range{Start: Position{5, 5}, End: Position{5, 9}}) {
+		t.Errorf("prepared range = %+v", r)
+	}
+	r = nil
+	s.result(keyword, &r)
+	if r != nil {
+		t.Errorf("prepared range of a keyword = %+v, want none", r)
+	}
+
+	var edit WorkspaceEdit
+	s.result(rename, &edit)
+	want := map[string][]Position{
+		s.uri("address.apex"): {{0, 5}},
+		s.uri("main.apex"):    {{2, 9}, {7, 8}},
+	}
+	if len(edit.Changes) != len(want) {
+		t.Fatalf("changes = %+v", edit.Changes)
+	}
+	for uri, starts := range want {
+		edits := edit.Changes[uri]
+		if len(edits) != len(starts) {
+			t.Errorf("%s edits = %+v", uri, edits)
+			continue
+		}
+		for i, e := range edits {
+			if e.Range.Start != starts[i] || e.NewText != "Location" {
+				t.Errorf("%s edit %d = %+v, want Location at %+v", uri, i, e, starts[i])
+			}
+		}
+	}
+	if err := s.errors[collision]; err == nil || err.Code != codeInvalidParams ||
+		!strings.Contains(err.Message, `"Users" is already defined`) {
+		t.Errorf("renaming to a defined name: error = %+v", err)
+	}
+}
+
+func TestServerDidChange(t *testing.T) {
+	s := newSession(t, map[string]string{"address.apex": "type Address { line: string }\n"})
+	s.open("main.apex", mainSpec)
+	change := func(changes ...map[string]interface{}) {
+		s.notify("textDocument/didChange", map[string]interface{}{
+			"textDocument":   map[string]interface{}{"uri": s.uri("main.apex"), "version": 2},
+			"contentChanges": changes,
+		})
+	}
+	edit := func(startLine, startChar, endLine, endChar int, text string) map[string]interface{} {
+		return map[string]interface{}{
+			"range": map[string]interface{}{
+				"start": map[string]interface{}{"line": startLine, "character": startChar},
+				"end":   map[string]interface{}{"line": endLine, "character": endChar},
+			},
+			"text": text,
+		}
+	}
+	change(edit(11, 19, 11, 23, "Missing"))
+	// The comment shifts the following lines. "😀" is two UTF-16 units.
+	change(edit(11, 19, 11, 26, "User"), edit(4, 0, 4, 0, "# é😀\n"), edit(4, 3, 4, 5, "x"))
+	hover := s.request("textDocument/hover", s.at("main.apex", 12, 19))
+	change(map[string]interface{}{"text": "namespace \"test\"\ntype T {\n"})
+	s.run()
+
+	d := s.diagnostics("main.apex")
+	if len(d) != 4 {
+		t.Fatalf("diagnostics = %+v, want 4 publications", d)
+	}
+	if len(d[0]) != 0 {
+		t.Errorf("diagnostics after opening = %+v", d[0])
+	}
+	if len(d[1]) != 1 || d[1][0].Range.Start != (Position{Line: 11, Character: 19}) ||
+		!strings.Contains(d[1][0].Message, `unknown type "Missing"`) {
+		t.Errorf("diagnostics after the first change = %+v", d[1])
+	}
+	if len(d[2]) != 0 {
+		t.Errorf("diagnostics after the second change = %+v", d[2])
+	}
+	if len(d[3]) != 1 || !strings.Contains(d[3][0].Message, "Syntax Error") {
+		t.Errorf("diagnostics after replacing the text = %+v", d[3])
+	}
+
+	var h *Hover
+	s.result(hover, &h)
+	if h == nil || !strings.Contains(h.Contents.Value, "type User") {
+		t.Errorf("hover after the changes = %+v", h)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/format"
+	"github.com/apexlang/apex-go/query"
+)
+
+// signature returns a one line summary of a definition or member and its
+// description.
+func signature(path query.Path) (string, *ast.StringValue) {
+	switch n := path.Node().(type) {
+	case *ast.DirectiveDefinition:
+		return "directive @" + n.Name.Value, n.Description
+	case *ast.AliasDefinition:
+		return "alias " + n.Name.Value + " = " + format.Type(n.Type), n.Description
+	case *ast.InterfaceDefinition:
+		return "interface " + n.Name.Value, n.Description
+	case *ast.TypeDefinition:
+		return "type " + n.Name.Value, n.Description
+	case *ast.UnionDefinition:
+		return "union " + n.Name.Value, n.Description
+	case *ast.EnumDefinition:
+		return "enum " + n.Name.Value, n.Description
+	case *ast.OperationDefinition:
+		prefix := "func "
+		if iface, ok := path.Parent().(*ast.InterfaceDefinition); ok {
+			prefix = iface.Name.Value + "."
+		}
+		return prefix + n.Name.Value + "(...): " + format.Type(n.Type), n.Description
+	case *ast.ParameterDefinition:
+		return n.Name.Value + ": " + format.Type(n.Type), n.Description
+	case *ast.FieldDefinition:
+		prefix := ""
+		if t, ok := path.Parent().(*ast.TypeDefinition); ok {
+			prefix = t.Name.Value + "."
+		}
+		return prefix + n.Name.Value + ": " + format.Type(n.Type), n.Description
+	case *ast.EnumValueDefinition:
+		prefix := ""
+		if e, ok := path.Parent().(*ast.EnumDefinition); ok {
+			prefix = e.Name.Value + "."
+		}
+		return prefix + n.Name.Value, n.Description
+	}
+	return "", nil
+}
+
+func completionKind(def ast.Node) CompletionItemKind {
+	switch def.(type) {
+	case *ast.TypeDefinition:
+		return CompletionItemKindStruct
+	case *ast.EnumDefinition:
+		return CompletionItemKindEnum
+	case *ast.InterfaceDefinition:
+		return CompletionItemKindInterface
+	}
+	return CompletionItemKindClass
+}
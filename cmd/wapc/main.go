@@ -12,7 +12,9 @@ func Initialize() {
 
 	// Create services
 	parserService := model.NewParser(resolverProvider)
+	formatService := model.NewFormatConverter(resolverProvider)
 
 	// Register services
 	wapc.RegisterParser(parserService)
+	wapc.RegisterFormatConverter(formatService)
 }
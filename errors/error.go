@@ -17,9 +17,6 @@ limitations under the License.
 package errors
 
 import (
-	"fmt"
-	"reflect"
-
 	"github.com/apexlang/apex-go/ast"
 	"github.com/apexlang/apex-go/location"
 	"github.com/apexlang/apex-go/source"
@@ -33,14 +30,27 @@ type Error struct {
 	Positions     []uint                    `json:"positions,omitempty"`
 	Locations     []location.SourceLocation `json:"locations,omitempty"`
 	OriginalError error                     `json:"-"`
-	Path          []interface{}             `json:"path,omitempty"`
+	Path          Path                      `json:"path,omitempty"`
+	Fixes         []source.Edit             `json:"fixes,omitempty"`
+	Severity      Severity                  `json:"severity,omitempty"`
 }
 
 type Errors []*Error
 
+// HasErrors returns true if any of errs is not a warning or lesser severity.
+func HasErrors(errs []error) bool {
+	for _, err := range errs {
+		if e, ok := err.(*Error); ok && e.Severity != SeverityError {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
 // implements Golang's built-in `error` interface
 func (g Error) Error() string {
-	return fmt.Sprintf("%v", g.Message)
+	return g.Message
 }
 
 func NewError(message string, nodes []ast.Node, stack string, source *source.Source, positions []uint, origError error) *Error {
@@ -58,7 +68,7 @@ func newError(message string, nodes []ast.Node, stack string, source *source.Sou
 	if source == nil {
 		for _, node := range nodes {
 			// get source from first node
-			if node == nil || reflect.ValueOf(node).IsNil() {
+			if ast.IsNil(node) {
 				continue
 			}
 			if node.GetLoc() != nil {
@@ -69,7 +79,7 @@ func newError(message string, nodes []ast.Node, stack string, source *source.Sou
 	}
 	if len(positions) == 0 && len(nodes) > 0 {
 		for _, node := range nodes {
-			if node == nil || reflect.ValueOf(node).IsNil() {
+			if ast.IsNil(node) {
 				continue
 			}
 			if node.GetLoc() == nil {
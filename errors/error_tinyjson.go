@@ -3,7 +3,6 @@
 package errors
 
 import (
-	json "encoding/json"
 	tinyjson "github.com/CosmWasm/tinyjson"
 	jlexer "github.com/CosmWasm/tinyjson/jlexer"
 	jwriter "github.com/CosmWasm/tinyjson/jwriter"
@@ -98,34 +97,34 @@ func tinyjsonC34e4ef0DecodeGithubComApexlangApexGoErrors(in *jlexer.Lexer, out *
 				in.Delim(']')
 			}
 		case "path":
+			(out.Path).UnmarshalTinyJSON(in)
+		case "fixes":
 			if in.IsNull() {
 				in.Skip()
-				out.Path = nil
+				out.Fixes = nil
 			} else {
 				in.Delim('[')
-				if out.Path == nil {
+				if out.Fixes == nil {
 					if !in.IsDelim(']') {
-						out.Path = make([]interface{}, 0, 4)
+						out.Fixes = make([]source.Edit, 0, 2)
 					} else {
-						out.Path = []interface{}{}
+						out.Fixes = []source.Edit{}
 					}
 				} else {
-					out.Path = (out.Path)[:0]
+					out.Fixes = (out.Fixes)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v3 interface{}
-					if m, ok := v3.(tinyjson.Unmarshaler); ok {
-						m.UnmarshalTinyJSON(in)
-					} else if m, ok := v3.(json.Unmarshaler); ok {
-						_ = m.UnmarshalJSON(in.Raw())
-					} else {
-						v3 = in.Interface()
-					}
-					out.Path = append(out.Path, v3)
+					var v3 source.Edit
+					tinyjsonC34e4ef0DecodeGithubComApexlangApexGoSource1(in, &v3)
+					out.Fixes = append(out.Fixes, v3)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "severity":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Severity).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -186,23 +185,27 @@ func tinyjsonC34e4ef0EncodeGithubComApexlangApexGoErrors(out *jwriter.Writer, in
 	if len(in.Path) != 0 {
 		const prefix string = ",\"path\":"
 		out.RawString(prefix)
+		(in.Path).MarshalTinyJSON(out)
+	}
+	if len(in.Fixes) != 0 {
+		const prefix string = ",\"fixes\":"
+		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v8, v9 := range in.Path {
+			for v8, v9 := range in.Fixes {
 				if v8 > 0 {
 					out.RawByte(',')
 				}
-				if m, ok := v9.(tinyjson.Marshaler); ok {
-					m.MarshalTinyJSON(out)
-				} else if m, ok := v9.(json.Marshaler); ok {
-					out.Raw(m.MarshalJSON())
-				} else {
-					out.Raw(json.Marshal(v9))
-				}
+				tinyjsonC34e4ef0EncodeGithubComApexlangApexGoSource1(out, v9)
 			}
 			out.RawByte(']')
 		}
 	}
+	if in.Severity != 0 {
+		const prefix string = ",\"severity\":"
+		out.RawString(prefix)
+		out.Raw((in.Severity).MarshalJSON())
+	}
 	out.RawByte('}')
 }
 
@@ -229,6 +232,62 @@ func (v *Error) UnmarshalJSON(data []byte) error {
 func (v *Error) UnmarshalTinyJSON(l *jlexer.Lexer) {
 	tinyjsonC34e4ef0DecodeGithubComApexlangApexGoErrors(l, v)
 }
+func tinyjsonC34e4ef0DecodeGithubComApexlangApexGoSource1(in *jlexer.Lexer, out *source.Edit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "start":
+			out.Start = uint(in.Uint())
+		case "end":
+			out.End = uint(in.Uint())
+		case "newText":
+			out.NewText = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjsonC34e4ef0EncodeGithubComApexlangApexGoSource1(out *jwriter.Writer, in source.Edit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"start\":"
+		out.RawString(prefix[1:])
+		out.Uint(uint(in.Start))
+	}
+	{
+		const prefix string = ",\"end\":"
+		out.RawString(prefix)
+		out.Uint(uint(in.End))
+	}
+	{
+		const prefix string = ",\"newText\":"
+		out.RawString(prefix)
+		out.String(string(in.NewText))
+	}
+	out.RawByte('}')
+}
 func tinyjsonC34e4ef0DecodeGithubComApexlangApexGoLocation(in *jlexer.Lexer, out *location.SourceLocation) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"strconv"
+
+	"github.com/CosmWasm/tinyjson"
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// Path is the path to the element an error is about, made of names and
+// indexes.
+type Path []interface{}
+
+// MarshalTinyJSON writes the path as a JSON array. Floats and elements
+// with a String method are written as strings, and elements that are not
+// strings, integers, booleans or tinyjson marshalers either as null. It keeps encoding/json, which the generated code would use
+// for the elements, out of wasm builds.
+func (p Path) MarshalTinyJSON(out *jwriter.Writer) {
+	if p == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+		return
+	}
+	out.RawByte('[')
+	for i, v := range p {
+		if i > 0 {
+			out.RawByte(',')
+		}
+		switch v := v.(type) {
+		case nil:
+			out.RawString("null")
+		case tinyjson.Marshaler:
+			v.MarshalTinyJSON(out)
+		case string:
+			out.String(v)
+		case bool:
+			out.Bool(v)
+		case int:
+			out.Int(v)
+		case int64:
+			out.Int64(v)
+		case uint:
+			out.Uint(v)
+		case uint64:
+			out.Uint64(v)
+		case float64:
+			out.String(strconv.FormatFloat(v, 'g', -1, 64))
+		case float32:
+			out.String(strconv.FormatFloat(float64(v), 'g', -1, 32))
+		case interface{ String() string }:
+			out.String(v.String())
+		default:
+			out.RawString("null")
+		}
+	}
+	out.RawByte(']')
+}
+
+// UnmarshalTinyJSON reads a JSON array of strings, booleans and
+// non-negative integers, which are read as uint64.
+func (p *Path) UnmarshalTinyJSON(in *jlexer.Lexer) {
+	if in.IsNull() {
+		in.Skip()
+		*p = nil
+		return
+	}
+	in.Delim('[')
+	path := Path{}
+	for !in.IsDelim(']') {
+		path = append(path, in.Interface())
+		in.WantComma()
+	}
+	in.Delim(']')
+	*p = path
+}
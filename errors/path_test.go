@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/errors"
+)
+
+func TestErrorJSON(t *testing.T) {
+	e := errors.Error{
+		Message: "Validation Error: \"quoted\"",
+		Path:    errors.Path{"types", 2, "fields", uint(1), true, 1.5, nil},
+	}
+	data, err := e.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"message":"Validation Error: \"quoted\"","path":["types",2,"fields",1,true,"1.5",null]}`
+	if string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+
+	var decoded errors.Error
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Message != e.Message || len(decoded.Path) != len(e.Path) {
+		t.Fatalf("decoded = %+v", decoded)
+	}
+	if decoded.Path[0] != "types" || decoded.Path[1] != uint64(2) || decoded.Path[6] != nil {
+		t.Errorf("decoded path = %#v", decoded.Path)
+	}
+}
+
+func TestErrorJSONWithoutPath(t *testing.T) {
+	var decoded errors.Error
+	if err := decoded.UnmarshalJSON([]byte(`{"message":"m","path":null}`)); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Path != nil {
+		t.Errorf("path = %#v, want nil", decoded.Path)
+	}
+	if err := decoded.UnmarshalJSON([]byte(`{"path":["a"`)); err == nil {
+		t.Error("decoded a truncated path")
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	stderrs "errors"
+	"strconv"
+)
+
+// Severity indicates how serious a reported problem is.
+// The zero value is an error so existing errors keep their meaning.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInformation
+	SeverityHint
+)
+
+var severityNames = map[Severity]string{
+	SeverityError:       "error",
+	SeverityWarning:     "warning",
+	SeverityInformation: "information",
+	SeverityHint:        "hint",
+}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+func (s *Severity) FromString(str string) error {
+	for k, v := range severityNames {
+		if v == str {
+			*s = k
+			return nil
+		}
+	}
+	return stderrs.New("unknown severity " + strconv.Quote(str))
+}
+
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
+
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return stderrs.New("invalid severity " + string(data))
+	}
+	return s.FromString(string(data[1 : len(data)-1]))
+}
@@ -17,8 +17,7 @@ limitations under the License.
 package errors
 
 import (
-	"fmt"
-	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/apexlang/apex-go/ast"
@@ -29,7 +28,7 @@ import (
 func NewSyntaxError(s *source.Source, position uint, description string) *Error {
 	l := location.GetLocation(s, position)
 	return NewError(
-		fmt.Sprintf("Syntax Error %s (%d:%d) %s\n\n%s", s.Name, l.Line, l.Column, description, highlightSourceAtLocation(s, l)),
+		heading("Syntax Error", s, l, description),
 		[]ast.Node{},
 		"",
 		s,
@@ -38,14 +37,58 @@ func NewSyntaxError(s *source.Source, position uint, description string) *Error
 	)
 }
 
+// NewLimitError returns an error for a parsing limit, such as the nesting
+// depth, exceeded at position.
+func NewLimitError(s *source.Source, position uint, description string) *Error {
+	l := location.GetLocation(s, position)
+	return NewError(
+		heading("Limit Error", s, l, description),
+		[]ast.Node{},
+		"",
+		s,
+		[]uint{position},
+		nil,
+	)
+}
+
+// NewImportError returns an error for the import at position that cannot
+// be resolved, such as one that closes a cycle.
+func NewImportError(s *source.Source, position uint, description string) *Error {
+	l := location.GetLocation(s, position)
+	return NewError(
+		heading("Import Error", s, l, description),
+		[]ast.Node{},
+		"",
+		s,
+		[]uint{position},
+		nil,
+	)
+}
+
+// heading formats the message of an error at l in s: its kind, location
+// and description followed by the highlighted source.
+func heading(kind string, s *source.Source, l location.SourceLocation, description string) string {
+	return kind + " " + s.Name + " (" + strconv.FormatUint(uint64(l.Line), 10) + ":" +
+		strconv.FormatUint(uint64(l.Column), 10) + ") " + description + "\n\n" + highlightSourceAtLocation(s, l)
+}
+
 // printCharCode here is slightly different from lexer.printCharCode()
 func printCharCode(code rune) string {
 	// print as ASCII for printable range
 	if code >= 0x0020 {
-		return fmt.Sprintf(`%c`, code)
+		return string(code)
 	}
 	// Otherwise print the escaped form. e.g. `"\\u0007"`
-	return fmt.Sprintf(`\u%04X`, code)
+	return `\u` + hex4(code)
+}
+
+// hex4 formats code in upper case hexadecimal with at least four digits.
+func hex4(code rune) string {
+	h := strings.ToUpper(strconv.FormatInt(int64(code), 16))
+	if len(h) < 4 {
+		h = strings.Repeat("0", 4-len(h)) + h
+	}
+	return h
 }
 
 func printLine(str string) string {
@@ -58,30 +101,30 @@ func printLine(str string) string {
 
 func highlightSourceAtLocation(s *source.Source, l location.SourceLocation) string {
 	line := l.Line
-	prevLineNum := fmt.Sprintf("%d", (line - 1))
-	lineNum := fmt.Sprintf("%d", line)
-	nextLineNum := fmt.Sprintf("%d", (line + 1))
+	prevLineNum := strconv.FormatUint(uint64(line-1), 10)
+	lineNum := strconv.FormatUint(uint64(line), 10)
+	nextLineNum := strconv.FormatUint(uint64(line+1), 10)
 	padLen := len(nextLineNum)
-	lines := regexp.MustCompile("\r\n|[\n\r]").Split(string(s.Body), -1)
-	var highlight string
-	if line >= 2 {
-		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, prevLineNum), printLine(lines[line-2]))
+	text := func(n uint) string {
+		b, _ := s.Line(n)
+		return printLine(string(b))
 	}
-	highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, lineNum), printLine(lines[line-1]))
-	for i := 1; i < (2 + padLen + int(l.Column)); i++ {
-		highlight += " "
+	var highlight strings.Builder
+	if line >= 2 {
+		highlight.WriteString(lpad(padLen, prevLineNum) + ": " + text(line-1) + "\n")
 	}
-	highlight += "^\n"
-	if int(line) < len(lines) {
-		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, nextLineNum), printLine(lines[line]))
+	highlight.WriteString(lpad(padLen, lineNum) + ": " + text(line) + "\n")
+	highlight.WriteString(strings.Repeat(" ", 1+padLen+int(l.Column)))
+	highlight.WriteString("^\n")
+	if line < s.LineCount() {
+		highlight.WriteString(lpad(padLen, nextLineNum) + ": " + text(line+1) + "\n")
 	}
-	return highlight
+	return highlight.String()
 }
 
 func lpad(l int, s string) string {
-	var r string
-	for i := 1; i < (l - len(s) + 1); i++ {
-		r += " "
+	if len(s) >= l {
+		return s
 	}
-	return r + s
+	return strings.Repeat(" ", l-len(s)) + s
 }
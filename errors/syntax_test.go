@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/source"
+)
+
+func TestNewSyntaxError(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		position uint
+		want     string
+	}{
+		{
+			name:     "first line",
+			body:     "namespace 1\ntype T {}\n",
+			position: 10,
+			want: "Syntax Error spec.apex (1:11) Unexpected Int\n\n" +
+				"1: namespace 1\n" +
+				"             ^\n" +
+				"2: type T {}\n",
+		},
+		{
+			name:     "carriage returns",
+			body:     "namespace \"a\"\r\ntype T {\r  x: }\r\n",
+			position: 29,
+			want: "Syntax Error spec.apex (3:6) Unexpected Int\n\n" +
+				"2: type T {\n" +
+				"3:   x: }\n" +
+				"        ^\n" +
+				"4: \n",
+		},
+		{
+			name:     "last line",
+			body:     "namespace \"a\"\ntype",
+			position: 18,
+			want: "Syntax Error spec.apex (2:5) Unexpected Int\n\n" +
+				"1: namespace \"a\"\n" +
+				"2: type\n" +
+				"       ^\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := source.NewSource("spec.apex", []byte(tt.body))
+			err := errors.NewSyntaxError(s, tt.position, "Unexpected Int")
+			if err.Message != tt.want {
+				t.Errorf("message:\n%s\nwant:\n%s", err.Message, tt.want)
+			}
+			if len(err.Locations) != 1 {
+				t.Fatalf("locations = %v", err.Locations)
+			}
+		})
+	}
+}
+
+func TestNewImportError(t *testing.T) {
+	s := source.NewSource("a.apex", []byte("namespace \"a\"\nimport * from \"b.apex\"\n"))
+	err := errors.NewImportError(s, 14, "Import cycle: a.apex imports b.apex imports a.apex.")
+	want := "Import Error a.apex (2:1) Import cycle: a.apex imports b.apex imports a.apex.\n\n" +
+		"1: namespace \"a\"\n" +
+		"2: import * from \"b.apex\"\n" +
+		"   ^\n" +
+		"3: \n"
+	if err.Message != want {
+		t.Errorf("message:\n%s\nwant:\n%s", err.Message, want)
+	}
+	if err.Source != s || len(err.Positions) != 1 || err.Positions[0] != 14 {
+		t.Errorf("source %v, positions %v", err.Source, err.Positions)
+	}
+}
@@ -1,24 +1,22 @@
 package errors
 
 import (
-	json "encoding/json"
 	"os"
 
+	"github.com/CosmWasm/tinyjson/jwriter"
 	"github.com/tetratelabs/tinymem"
 )
 
 func Return(errs ...error) (ptrSize uint64) {
 	cerrs := Convert(errs...)
-	jsonBytes, _ := json.Marshal(cerrs)
-	jsonString := string(jsonBytes)
+	jsonString := string(cerrs.marshal())
 	ptr, size := tinymem.StringToPtr(jsonString)
 	return (uint64(ptr) << uint64(32)) | uint64(size)
 }
 
 func Write(errs ...error) {
 	cerrs := Convert(errs...)
-	jsonBytes, _ := json.Marshal(cerrs)
-	os.Stderr.Write(jsonBytes)
+	os.Stderr.Write(cerrs.marshal())
 	os.Exit(1)
 }
 
@@ -35,3 +33,18 @@ func Convert(errs ...error) Errors {
 	}
 	return e
 }
+
+// marshal writes errs as a JSON array with the generated tinyjson
+// marshalers, keeping encoding/json and its reflection out of wasm builds.
+func (errs Errors) marshal() []byte {
+	w := jwriter.Writer{}
+	w.RawByte('[')
+	for i, e := range errs {
+		if i > 0 {
+			w.RawByte(',')
+		}
+		e.MarshalTinyJSON(&w)
+	}
+	w.RawByte(']')
+	return w.Buffer.BuildBytes()
+}
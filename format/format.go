@@ -0,0 +1,467 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package format prints Apex documents in canonical form.
+package format
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/lexer"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/source"
+)
+
+// Source parses and formats an Apex specification. Imports are not resolved
+// and comments are preserved.
+func Source(src *source.Source) ([]byte, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: src,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(Document(doc)), nil
+}
+
+// Document prints doc in canonical form. When doc was parsed with
+// locations and source, comments from the source are kept in place.
+func Document(doc *ast.Document) string {
+	p := printer{}
+	if loc := doc.GetLoc(); loc != nil && loc.Source != nil {
+		p.comments = scanComments(loc.Source)
+	}
+	for i, def := range doc.Definitions {
+		if i > 0 && !consecutiveImports(doc.Definitions[i-1], def) {
+			p.b.WriteString("\n")
+		}
+		p.definition(def)
+	}
+	p.flushComments(^uint(0))
+	return p.b.String()
+}
+
+// consecutiveImports reports whether prev and def are imports that are kept
+// together without a blank line.
+func consecutiveImports(prev, def ast.Node) bool {
+	_, ok1 := prev.(*ast.ImportDefinition)
+	_, ok2 := def.(*ast.ImportDefinition)
+	return ok1 && ok2
+}
+
+type comment struct {
+	start uint
+	// prevEnd is the end of the token before the comment.
+	prevEnd uint
+	// trailing is true when the comment is on the same line as prevEnd.
+	trailing bool
+	text     string
+}
+
+// scanComments collects the comments between tokens of src.
+func scanComments(src *source.Source) []comment {
+	var comments []comment
+	lex := lexer.Lex(src)
+	body := src.Body
+	var prevEnd uint
+	for {
+		tok, err := lex(0)
+		if err != nil {
+			return comments
+		}
+		end := tok.Start
+		if tok.Kind == lexer.EOF {
+			end = uint(len(body))
+		}
+		trailing := prevEnd > 0
+		for i := prevEnd; i < end && i < uint(len(body)); i++ {
+			switch body[i] {
+			case '\n', '\r':
+				trailing = false
+			case '#':
+				j := i
+				for j < end && body[j] != '\n' && body[j] != '\r' {
+					j++
+				}
+				comments = append(comments, comment{
+					start:    i,
+					prevEnd:  prevEnd,
+					trailing: trailing,
+					text:     strings.TrimRight(string(body[i:j]), " \t"),
+				})
+				trailing = false
+				i = j - 1
+			}
+		}
+		if tok.Kind == lexer.EOF {
+			return comments
+		}
+		prevEnd = tok.End
+	}
+}
+
+type printer struct {
+	b        strings.Builder
+	indent   int
+	comments []comment
+}
+
+func (p *printer) line(s string) {
+	p.b.WriteString(strings.Repeat("  ", p.indent))
+	p.b.WriteString(s)
+}
+
+// flushComments prints the comments that start before pos on their own lines.
+func (p *printer) flushComments(pos uint) {
+	for len(p.comments) > 0 && p.comments[0].start < pos {
+		p.line(p.comments[0].text + "\n")
+		p.comments = p.comments[1:]
+	}
+}
+
+// end finishes the line of node, appending a comment that trails it.
+func (p *printer) end(node ast.Node) {
+	if loc := node.GetLoc(); loc != nil && len(p.comments) > 0 {
+		c := p.comments[0]
+		if c.trailing && c.prevEnd == loc.End {
+			p.b.WriteString(" " + c.text)
+			p.comments = p.comments[1:]
+		}
+	}
+	p.b.WriteString("\n")
+}
+
+func (p *printer) begin(node ast.Node, description *ast.StringValue) {
+	if loc := node.GetLoc(); loc != nil {
+		p.flushComments(loc.Start)
+	}
+	p.description(description)
+}
+
+func (p *printer) closeBlock(node ast.Node) {
+	if loc := node.GetLoc(); loc != nil {
+		p.indent++
+		p.flushComments(loc.End)
+		p.indent--
+	}
+	p.line("}")
+	p.end(node)
+}
+
+func (p *printer) definition(def ast.Node) {
+	switch d := def.(type) {
+	case *ast.NamespaceDefinition:
+		p.begin(d, d.Description)
+		p.line("namespace " + quote(d.Name.Value) + annotations(d.Annotations))
+		p.end(d)
+
+	case *ast.ImportDefinition:
+		p.begin(d, d.Description)
+		var b strings.Builder
+		b.WriteString("import ")
+		if d.All {
+			b.WriteString("*")
+			if d.Alias != nil {
+				b.WriteString(" as " + d.Alias.Value)
+			}
+		} else {
+			b.WriteString("{ ")
+			for i, n := range d.Names {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(n.Name.Value)
+				if n.Alias != nil {
+					b.WriteString(" as " + n.Alias.Value)
+				}
+			}
+			b.WriteString(" }")
+		}
+		b.WriteString(" from " + quote(d.From.Value) + annotations(d.Annotations))
+		p.line(b.String())
+		p.end(d)
+
+	case *ast.DirectiveDefinition:
+		p.begin(d, d.Description)
+		var b strings.Builder
+		// The parser requires the parameters of a directive, even when
+		// there are none.
+		b.WriteString("directive @" + d.Name.Value + parameters(d.Parameters))
+		b.WriteString(" on " + names(d.Locations, " | "))
+		if len(d.Requires) > 0 {
+			b.WriteString(" require")
+			for i, r := range d.Requires {
+				if i > 0 {
+					b.WriteString(" |")
+				}
+				b.WriteString(" @" + r.Directive.Value + " " + names(r.Locations, " | "))
+			}
+		}
+		p.line(b.String())
+		p.end(d)
+
+	case *ast.AliasDefinition:
+		p.begin(d, d.Description)
+		p.line("alias " + d.Name.Value + " = " + Type(d.Type) + annotations(d.Annotations))
+		p.end(d)
+
+	case *ast.OperationDefinition:
+		p.operation("func ", d)
+
+	case *ast.InterfaceDefinition:
+		p.begin(d, d.Description)
+		p.line("interface " + d.Name.Value + annotations(d.Annotations) + " {\n")
+		p.indent++
+		for _, oper := range d.Operations {
+			p.operation("", oper)
+		}
+		p.indent--
+		p.closeBlock(d)
+
+	case *ast.TypeDefinition:
+		p.begin(d, d.Description)
+		var b strings.Builder
+		b.WriteString("type " + d.Name.Value)
+		if len(d.Interfaces) > 0 {
+			b.WriteString(" implements ")
+			for i, iface := range d.Interfaces {
+				if i > 0 {
+					b.WriteString(" & ")
+				}
+				b.WriteString(iface.Name.Value)
+			}
+		}
+		b.WriteString(annotations(d.Annotations) + " {\n")
+		p.line(b.String())
+		p.indent++
+		for _, field := range d.Fields {
+			p.begin(field, field.Description)
+			p.line(field.Name.Value + ": " + Type(field.Type) + defaultValue(field.Default) + annotations(field.Annotations))
+			p.end(field)
+		}
+		p.indent--
+		p.closeBlock(d)
+
+	case *ast.UnionDefinition:
+		p.begin(d, d.Description)
+		var b strings.Builder
+		b.WriteString("union " + d.Name.Value + annotations(d.Annotations) + " =")
+		for i, m := range d.Members {
+			if i > 0 {
+				b.WriteString(" |")
+			}
+			if m.Description != nil {
+				b.WriteString(" " + quote(m.Description.Value))
+			}
+			b.WriteString(" " + Type(m.Type) + annotations(m.Annotations))
+		}
+		p.line(b.String())
+		p.end(d)
+
+	case *ast.EnumDefinition:
+		p.begin(d, d.Description)
+		p.line("enum " + d.Name.Value + annotations(d.Annotations) + " {\n")
+		p.indent++
+		for _, v := range d.Values {
+			p.begin(v, v.Description)
+			s := v.Name.Value + " = " + strconv.Itoa(v.Index.Value) + annotations(v.Annotations)
+			if v.Display != nil {
+				s += " as " + quote(v.Display.Value)
+			}
+			p.line(s)
+			p.end(v)
+		}
+		p.indent--
+		p.closeBlock(d)
+	}
+}
+
+func (p *printer) operation(keyword string, oper *ast.OperationDefinition) {
+	p.begin(oper, oper.Description)
+	var b strings.Builder
+	b.WriteString(keyword + oper.Name.Value)
+	if oper.Unary && len(oper.Parameters) == 1 {
+		b.WriteString("[" + parameter(oper.Parameters[0]) + "]")
+	} else {
+		b.WriteString(parameters(oper.Parameters))
+	}
+	if named, ok := oper.Type.(*ast.Named); !ok || named.Namespace != nil || named.Name.Value != "void" {
+		b.WriteString(": " + Type(oper.Type))
+	}
+	b.WriteString(annotations(oper.Annotations))
+	p.line(b.String())
+	p.end(oper)
+}
+
+func (p *printer) description(description *ast.StringValue) {
+	if description == nil {
+		return
+	}
+	if !strings.Contains(description.Value, "\n") {
+		p.line(quote(description.Value) + "\n")
+		return
+	}
+	p.line(`"""` + "\n")
+	for _, l := range strings.Split(description.Value, "\n") {
+		if l == "" {
+			p.b.WriteString("\n")
+			continue
+		}
+		p.line(strings.ReplaceAll(l, `"""`, `\"""`) + "\n")
+	}
+	p.line(`"""` + "\n")
+}
+
+func parameters(params []*ast.ParameterDefinition) string {
+	var b strings.Builder
+	b.WriteString("(")
+	for i, param := range params {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(parameter(param))
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func parameter(param *ast.ParameterDefinition) string {
+	var s string
+	if param.Description != nil {
+		s = quote(param.Description.Value) + " "
+	}
+	return s + param.Name.Value + ": " + Type(param.Type) + defaultValue(param.Default) + annotations(param.Annotations)
+}
+
+func defaultValue(v ast.Value) string {
+	if v == nil {
+		return ""
+	}
+	return " = " + Value(v)
+}
+
+func annotations(annotations []*ast.Annotation) string {
+	var b strings.Builder
+	for _, a := range annotations {
+		b.WriteString(" @" + a.Name.Value)
+		if len(a.Arguments) == 0 {
+			continue
+		}
+		b.WriteString("(")
+		if len(a.Arguments) == 1 && a.Arguments[0].Name.Loc == nil {
+			// The argument was written without a name.
+			b.WriteString(Value(a.Arguments[0].Value))
+		} else {
+			for i, arg := range a.Arguments {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(arg.Name.Value + ": " + Value(arg.Value))
+			}
+		}
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+func names(items []*ast.Name, sep string) string {
+	s := make([]string, len(items))
+	for i, n := range items {
+		s[i] = n.Value
+	}
+	return strings.Join(s, sep)
+}
+
+// Type returns the source form of t.
+func Type(t ast.Type) string {
+	switch v := t.(type) {
+	case *ast.Named:
+		return v.QualifiedName()
+	case *ast.Optional:
+		return Type(v.Type) + "?"
+	case *ast.ListType:
+		return "[" + Type(v.Type) + "]"
+	case *ast.MapType:
+		return "{" + Type(v.KeyType) + ": " + Type(v.ValueType) + "}"
+	case *ast.Stream:
+		return "stream " + Type(v.Type)
+	}
+	return ""
+}
+
+// Value returns the source form of v.
+func Value(v ast.Value) string {
+	switch t := v.(type) {
+	case *ast.StringValue:
+		return quote(t.Value)
+	case *ast.IntValue:
+		return strconv.Itoa(t.Value)
+	case *ast.FloatValue:
+		s := strconv.FormatFloat(t.Value, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eE") {
+			s += ".0"
+		}
+		return s
+	case *ast.BooleanValue:
+		return strconv.FormatBool(t.Value)
+	case *ast.EnumValue:
+		return t.Value
+	case *ast.ListValue:
+		s := make([]string, len(t.Values))
+		for i, item := range t.Values {
+			s[i] = Value(item)
+		}
+		return "[" + strings.Join(s, ", ") + "]"
+	case *ast.ObjectValue:
+		s := make([]string, len(t.Fields))
+		for i, f := range t.Fields {
+			s[i] = f.Name.Value + ": " + Value(f.Value)
+		}
+		return "{" + strings.Join(s, ", ") + "}"
+	}
+	return ""
+}
+
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				b.WriteString(`\u00`)
+				b.WriteByte("0123456789abcdef"[r>>4])
+				b.WriteByte("0123456789abcdef"[r&0xF])
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package format_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/format"
+	"github.com/apexlang/apex-go/source"
+)
+
+const messy = `# leading comment
+namespace   "test"  @info(version: "1.0")
+import * from "./a.apex"
+import { A, B as C } from "./b.apex"
+"Directive doc"
+directive @info(version: string, tags: [string]? = []) on NAMESPACE | INTERFACE require @other TYPE
+alias UUID=string @format(name:"uuid")
+# about users
+"A user."
+type User @entity {
+  "The ID." id: UUID @key
+  tags: [string]?
+  attrs: {string:i64} = {}
+  kind: Kind = FOO
+}
+interface Users @service{
+  get(id: UUID): User
+  put[user: User]: void
+  list(
+    offset: u32 = 0,
+    limit: u32 = 10
+  ): stream User
+}
+func ping(): string
+union Thing = User | string | Other
+enum Kind { FOO = 0 as "foo" BAR = 1 }
+`
+
+const formatted = `# leading comment
+namespace "test" @info(version: "1.0")
+
+import * from "./a.apex"
+import { A, B as C } from "./b.apex"
+
+"Directive doc"
+directive @info(version: string, tags: [string]? = []) on NAMESPACE | INTERFACE require @other TYPE
+
+alias UUID = string @format(name: "uuid")
+
+# about users
+"A user."
+type User @entity {
+  "The ID."
+  id: UUID @key
+  tags: [string]?
+  attrs: {string: i64} = {}
+  kind: Kind = FOO
+}
+
+interface Users @service {
+  get(id: UUID): User
+  put[user: User]
+  list(offset: u32 = 0, limit: u32 = 10): stream User
+}
+
+func ping(): string
+
+union Thing = User | string | Other
+
+enum Kind {
+  FOO = 0 as "foo"
+  BAR = 1
+}
+`
+
+func formatSource(t *testing.T, text string) string {
+	t.Helper()
+	out, err := format.Source(source.NewSource("test.apex", []byte(text)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestSource(t *testing.T) {
+	if got := formatSource(t, messy); got != formatted {
+		t.Errorf("formatted:\n%s\nwant:\n%s", got, formatted)
+	}
+}
+
+func TestSourceIsIdempotent(t *testing.T) {
+	if got := formatSource(t, formatted); got != formatted {
+		t.Errorf("formatting formatted source changed it:\n%s", got)
+	}
+}
+
+func TestSourceComments(t *testing.T) {
+	got := formatSource(t, `namespace "test"
+# before
+type T {
+  # inside
+  a: string
+}
+# trailing
+`)
+	for _, comment := range []string{"# before\ntype T {", "  # inside\n  a: string", "}\n# trailing\n"} {
+		if !strings.Contains(got, comment) {
+			t.Errorf("formatted source lost %q:\n%s", comment, got)
+		}
+	}
+}
+
+func TestSourceSyntaxError(t *testing.T) {
+	_, err := format.Source(source.NewSource("test.apex", []byte("type T {")))
+	if err == nil || !strings.Contains(err.Error(), "Syntax Error") {
+		t.Errorf("error = %v, want a syntax error", err)
+	}
+}
@@ -46,16 +46,17 @@ const (
 	Stream   Kind = "Stream"
 
 	// Definitions
-	NamespaceDefinition Kind = "NamespaceDefinition"
-	ImportDefinition    Kind = "ImportDefinition"
-	AliasDefinition     Kind = "AliasDefinition"
-	InterfaceDefinition Kind = "InterfaceDefinition"
-	OperationDefinition Kind = "OperationDefinition"
-	ParameterDefinition Kind = "ParameterDefinition"
-	TypeDefinition      Kind = "TypeDefinition"
-	FieldDefinition     Kind = "FieldDefinition"
-	UnionDefinition     Kind = "UnionDefinition"
-	EnumDefinition      Kind = "EnumDefinition"
-	EnumValueDefinition Kind = "EnumValueDefinition"
-	DirectiveDefinition Kind = "DirectiveDefinition"
+	NamespaceDefinition   Kind = "NamespaceDefinition"
+	ImportDefinition      Kind = "ImportDefinition"
+	AliasDefinition       Kind = "AliasDefinition"
+	InterfaceDefinition   Kind = "InterfaceDefinition"
+	OperationDefinition   Kind = "OperationDefinition"
+	ParameterDefinition   Kind = "ParameterDefinition"
+	TypeDefinition        Kind = "TypeDefinition"
+	FieldDefinition       Kind = "FieldDefinition"
+	UnionDefinition       Kind = "UnionDefinition"
+	UnionMemberDefinition Kind = "UnionMemberDefinition"
+	EnumDefinition        Kind = "EnumDefinition"
+	EnumValueDefinition   Kind = "EnumValueDefinition"
+	DirectiveDefinition   Kind = "DirectiveDefinition"
 )
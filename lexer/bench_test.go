@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lexer_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/apexlang/apex-go/lexer"
+	"github.com/apexlang/apex-go/source"
+)
+
+// benchSpec returns a specification with n repetitions of definitions
+// that use every kind of token.
+func benchSpec(n int) []byte {
+	var b bytes.Buffer
+	b.WriteString("namespace \"bench.v1\"\n\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, `"""
+Record%[1]d is a generated type.
+  It has "quotes" and é.
+"""
+type Record%[1]d @info(tags: ["a", "b"], meta: {key: "value"}) {
+  "The id."
+  id: string @key # trailing comment
+  score: f64 = -12.5e-3 @range(min: 0, max: 100)
+  labels: {string: [i64]}?
+  status: bench.Status = ACTIVE
+}
+
+union Any%[1]d = Record%[1]d | string
+func lookup%[1]d[id: string]: stream Record%[1]d & Any%[1]d
+
+`, i)
+	}
+	return b.Bytes()
+}
+
+func BenchmarkLex(b *testing.B) {
+	for _, n := range []int{10, 1000} {
+		body := benchSpec(n)
+		b.Run(fmt.Sprintf("defs=%d", n), func(b *testing.B) {
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				lex := lexer.Lex(source.NewSource("bench.apex", body))
+				for {
+					token, err := lex(0)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if token.Kind == lexer.EOF {
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkScan(b *testing.B) {
+	body := benchSpec(1000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s := lexer.NewScanner(source.NewSource("bench.apex", body))
+		for s.Scan() {
+		}
+		if err := s.Err(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -17,9 +17,7 @@ limitations under the License.
 package lexer
 
 import (
-	"bytes"
-	"fmt"
-	"regexp"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
@@ -27,6 +25,7 @@ import (
 	"github.com/apexlang/apex-go/source"
 )
 
+// Token kinds. Token.Kind holds one of these constants.
 const (
 	EOF = iota + 1
 	BANG
@@ -51,6 +50,7 @@ const (
 	STRING
 	BLOCK_STRING
 	AMP
+	COMMENT
 )
 
 // NAME -> keyword relationship
@@ -66,61 +66,61 @@ const (
 	DIRECTIVE = "directive"
 )
 
-var TokenKind map[int]int
-var tokenDescription map[int]string
-
-func init() {
-	TokenKind = make(map[int]int)
-	{
-		TokenKind[EOF] = EOF
-		TokenKind[BANG] = BANG
-		TokenKind[QUESTION] = QUESTION
-		TokenKind[DOLLAR] = DOLLAR
-		TokenKind[PAREN_L] = PAREN_L
-		TokenKind[PAREN_R] = PAREN_R
-		TokenKind[SPREAD] = SPREAD
-		TokenKind[COLON] = COLON
-		TokenKind[EQUALS] = EQUALS
-		TokenKind[STAR] = STAR
-		TokenKind[AT] = AT
-		TokenKind[BRACKET_L] = BRACKET_L
-		TokenKind[BRACKET_R] = BRACKET_R
-		TokenKind[BRACE_L] = BRACE_L
-		TokenKind[PIPE] = PIPE
-		TokenKind[BRACE_R] = BRACE_R
-		TokenKind[NAME] = NAME
-		TokenKind[NS] = NS
-		TokenKind[INT] = INT
-		TokenKind[FLOAT] = FLOAT
-		TokenKind[STRING] = STRING
-		TokenKind[BLOCK_STRING] = BLOCK_STRING
-	}
-	tokenDescription = make(map[int]string)
-	{
-		tokenDescription[TokenKind[EOF]] = "EOF"
-		tokenDescription[TokenKind[BANG]] = "!"
-		tokenDescription[TokenKind[QUESTION]] = "?"
-		tokenDescription[TokenKind[DOLLAR]] = "$"
-		tokenDescription[TokenKind[PAREN_L]] = "("
-		tokenDescription[TokenKind[PAREN_R]] = ")"
-		tokenDescription[TokenKind[SPREAD]] = "..."
-		tokenDescription[TokenKind[COLON]] = ":"
-		tokenDescription[TokenKind[EQUALS]] = "="
-		tokenDescription[TokenKind[STAR]] = "*"
-		tokenDescription[TokenKind[AT]] = "@"
-		tokenDescription[TokenKind[BRACKET_L]] = "["
-		tokenDescription[TokenKind[BRACKET_R]] = "]"
-		tokenDescription[TokenKind[BRACE_L]] = "{"
-		tokenDescription[TokenKind[PIPE]] = "|"
-		tokenDescription[TokenKind[BRACE_R]] = "}"
-		tokenDescription[TokenKind[NAME]] = "Name"
-		tokenDescription[TokenKind[NS]] = "NS"
-		tokenDescription[TokenKind[INT]] = "Int"
-		tokenDescription[TokenKind[FLOAT]] = "Float"
-		tokenDescription[TokenKind[STRING]] = "String"
-		tokenDescription[TokenKind[BLOCK_STRING]] = "BlockString"
-		tokenDescription[TokenKind[AMP]] = "&"
-	}
+// TokenKind maps each token kind to itself.
+//
+// Deprecated: token kinds are constants; use them directly.
+var TokenKind = map[int]int{
+	EOF:          EOF,
+	BANG:         BANG,
+	QUESTION:     QUESTION,
+	DOLLAR:       DOLLAR,
+	PAREN_L:      PAREN_L,
+	PAREN_R:      PAREN_R,
+	SPREAD:       SPREAD,
+	COLON:        COLON,
+	EQUALS:       EQUALS,
+	STAR:         STAR,
+	AT:           AT,
+	BRACKET_L:    BRACKET_L,
+	BRACKET_R:    BRACKET_R,
+	BRACE_L:      BRACE_L,
+	PIPE:         PIPE,
+	BRACE_R:      BRACE_R,
+	NAME:         NAME,
+	NS:           NS,
+	INT:          INT,
+	FLOAT:        FLOAT,
+	STRING:       STRING,
+	BLOCK_STRING: BLOCK_STRING,
+	AMP:          AMP,
+	COMMENT:      COMMENT,
+}
+
+var tokenDescription = [...]string{
+	EOF:          "EOF",
+	BANG:         "!",
+	QUESTION:     "?",
+	DOLLAR:       "$",
+	PAREN_L:      "(",
+	PAREN_R:      ")",
+	SPREAD:       "...",
+	COLON:        ":",
+	EQUALS:       "=",
+	STAR:         "*",
+	AT:           "@",
+	BRACKET_L:    "[",
+	BRACKET_R:    "]",
+	BRACE_L:      "{",
+	PIPE:         "|",
+	BRACE_R:      "}",
+	NAME:         "Name",
+	NS:           "NS",
+	INT:          "Int",
+	FLOAT:        "Float",
+	STRING:       "String",
+	BLOCK_STRING: "BlockString",
+	AMP:          "&",
+	COMMENT:      "Comment",
 }
 
 // Token is a representation of a lexed Token. Value only appears for non-punctuation
@@ -135,12 +135,13 @@ type Token struct {
 type Lexer func(resetPosition uint) (Token, error)
 
 func Lex(s *source.Source) Lexer {
+	t := newTokenizer(s)
 	var prevPosition uint
 	return func(resetPosition uint) (Token, error) {
 		if resetPosition == 0 {
 			resetPosition = prevPosition
 		}
-		token, err := readToken(s, resetPosition)
+		token, err := t.readToken(resetPosition)
 		if err != nil {
 			return token, err
 		}
@@ -149,231 +150,230 @@ func Lex(s *source.Source) Lexer {
 	}
 }
 
+// tokenizer reads tokens from a source in a single pass over its bytes.
+// Names are interned so that repeated names share one string.
+type tokenizer struct {
+	src  *source.Source
+	body []byte
+	// names is a direct mapped cache of interned names indexed by a hash
+	// of their bytes.
+	names [1024]string
+}
+
+func newTokenizer(s *source.Source) *tokenizer {
+	return &tokenizer{
+		src:  s,
+		body: s.Body,
+	}
+}
+
+// intern returns b as a string, reusing the string of an earlier name
+// with the same bytes and hash.
+func (t *tokenizer) intern(b []byte, hash uint32) string {
+	slot := &t.names[hash%uint32(len(t.names))]
+	// The conversion in the comparison does not allocate.
+	if *slot == string(b) {
+		return *slot
+	}
+	name := string(b)
+	*slot = name
+	return name
+}
+
 // Reads an alphanumeric + underscore name from the source.
 // [_A-Za-z][_0-9A-Za-z]*
 // position: Points to the byte position in the byte array
-// runePosition: Points to the rune position in the byte array
-func readName(source *source.Source, position, runePosition uint) Token {
-	body := source.Body
-	bodyLength := uint(len(body))
-	endByte := position + 1
-	endRune := runePosition + 1
+func (t *tokenizer) readName(position uint) Token {
+	body := t.body
+	end := position
 	kind := NAME
-	for {
-		code, _ := runeAt(body, endByte)
-		if (endByte != bodyLength) &&
-			(code == '_' || code == '.' || // _
-				(code >= '0' && code <= '9') || // 0-9
-				(code >= 'A' && code <= 'Z') || // A-Z
-				(code >= 'a' && code <= 'z')) { // a-z
-			if code == '.' {
-				kind = NS
-			}
-			endByte++
-			endRune++
-			continue
-		} else {
+	// FNV-1a hash of the name for interning.
+	hash := uint32(2166136261)
+	for ; end < uint(len(body)); end++ {
+		code := body[end]
+		if code == '.' {
+			kind = NS
+		} else if !isNameByte(code) {
 			break
 		}
+		hash = (hash ^ uint32(code)) * 16777619
 	}
-	return makeToken(TokenKind[kind], runePosition, endRune, string(body[position:endByte]))
+	return makeToken(kind, position, end, t.intern(body[position:end], hash))
+}
+
+func isNameByte(code byte) bool {
+	return code == '_' ||
+		(code >= '0' && code <= '9') ||
+		(code >= 'A' && code <= 'Z') ||
+		(code >= 'a' && code <= 'z')
 }
 
 // Reads a number token from the source file, either a float
 // or an int depending on whether a decimal point appears.
 // Int:   -?(0|[1-9][0-9]*)
 // Float: -?(0|[1-9][0-9]*)(\.[0-9]+)?((E|e)(+|-)?[0-9]+)?
-func readNumber(s *source.Source, start uint, firstCode rune, codeLength uint) (Token, error) {
-	code := firstCode
-	body := s.Body
+func (t *tokenizer) readNumber(start uint) (Token, error) {
+	body := t.body
 	position := start
 	isFloat := false
+	code := byteAt(body, position)
 	if code == '-' { // -
-		position += codeLength
-		code, codeLength = runeAt(body, position)
+		position++
+		code = byteAt(body, position)
 	}
 	if code == '0' { // 0
-		position += codeLength
-		code, codeLength = runeAt(body, position)
+		position++
+		code = byteAt(body, position)
 		if code >= '0' && code <= '9' {
-			description := fmt.Sprintf("Invalid number, unexpected digit after 0: %v.", printCharCode(code))
-			return Token{}, errors.NewSyntaxError(s, position, description)
+			description := "Invalid number, unexpected digit after 0: " + printCharCode(rune(code)) + "."
+			return Token{}, errors.NewSyntaxError(t.src, position, description)
 		}
 	} else {
-		p, err := readDigits(s, position, code, codeLength)
+		p, err := t.readDigits(position)
 		if err != nil {
 			return Token{}, err
 		}
 		position = p
-		code, codeLength = runeAt(body, position)
+		code = byteAt(body, position)
 	}
 	if code == '.' { // .
 		isFloat = true
-		position += codeLength
-		code, codeLength = runeAt(body, position)
-		p, err := readDigits(s, position, code, codeLength)
+		position++
+		p, err := t.readDigits(position)
 		if err != nil {
 			return Token{}, err
 		}
 		position = p
-		code, codeLength = runeAt(body, position)
+		code = byteAt(body, position)
 	}
 	if code == 'E' || code == 'e' { // E e
 		isFloat = true
-		position += codeLength
-		code, codeLength = runeAt(body, position)
+		position++
+		code = byteAt(body, position)
 		if code == '+' || code == '-' { // + -
-			position += codeLength
-			code, codeLength = runeAt(body, position)
+			position++
 		}
-		p, err := readDigits(s, position, code, codeLength)
+		p, err := t.readDigits(position)
 		if err != nil {
 			return Token{}, err
 		}
 		position = p
 	}
-	kind := TokenKind[INT]
+	kind := INT
 	if isFloat {
-		kind = TokenKind[FLOAT]
+		kind = FLOAT
 	}
 
 	return makeToken(kind, start, position, string(body[start:position])), nil
 }
 
 // Returns the new position in the source after reading digits.
-func readDigits(s *source.Source, start uint, firstCode rune, codeLength uint) (uint, error) {
-	body := s.Body
+func (t *tokenizer) readDigits(start uint) (uint, error) {
+	body := t.body
 	position := start
-	code := firstCode
-	if code >= '0' && code <= '9' { // 0 - 9
-		for {
-			if code >= '0' && code <= '9' { // 0 - 9
-				position += codeLength
-				code, codeLength = runeAt(body, position)
-				continue
-			} else {
-				break
-			}
-		}
+	for position < uint(len(body)) && body[position] >= '0' && body[position] <= '9' {
+		position++
+	}
+	if position > start {
 		return position, nil
 	}
-	description := fmt.Sprintf("Invalid number, expected digit but got: %v.", printCharCode(code))
-	return position, errors.NewSyntaxError(s, position, description)
+	code, _ := runeAt(body, position)
+	description := "Invalid number, expected digit but got: " + printCharCode(code) + "."
+	return position, errors.NewSyntaxError(t.src, position, description)
 }
 
-func readString(s *source.Source, start uint) (Token, error) {
-	body := s.Body
+// readString reads a string token from the source file. The value is
+// sliced from the body unless the string contains escape sequences.
+func (t *tokenizer) readString(start uint) (Token, error) {
+	body := t.body
 	position := start + 1
-	runePosition := start + 1
 	chunkStart := position
-	var code rune
-	var n uint
-	var valueBuffer bytes.Buffer
-	for {
-		code, n = runeAt(body, position)
-		if position < uint(len(body)) &&
-			// not LineTerminator
-			code != 0x000A && code != 0x000D &&
-			// not Quote (")
-			code != '"' {
-
-			// SourceCharacter
-			if code < 0x0020 && code != 0x0009 {
-				return Token{}, errors.NewSyntaxError(s, runePosition, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(code)))
-			}
-			position += n
-			runePosition++
-			if code == '\\' { // \
-				valueBuffer.Write(body[chunkStart : position-1])
-				code, n = runeAt(body, position)
-				switch code {
-				case '"':
-					valueBuffer.WriteRune('"')
-				case '/':
-					valueBuffer.WriteRune('/')
-				case '\\':
-					valueBuffer.WriteRune('\\')
-				case 'b':
-					valueBuffer.WriteRune('\b')
-				case 'f':
-					valueBuffer.WriteRune('\f')
-				case 'n':
-					valueBuffer.WriteRune('\n')
-				case 'r':
-					valueBuffer.WriteRune('\r')
-				case 't':
-					valueBuffer.WriteRune('\t')
-				case 'u':
-					// Check if there are at least 4 bytes available
-					if uint(len(body)) <= position+4 {
-						return Token{}, errors.NewSyntaxError(s, runePosition,
-							fmt.Sprintf("Invalid character escape sequence: "+
-								"\\u%v", string(body[position+1:])))
-					}
-					charCode := uniCharCode(
-						rune(body[position+1]),
-						rune(body[position+2]),
-						rune(body[position+3]),
-						rune(body[position+4]),
-					)
-					if charCode < 0 {
-						return Token{}, errors.NewSyntaxError(s, runePosition,
-							fmt.Sprintf("Invalid character escape sequence: "+
-								"\\u%v", string(body[position+1:position+5])))
-					}
-					valueBuffer.WriteRune(charCode)
-					position += 4
-					runePosition += 4
-				default:
-					return Token{}, errors.NewSyntaxError(s, runePosition,
-						fmt.Sprintf(`Invalid character escape sequence: \\%c.`, code))
-				}
-				position += n
-				runePosition++
-				chunkStart = position
+	var value []byte
+	for position < uint(len(body)) {
+		code := body[position]
+		if code == '"' {
+			if value == nil {
+				return makeToken(STRING, start, position+1, string(body[chunkStart:position])), nil
 			}
-			continue
-		} else {
+			value = append(value, body[chunkStart:position]...)
+			return makeToken(STRING, start, position+1, string(value)), nil
+		}
+		// LineTerminator
+		if code == '\n' || code == '\r' {
 			break
 		}
+		// SourceCharacter
+		if code < 0x0020 && code != 0x0009 {
+			return Token{}, errors.NewSyntaxError(t.src, position, "Invalid character within String: "+printCharCode(rune(code))+".")
+		}
+		position++
+		if code != '\\' {
+			continue
+		}
+
+		value = append(value, body[chunkStart:position-1]...)
+		escape, n := runeAt(body, position)
+		switch escape {
+		case '"':
+			value = append(value, '"')
+		case '/':
+			value = append(value, '/')
+		case '\\':
+			value = append(value, '\\')
+		case 'b':
+			value = append(value, '\b')
+		case 'f':
+			value = append(value, '\f')
+		case 'n':
+			value = append(value, '\n')
+		case 'r':
+			value = append(value, '\r')
+		case 't':
+			value = append(value, '\t')
+		case 'u':
+			// Check if there are at least 4 bytes available
+			if uint(len(body)) <= position+4 {
+				return Token{}, errors.NewSyntaxError(t.src, position,
+					"Invalid character escape sequence: \\u"+string(body[position+1:]))
+			}
+			charCode := uniCharCode(
+				rune(body[position+1]),
+				rune(body[position+2]),
+				rune(body[position+3]),
+				rune(body[position+4]),
+			)
+			if charCode < 0 {
+				return Token{}, errors.NewSyntaxError(t.src, position,
+					"Invalid character escape sequence: \\u"+string(body[position+1:position+5]))
+			}
+			value = utf8.AppendRune(value, charCode)
+			position += 4
+		default:
+			return Token{}, errors.NewSyntaxError(t.src, position,
+				`Invalid character escape sequence: \\`+string(escape)+".")
+		}
+		position += n
+		chunkStart = position
 	}
-	if code != '"' { // quote (")
-		return Token{}, errors.NewSyntaxError(s, runePosition, "Unterminated string.")
-	}
-	stringContent := body[chunkStart:position]
-	valueBuffer.Write(stringContent)
-	value := valueBuffer.String()
-	return makeToken(TokenKind[STRING], start, position+1, value), nil
+	return Token{}, errors.NewSyntaxError(t.src, position, "Unterminated string.")
 }
 
 // readBlockString reads a block string token from the source file.
 //
 // """("?"?(\\"""|\\(?!=""")|[^"\\]))*"""
-func readBlockString(s *source.Source, start uint) (Token, error) {
-	body := s.Body
+func (t *tokenizer) readBlockString(start uint) (Token, error) {
+	body := t.body
 	position := start + 3
-	runePosition := start + 3
 	chunkStart := position
-	var valueBuffer bytes.Buffer
-
-	for {
-		// Stop if we've reached the end of the buffer
-		if position >= uint(len(body)) {
-			break
-		}
+	var value []byte
 
-		code, n := runeAt(body, position)
+	for position < uint(len(body)) {
+		code := body[position]
 
 		// Closing Triple-Quote (""")
-		if code == '"' {
-			x, _ := runeAt(body, position+1)
-			y, _ := runeAt(body, position+2)
-			if x == '"' && y == '"' {
-				stringContent := body[chunkStart:position]
-				valueBuffer.Write(stringContent)
-				value := blockStringValue(valueBuffer.String())
-				return makeToken(TokenKind[BLOCK_STRING], start, position+3, value), nil
-			}
+		if code == '"' && byteAt(body, position+1) == '"' && byteAt(body, position+2) == '"' {
+			value = append(value, body[chunkStart:position]...)
+			return makeToken(BLOCK_STRING, start, position+3, blockStringValue(string(value))), nil
 		}
 
 		// SourceCharacter
@@ -381,33 +381,25 @@ func readBlockString(s *source.Source, start uint) (Token, error) {
 			code != 0x0009 &&
 			code != 0x000a &&
 			code != 0x000d {
-			return Token{}, errors.NewSyntaxError(s, runePosition, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(code)))
+			return Token{}, errors.NewSyntaxError(t.src, position, "Invalid character within String: "+printCharCode(rune(code))+".")
 		}
 
 		// Escape Triple-Quote (\""")
-		if code == '\\' { // \
-			x, _ := runeAt(body, position+1)
-			y, _ := runeAt(body, position+2)
-			z, _ := runeAt(body, position+3)
-			if x == '"' && y == '"' && z == '"' {
-				stringContent := append(body[chunkStart:position], []byte(`"""`)...)
-				valueBuffer.Write(stringContent)
-				position += 4     // account for `"""` characters
-				runePosition += 4 // "       "   "     "
-				chunkStart = position
-				continue
-			}
+		if code == '\\' && byteAt(body, position+1) == '"' &&
+			byteAt(body, position+2) == '"' && byteAt(body, position+3) == '"' {
+			value = append(value, body[chunkStart:position]...)
+			value = append(value, `"""`...)
+			position += 4 // account for `\"""` characters
+			chunkStart = position
+			continue
 		}
 
-		position += n
-		runePosition++
+		position++
 	}
 
-	return Token{}, errors.NewSyntaxError(s, runePosition, "Unterminated string.")
+	return Token{}, errors.NewSyntaxError(t.src, position, "Unterminated string.")
 }
 
-var splitLinesRegex = regexp.MustCompile("\r\n|[\n\r]")
-
 // This implements the GraphQL spec's BlockStringValue() static algorithm.
 //
 // Produces the value of a block string from its parsed raw value, similar to
@@ -417,7 +409,7 @@ var splitLinesRegex = regexp.MustCompile("\r\n|[\n\r]")
 // Heavily borrows from: https://github.com/graphql/graphql-js/blob/8e0c599ceccfa8c40d6edf3b72ee2a71490b10e0/src/language/blockStringValue.js
 func blockStringValue(in string) string {
 	// Expand a block string's raw value into independent lines.
-	lines := splitLinesRegex.Split(in, -1)
+	lines := splitLines(in)
 
 	// Remove common indentation from all lines but first
 	commonIndent := -1
@@ -441,26 +433,39 @@ func blockStringValue(in string) string {
 	}
 
 	// Remove leading blank lines.
-	for {
-		if isBlank := lineIsBlank(lines[0]); !isBlank {
-			break
-		}
+	for len(lines) > 0 && lineIsBlank(lines[0]) {
 		lines = lines[1:]
 	}
 
 	// Remove trailing blank lines.
-	for {
-		i := len(lines) - 1
-		if isBlank := lineIsBlank(lines[i]); !isBlank {
-			break
-		}
-		lines = append(lines[:i], lines[i+1:]...)
+	for len(lines) > 0 && lineIsBlank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
 	}
 
 	// Return a string of the lines joined with U+000A.
 	return strings.Join(lines, "\n")
 }
 
+// splitLines splits in at "\r\n", "\n" and "\r".
+func splitLines(in string) []string {
+	lines := make([]string, 0, strings.Count(in, "\n")+1)
+	start := 0
+	for i := 0; i < len(in); i++ {
+		switch in[i] {
+		case '\r':
+			lines = append(lines, in[start:i])
+			if i+1 < len(in) && in[i+1] == '\n' {
+				i++
+			}
+			start = i + 1
+		case '\n':
+			lines = append(lines, in[start:i])
+			start = i + 1
+		}
+	}
+	return append(lines, in[start:])
+}
+
 // leadingWhitespaceLen returns count of whitespace characters on given line.
 func leadingWhitespaceLen(in string) (n int) {
 	for _, ch := range in {
@@ -516,109 +521,110 @@ func printCharCode(code rune) string {
 	}
 	// print as ASCII for printable range
 	if code >= 0x0020 && code < 0x007F {
-		return fmt.Sprintf(`"%c"`, code)
+		return `"` + string(code) + `"`
 	}
 	// Otherwise print the escaped form. e.g. `"\\u0007"`
-	return fmt.Sprintf(`"\\u%04X"`, code)
+	h := strings.ToUpper(strconv.FormatInt(int64(code), 16))
+	if len(h) < 4 {
+		h = strings.Repeat("0", 4-len(h)) + h
+	}
+	return `"\\u` + h + `"`
 }
 
-func readToken(s *source.Source, fromPosition uint) (Token, error) {
-	body := s.Body
+func (t *tokenizer) readToken(fromPosition uint) (Token, error) {
+	body := t.body
 	bodyLength := uint(len(body))
-	position, runePosition := positionAfterWhitespace(body, fromPosition)
+	position := positionAfterWhitespace(body, fromPosition)
 	if position >= bodyLength {
-		return makeToken(TokenKind[EOF], position, position, ""), nil
+		return makeToken(EOF, position, position, ""), nil
 	}
-	code, codeLength := runeAt(body, position)
+	code := body[position]
 
 	// SourceCharacter
 	if code < 0x0020 && code != 0x0009 && code != 0x000A && code != 0x000D {
-		return Token{}, errors.NewSyntaxError(s, runePosition, fmt.Sprintf(`Invalid character %v`, printCharCode(code)))
+		return Token{}, errors.NewSyntaxError(t.src, position, "Invalid character "+printCharCode(rune(code)))
 	}
 
 	switch code {
 	// !
 	case '!':
-		return makeToken(TokenKind[BANG], position, position+1, ""), nil
+		return makeToken(BANG, position, position+1, ""), nil
 	case '?':
-		return makeToken(TokenKind[QUESTION], position, position+1, ""), nil
+		return makeToken(QUESTION, position, position+1, ""), nil
 	// $
 	case '$':
-		return makeToken(TokenKind[DOLLAR], position, position+1, ""), nil
+		return makeToken(DOLLAR, position, position+1, ""), nil
 	// &
 	case '&':
-		return makeToken(TokenKind[AMP], position, position+1, ""), nil
+		return makeToken(AMP, position, position+1, ""), nil
 	// (
 	case '(':
-		return makeToken(TokenKind[PAREN_L], position, position+1, ""), nil
+		return makeToken(PAREN_L, position, position+1, ""), nil
 	// )
 	case ')':
-		return makeToken(TokenKind[PAREN_R], position, position+1, ""), nil
+		return makeToken(PAREN_R, position, position+1, ""), nil
 	case '*':
-		return makeToken(TokenKind[STAR], position, position+1, ""), nil
+		return makeToken(STAR, position, position+1, ""), nil
 	// .
 	case '.':
-		next1, _ := runeAt(body, position+1)
-		next2, _ := runeAt(body, position+2)
-		if next1 == '.' && next2 == '.' {
-			return makeToken(TokenKind[SPREAD], position, position+3, ""), nil
+		if byteAt(body, position+1) == '.' && byteAt(body, position+2) == '.' {
+			return makeToken(SPREAD, position, position+3, ""), nil
 		}
 	// :
 	case ':':
-		return makeToken(TokenKind[COLON], position, position+1, ""), nil
+		return makeToken(COLON, position, position+1, ""), nil
 	// =
 	case '=':
-		return makeToken(TokenKind[EQUALS], position, position+1, ""), nil
+		return makeToken(EQUALS, position, position+1, ""), nil
 	// @
 	case '@':
-		return makeToken(TokenKind[AT], position, position+1, ""), nil
+		return makeToken(AT, position, position+1, ""), nil
 	// [
 	case '[':
-		return makeToken(TokenKind[BRACKET_L], position, position+1, ""), nil
+		return makeToken(BRACKET_L, position, position+1, ""), nil
 	// ]
 	case ']':
-		return makeToken(TokenKind[BRACKET_R], position, position+1, ""), nil
+		return makeToken(BRACKET_R, position, position+1, ""), nil
 	// {
 	case '{':
-		return makeToken(TokenKind[BRACE_L], position, position+1, ""), nil
+		return makeToken(BRACE_L, position, position+1, ""), nil
 	// |
 	case '|':
-		return makeToken(TokenKind[PIPE], position, position+1, ""), nil
+		return makeToken(PIPE, position, position+1, ""), nil
 	// }
 	case '}':
-		return makeToken(TokenKind[BRACE_R], position, position+1, ""), nil
+		return makeToken(BRACE_R, position, position+1, ""), nil
 	// A-Z
 	case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N',
 		'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
-		return readName(s, position, runePosition), nil
+		return t.readName(position), nil
 	// _
 	// a-z
 	case '_', 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n',
 		'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
-		return readName(s, position, runePosition), nil
+		return t.readName(position), nil
 	// -
 	// 0-9
 	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-		token, err := readNumber(s, position, code, codeLength)
-		if err != nil {
-			return token, err
-		}
-		return token, nil
+		return t.readNumber(position)
 	// "
 	case '"':
-		var token Token
-		var err error
-		x, _ := runeAt(body, position+1)
-		y, _ := runeAt(body, position+2)
-		if x == '"' && y == '"' {
-			token, err = readBlockString(s, position)
-		} else {
-			token, err = readString(s, position)
+		if byteAt(body, position+1) == '"' && byteAt(body, position+2) == '"' {
+			return t.readBlockString(position)
 		}
-		return token, err
+		return t.readString(position)
 	}
-	description := fmt.Sprintf("Unexpected character %v.", printCharCode(code))
-	return Token{}, errors.NewSyntaxError(s, runePosition, description)
+	r, _ := runeAt(body, position)
+	description := "Unexpected character " + printCharCode(r) + "."
+	return Token{}, errors.NewSyntaxError(t.src, position, description)
+}
+
+// byteAt returns the byte at position, or 0 past the end of body.
+func byteAt(body []byte, position uint) byte {
+	if position < uint(len(body)) {
+		return body[position]
+	}
+	return 0
 }
 
 // Gets the rune from the byte array at given byte position and it's width in bytes
@@ -638,63 +644,49 @@ func runeAt(body []byte, position uint) (code rune, charWidth uint) {
 }
 
 // Reads from body starting at startPosition until it finds a non-whitespace
-// or commented character, then returns the position of that character for lexing.
+// or commented character, then returns the position of that character for
 // lexing.
-// Returns both byte positions and rune position
-func positionAfterWhitespace(body []byte, startPosition uint) (position uint, runePosition uint) {
+func positionAfterWhitespace(body []byte, startPosition uint) uint {
 	bodyLength := uint(len(body))
-	position = startPosition
-	runePosition = startPosition
-	for {
-		if position < bodyLength {
-			code, n := runeAt(body, position)
-
-			// Skip Ignored
-			if code == 0xFEFF || // BOM
-				// White Space
-				code == 0x0009 || // tab
-				code == 0x0020 || // space
-				// Line Terminator
-				code == 0x000A || // new line
-				code == 0x000D || // carriage return
-				// Comma
-				code == 0x002C {
-				position += n
-				runePosition++
-			} else if code == 35 { // #
-				position += n
-				runePosition++
-				for {
-					code, n := runeAt(body, position)
-					if position < bodyLength &&
-						code != 0 &&
-						// SourceCharacter but not LineTerminator
-						(code > 0x001F || code == 0x0009) && code != 0x000A && code != 0x000D {
-						position += n
-						runePosition++
-						continue
-					} else {
-						break
-					}
+	position := startPosition
+	for position < bodyLength {
+		switch body[position] {
+		// White Space, Line Terminator and Comma
+		case '\t', ' ', '\n', '\r', ',':
+			position++
+		// BOM (U+FEFF)
+		case 0xEF:
+			if byteAt(body, position+1) != 0xBB || byteAt(body, position+2) != 0xBF {
+				return position
+			}
+			position += 3
+		case '#':
+			position++
+			// SourceCharacter but not LineTerminator
+			for position < bodyLength {
+				code := body[position]
+				if code < 0x0020 && code != 0x0009 {
+					break
 				}
-			} else {
-				break
+				position++
 			}
-			continue
-		} else {
-			break
+		default:
+			return position
 		}
 	}
-	return position, runePosition
+	return position
 }
 
 func GetTokenDesc(token Token) string {
 	if token.Value == "" {
 		return GetTokenKindDesc(token.Kind)
 	}
-	return fmt.Sprintf("%s \"%s\"", GetTokenKindDesc(token.Kind), token.Value)
+	return GetTokenKindDesc(token.Kind) + ` "` + token.Value + `"`
 }
 
 func GetTokenKindDesc(kind int) string {
+	if kind < 0 || kind >= len(tokenDescription) {
+		return ""
+	}
 	return tokenDescription[kind]
 }
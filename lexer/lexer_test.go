@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lexer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/lexer"
+	"github.com/apexlang/apex-go/source"
+)
+
+// Token positions are byte offsets, including after multi-byte text.
+func TestLexByteOffsets(t *testing.T) {
+	src := source.NewSource("test", []byte(`"日本" name 1`))
+	lex := lexer.Lex(src)
+	var got []lexer.Token
+	for {
+		token, err := lex(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.Kind == lexer.EOF {
+			break
+		}
+		got = append(got, token)
+	}
+	want := []lexer.Token{
+		{Kind: lexer.STRING, Start: 0, End: 8, Value: "日本"},
+		{Kind: lexer.NAME, Start: 9, End: 13, Value: "name"},
+		{Kind: lexer.INT, Start: 14, End: 15, Value: "1"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("tokens = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("token %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLexBlockStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty", `""""""`, ""},
+		{"blank lines", "\"\"\"\n  \n\n\"\"\"", ""},
+		{"one line", `"""text"""`, "text"},
+		{
+			name: "common indentation",
+			body: "\"\"\"\n    first\n      second\r\n    third\n  \"\"\"",
+			want: "first\n  second\nthird",
+		},
+		{"escaped quotes", `"""a \""" b"""`, `a """ b`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := lexer.Lex(source.NewSource("test", []byte(tt.body)))(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token.Kind != lexer.BLOCK_STRING || token.Value != tt.want {
+				t.Errorf("token = %+v, want a block string %q", token, tt.want)
+			}
+		})
+	}
+}
+
+func TestLexErrors(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{"\x07", `Syntax Error test (1:1) Invalid character "\\u0007"`},
+		{"\"a\x01\"", `Syntax Error test (1:3) Invalid character within String: "\\u0001".`},
+		{`"\q"`, `Syntax Error test (1:3) Invalid character escape sequence: \\q.`},
+		{`"\uzzzz"`, `Syntax Error test (1:3) Invalid character escape sequence: \uzzzz`},
+		{"01", `Syntax Error test (1:2) Invalid number, unexpected digit after 0: "1".`},
+		{"1.a", `Syntax Error test (1:3) Invalid number, expected digit but got: "a".`},
+		{"~", `Syntax Error test (1:1) Unexpected character "~".`},
+		{"é", `Syntax Error test (1:1) Unexpected character "\\u00E9".`},
+	}
+	for _, tt := range tests {
+		_, err := lexer.Lex(source.NewSource("test", []byte(tt.body)))(0)
+		if err == nil {
+			t.Errorf("Lex(%q) succeeded, want %q", tt.body, tt.want)
+			continue
+		}
+		if msg, _, _ := strings.Cut(err.Error(), "\n\n"); msg != tt.want {
+			t.Errorf("Lex(%q) error = %q, want %q", tt.body, msg, tt.want)
+		}
+	}
+}
@@ -0,0 +1,424 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lexer
+
+import (
+	"github.com/apexlang/apex-go/source"
+)
+
+// Class is the syntactic role of a token, for highlighters and other
+// tooling that does not need a full parse.
+type Class int
+
+const (
+	// ClassPunctuation is punctuation and names whose role is unknown.
+	ClassPunctuation Class = iota
+	// ClassKeyword is a keyword such as type, import or on, including
+	// directive locations.
+	ClassKeyword
+	// ClassTypeName is a defined or referenced type name.
+	ClassTypeName
+	// ClassFieldName is the name of a field, operation, parameter, enum
+	// value or annotation argument.
+	ClassFieldName
+	// ClassAnnotation is the @ and name of an annotation or directive.
+	ClassAnnotation
+	// ClassLiteral is a string, number, boolean, null or enum value,
+	// including descriptions.
+	ClassLiteral
+	// ClassComment is a # comment.
+	ClassComment
+)
+
+var classNames = [...]string{
+	ClassPunctuation: "punctuation",
+	ClassKeyword:     "keyword",
+	ClassTypeName:    "type",
+	ClassFieldName:   "field",
+	ClassAnnotation:  "annotation",
+	ClassLiteral:     "literal",
+	ClassComment:     "comment",
+}
+
+func (c Class) String() string {
+	if c < 0 || int(c) >= len(classNames) {
+		return "unknown"
+	}
+	return classNames[c]
+}
+
+// Lexeme is a token yielded by a Scanner.
+type Lexeme struct {
+	Token
+	Class Class
+	// Line and Column are the one based position of Start. Columns are
+	// counted in bytes; use source.Source.LineColumn for other units.
+	Line   uint
+	Column uint
+}
+
+// Text returns the source text of the lexeme.
+func (l Lexeme) Text(s *source.Source) string {
+	return string(s.Body[l.Start:l.End])
+}
+
+// scope is the kind of bracketed construct a token is in.
+type scope int
+
+const (
+	scopeBody      scope = iota // definition body
+	scopeParams                 // operation or directive parameters
+	scopeArgs                   // annotation arguments
+	scopeImport                 // selective import names
+	scopeListType               // [Type]
+	scopeMapType                // {Key: Value}
+	scopeListValue              // [value, ...]
+	scopeObject                 // {key: value}
+)
+
+// expectation is what the previous tokens say the next name is.
+type expectation int
+
+const (
+	expectNone expectation = iota
+	expectDefinitionName
+	expectFunctionName
+	expectTypeName
+	expectValue
+	expectAnnotationName
+	expectLocation
+)
+
+var keywords = map[string]bool{
+	NAMESPACE:    true,
+	IMPORT:       true,
+	ALIAS:        true,
+	TYPE:         true,
+	FUNC:         true,
+	INTERFACE:    true,
+	UNION:        true,
+	ENUM:         true,
+	DIRECTIVE:    true,
+	"from":       true,
+	"implements": true,
+	"on":         true,
+	"require":    true,
+}
+
+// Scanner yields every token of a source, including comments, in order.
+// Tokens are classified from their context rather than by parsing, so
+// incomplete specifications are classified as far as they go.
+//
+//	s := lexer.NewScanner(src)
+//	for s.Scan() {
+//		t := s.Token()
+//		...
+//	}
+//	if err := s.Err(); err != nil {
+//		...
+//	}
+type Scanner struct {
+	src      *source.Source
+	tokens   *tokenizer
+	position uint
+	token    Lexeme
+	err      error
+	done     bool
+
+	scopes []scope
+	// decl is the keyword of the top-level definition being scanned.
+	decl      string
+	expect    expectation
+	prev      Lexeme
+	requires  bool // after require in a directive definition
+	paramsFor bool // the next ( or [ opens parameters
+}
+
+// NewScanner returns a scanner for s.
+func NewScanner(s *source.Source) *Scanner {
+	return &Scanner{src: s, tokens: newTokenizer(s)}
+}
+
+// Scan advances to the next token. It returns false at the end of the
+// source or when a token cannot be read, which Err reports.
+func (s *Scanner) Scan() bool {
+	if s.done {
+		return false
+	}
+	token, err := s.read()
+	if err != nil {
+		s.err = err
+		s.done = true
+		return false
+	}
+	if token.Kind == EOF {
+		s.done = true
+		return false
+	}
+	s.position = token.End
+
+	line, column := s.src.LineColumn(token.Start, source.Bytes)
+	s.token = Lexeme{
+		Token:  token,
+		Class:  s.classify(token),
+		Line:   line,
+		Column: column,
+	}
+	if token.Kind != COMMENT {
+		s.prev = s.token
+	}
+	return true
+}
+
+// Token returns the token read by the last call to Scan.
+func (s *Scanner) Token() Lexeme {
+	return s.token
+}
+
+// Err returns the syntax error that stopped the scanner, if any.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// Tokens returns every token of s.
+func Tokens(s *source.Source) ([]Lexeme, error) {
+	var tokens []Lexeme
+	scanner := NewScanner(s)
+	for scanner.Scan() {
+		tokens = append(tokens, scanner.Token())
+	}
+	return tokens, scanner.Err()
+}
+
+// read reads the next token, returning comments as COMMENT tokens instead
+// of skipping them.
+func (s *Scanner) read() (Token, error) {
+	body := s.src.Body
+	position := s.position
+	for position < uint(len(body)) {
+		code, n := runeAt(body, position)
+		if code != 0xFEFF && code != '\t' && code != ' ' &&
+			code != '\n' && code != '\r' && code != ',' {
+			break
+		}
+		position += n
+	}
+	if code, _ := runeAt(body, position); code == '#' {
+		end := position + 1
+		for end < uint(len(body)) && body[end] != '\n' && body[end] != '\r' {
+			end++
+		}
+		return makeToken(COMMENT, position, end, string(body[position+1:end])), nil
+	}
+	return s.tokens.readToken(position)
+}
+
+func (s *Scanner) scope() (scope, bool) {
+	if len(s.scopes) == 0 {
+		return 0, false
+	}
+	return s.scopes[len(s.scopes)-1], true
+}
+
+func (s *Scanner) push(sc scope) {
+	s.scopes = append(s.scopes, sc)
+}
+
+func (s *Scanner) pop() {
+	if len(s.scopes) == 0 {
+		return
+	}
+	sc := s.scopes[len(s.scopes)-1]
+	s.scopes = s.scopes[:len(s.scopes)-1]
+	if len(s.scopes) == 0 && sc == scopeBody {
+		// The definition ends with its body.
+		s.decl = ""
+	}
+}
+
+func (s *Scanner) classify(token Token) Class {
+	expect := s.expect
+	s.expect = expectNone
+	paramsFor := s.paramsFor
+	s.paramsFor = false
+	sc, nested := s.scope()
+
+	switch token.Kind {
+	case COMMENT:
+		s.expect = expect
+		s.paramsFor = paramsFor
+		return ClassComment
+	case STRING, BLOCK_STRING, INT, FLOAT:
+		if !nested && s.decl == IMPORT && s.prev.Value == "from" {
+			s.decl = ""
+		}
+		// Descriptions do not change what comes next.
+		if expect == expectNone {
+			s.paramsFor = paramsFor
+		}
+		return ClassLiteral
+	case AT:
+		s.expect = expectAnnotationName
+		return ClassAnnotation
+	case NAME, NS:
+		return s.classifyName(token, expect, sc, nested)
+
+	case COLON:
+		if nested && (sc == scopeArgs || sc == scopeObject) {
+			s.expect = expectValue
+		} else {
+			s.expect = expectTypeName
+		}
+	case EQUALS:
+		if !nested {
+			// alias X = Type and union X = A | B
+			s.expect = expectTypeName
+		} else {
+			// Default and enum values.
+			s.expect = expectValue
+		}
+	case PIPE:
+		switch s.decl {
+		case UNION:
+			s.expect = expectTypeName
+		case DIRECTIVE:
+			s.expect = expectLocation
+		}
+	case AMP:
+		s.expect = expectTypeName
+	case PAREN_L:
+		switch {
+		case paramsFor:
+			s.push(scopeParams)
+		case s.prev.Kind == NAME && s.prev.Class == ClassAnnotation:
+			s.push(scopeArgs)
+		default:
+			s.push(scopeParams)
+		}
+	case BRACKET_L:
+		switch {
+		case paramsFor:
+			// Unary operations: op[param: Type]
+			s.push(scopeParams)
+		case expect == expectTypeName:
+			s.push(scopeListType)
+			s.expect = expectTypeName
+		default:
+			s.push(scopeListValue)
+		}
+	case BRACE_L:
+		switch {
+		case expect == expectTypeName:
+			s.push(scopeMapType)
+			s.expect = expectTypeName
+		case expect == expectValue || (nested && (sc == scopeListValue || sc == scopeArgs || sc == scopeObject)):
+			s.push(scopeObject)
+		case !nested && s.decl == IMPORT:
+			s.push(scopeImport)
+		default:
+			s.push(scopeBody)
+		}
+	case PAREN_R, BRACKET_R, BRACE_R:
+		s.pop()
+	}
+	return ClassPunctuation
+}
+
+func (s *Scanner) classifyName(token Token, expect expectation, sc scope, nested bool) Class {
+	switch expect {
+	case expectAnnotationName:
+		if !nested && s.decl == DIRECTIVE {
+			if s.requires {
+				// require @x LOCATION | ...
+				s.expect = expectLocation
+			} else {
+				s.paramsFor = true
+			}
+		}
+		return ClassAnnotation
+	case expectDefinitionName:
+		return ClassTypeName
+	case expectFunctionName:
+		s.paramsFor = true
+		return ClassFieldName
+	case expectTypeName:
+		if token.Value == "stream" && s.prev.Kind == COLON && (!nested || sc == scopeParams || sc == scopeBody) {
+			// Streamed parameters and return types: name: stream Type
+			s.expect = expectTypeName
+			return ClassKeyword
+		}
+		return ClassTypeName
+	case expectValue:
+		return ClassLiteral
+	case expectLocation:
+		return ClassKeyword
+	}
+
+	if !nested {
+		if s.decl == IMPORT && token.Value == "as" && s.prev.Kind == STAR {
+			// import * as alias from "..."
+			s.expect = expectDefinitionName
+			return ClassKeyword
+		}
+		if !keywords[token.Value] {
+			return ClassPunctuation
+		}
+		switch token.Value {
+		case NAMESPACE, ALIAS, TYPE, INTERFACE, UNION, ENUM:
+			s.decl = token.Value
+			s.requires = false
+			s.expect = expectDefinitionName
+		case FUNC:
+			s.decl = token.Value
+			s.requires = false
+			s.expect = expectFunctionName
+		case IMPORT, DIRECTIVE:
+			s.decl = token.Value
+			s.requires = false
+		case "implements":
+			s.expect = expectTypeName
+		case "on":
+			s.expect = expectLocation
+		case "require":
+			s.requires = true
+		}
+		return ClassKeyword
+	}
+
+	switch sc {
+	case scopeBody:
+		if s.decl == ENUM && token.Value == "as" &&
+			(s.prev.Kind == INT || s.prev.Kind == PAREN_R || s.prev.Class == ClassAnnotation) {
+			return ClassKeyword
+		}
+		if s.decl == INTERFACE {
+			s.paramsFor = true
+		}
+		return ClassFieldName
+	case scopeImport:
+		if token.Value == "as" {
+			return ClassKeyword
+		}
+		return ClassTypeName
+	case scopeListType, scopeMapType:
+		return ClassTypeName
+	case scopeListValue:
+		return ClassLiteral
+	}
+	// Parameters, annotation arguments and object fields.
+	return ClassFieldName
+}
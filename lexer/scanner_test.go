@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lexer_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/lexer"
+	"github.com/apexlang/apex-go/source"
+)
+
+// classes renders the tokens of body as class:text pairs.
+func classes(t *testing.T, body string) string {
+	t.Helper()
+	src := source.NewSource("test", []byte(body))
+	tokens, err := lexer.Tokens(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := make([]string, len(tokens))
+	for i, token := range tokens {
+		parts[i] = token.Class.String() + ":" + token.Text(src)
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestScannerClasses(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "namespace and comment",
+			body: "# comment\nnamespace \"test\"",
+			want: `comment:# comment keyword:namespace literal:"test"`,
+		},
+		{
+			name: "import",
+			body: `import { A as B } from "./a"`,
+			want: `keyword:import punctuation:{ type:A keyword:as type:B punctuation:} keyword:from literal:"./a"`,
+		},
+		{
+			name: "import alias",
+			body: `import * as billing from "./billing"`,
+			want: `keyword:import punctuation:* keyword:as type:billing keyword:from literal:"./billing"`,
+		},
+		{
+			name: "directive",
+			body: "directive @auth(role: Role = admin) on OPERATION | FIELD",
+			want: "keyword:directive annotation:@ annotation:auth punctuation:( field:role punctuation:: type:Role " +
+				"punctuation:= literal:admin punctuation:) keyword:on keyword:OPERATION punctuation:| keyword:FIELD",
+		},
+		{
+			name: "type",
+			body: "\"Doc\"\ntype User implements Node @key(x: {a: [1.5]}) {\n  id: [string] @auth(role: admin)\n  m: {string: B}?\n}",
+			want: "literal:\"Doc\" keyword:type type:User keyword:implements type:Node annotation:@ annotation:key " +
+				"punctuation:( field:x punctuation:: punctuation:{ field:a punctuation:: punctuation:[ literal:1.5 " +
+				"punctuation:] punctuation:} punctuation:) punctuation:{ field:id punctuation:: punctuation:[ " +
+				"type:string punctuation:] annotation:@ annotation:auth punctuation:( field:role punctuation:: " +
+				"literal:admin punctuation:) field:m punctuation:: punctuation:{ type:string punctuation:: type:B " +
+				"punctuation:} punctuation:? punctuation:}",
+		},
+		{
+			name: "union",
+			body: "union U = A | B",
+			want: "keyword:union type:U punctuation:= type:A punctuation:| type:B",
+		},
+		{
+			name: "enum",
+			body: `enum E { one = 1 as "One" @label("x") }`,
+			want: `keyword:enum type:E punctuation:{ field:one punctuation:= literal:1 keyword:as literal:"One" ` +
+				`annotation:@ annotation:label punctuation:( literal:"x" punctuation:) punctuation:}`,
+		},
+		{
+			name: "function with streams",
+			body: "func f(a: stream i32 = -1): stream User",
+			want: "keyword:func field:f punctuation:( field:a punctuation:: keyword:stream type:i32 punctuation:= " +
+				"literal:-1 punctuation:) punctuation:: keyword:stream type:User",
+		},
+		{
+			name: "interface",
+			body: "interface I { get[id: string]: stream {string: stream} }",
+			want: "keyword:interface type:I punctuation:{ field:get punctuation:[ field:id punctuation:: type:string " +
+				"punctuation:] punctuation:: keyword:stream punctuation:{ type:string punctuation:: type:stream " +
+				"punctuation:} punctuation:}",
+		},
+		{
+			name: "incomplete",
+			body: "type User {\n  id: ",
+			want: "keyword:type type:User punctuation:{ field:id punctuation::",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classes(t, tt.body); got != tt.want {
+				t.Errorf("classes:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScannerPositions(t *testing.T) {
+	src := source.NewSource("test", []byte("\"é\"\r\ntype T # c\n"))
+	var got []string
+	s := lexer.NewScanner(src)
+	for s.Scan() {
+		token := s.Token()
+		got = append(got, fmt.Sprintf("%d-%d@%d:%d", token.Start, token.End, token.Line, token.Column))
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := "0-4@1:1 6-10@2:1 11-12@2:6 13-16@2:8"
+	if strings.Join(got, " ") != want {
+		t.Errorf("positions = %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestScannerError(t *testing.T) {
+	src := source.NewSource("test", []byte("type T { a: \"unterminated"))
+	tokens, err := lexer.Tokens(src)
+	if err == nil || !strings.Contains(err.Error(), "Unterminated string") {
+		t.Errorf("error = %v, want an unterminated string", err)
+	}
+	if len(tokens) != 5 {
+		t.Errorf("tokens before the error = %d, want 5", len(tokens))
+	}
+}
+
+func TestClassString(t *testing.T) {
+	if got := lexer.ClassKeyword.String(); got != "keyword" {
+		t.Errorf("ClassKeyword = %q", got)
+	}
+	if got := lexer.Class(100).String(); got != "unknown" {
+		t.Errorf("Class(100) = %q", got)
+	}
+}
@@ -17,8 +17,6 @@ limitations under the License.
 package location
 
 import (
-	"regexp"
-
 	"github.com/apexlang/apex-go/source"
 )
 
@@ -27,25 +25,8 @@ type SourceLocation struct {
 	Column uint `json:"column"`
 }
 
+// GetLocation returns the one based line and byte column of position in s.
 func GetLocation(s *source.Source, position uint) SourceLocation {
-	body := []byte{}
-	if s != nil {
-		body = s.Body
-	}
-	line := uint(1)
-	column := position + 1
-	lineRegexp := regexp.MustCompile("\r\n|[\n\r]")
-	matches := lineRegexp.FindAllIndex(body, -1)
-	for _, match := range matches {
-		matchIndex := uint(match[0])
-		if matchIndex < position {
-			line++
-			l := uint(len(s.Body[match[0]:match[1]]))
-			column = position + 1 - (matchIndex + l)
-			continue
-		} else {
-			break
-		}
-	}
+	line, column := s.LineColumn(position, source.Bytes)
 	return SourceLocation{Line: line, Column: column}
 }
@@ -0,0 +1,409 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"strconv"
+
+	"github.com/wapc/tinygo-msgpack"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/location"
+)
+
+// An artifact is a compiled model that loads without parsing and
+// validating its specification. It is a header followed by a msgpack
+// payload:
+//
+//	magic    [4]byte   "APXM"
+//	version  uint16    big endian artifact format version
+//	checksum [32]byte  SHA-256 of the payload
+//	payload            msgpack map of "namespace" and "sourceMap"
+
+// ArtifactVersion is the artifact format version written by
+// MarshalBinary. Load reads versions from MinArtifactVersion to
+// ArtifactVersion.
+const (
+	ArtifactVersion    uint16 = 1
+	MinArtifactVersion uint16 = 1
+)
+
+const artifactMagic = "APXM"
+
+// artifactHeaderSize is the size of the magic, version and checksum.
+const artifactHeaderSize = len(artifactMagic) + 2 + sha256.Size
+
+var (
+	// ErrNotArtifact is returned by Load for data without the artifact
+	// header.
+	ErrNotArtifact = errors.New("not an Apex model artifact")
+	// ErrArtifactChecksum is returned by Load when the payload does not
+	// match the checksum of the header.
+	ErrArtifactChecksum = errors.New("artifact checksum does not match its contents")
+)
+
+// ArtifactVersionError is returned by Load for artifacts written in a
+// format version it cannot read.
+type ArtifactVersionError struct {
+	Version uint16
+}
+
+func (e *ArtifactVersionError) Error() string {
+	return "artifact format version " + strconv.Itoa(int(e.Version)) +
+		" is not supported (supported versions are " + strconv.Itoa(int(MinArtifactVersion)) +
+		" to " + strconv.Itoa(int(ArtifactVersion)) + ")"
+}
+
+// Artifact is a compiled model and, optionally, the source locations of
+// its elements.
+type Artifact struct {
+	// Version is the format version of a loaded artifact.
+	Version   uint16
+	Namespace *Namespace
+	SourceMap *SourceMap
+}
+
+// MarshalBinary encodes a as an artifact in the current format version.
+func (a *Artifact) MarshalBinary() ([]byte, error) {
+	if a.Namespace == nil {
+		return nil, errors.New("artifact has no namespace")
+	}
+	payload, err := msgpack.ToBytes(a)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, artifactHeaderSize, artifactHeaderSize+len(payload))
+	copy(data, artifactMagic)
+	binary.BigEndian.PutUint16(data[len(artifactMagic):], ArtifactVersion)
+	checksum := sha256.Sum256(payload)
+	copy(data[len(artifactMagic)+2:], checksum[:])
+	return append(data, payload...), nil
+}
+
+// Load decodes an artifact written by MarshalBinary. It returns
+// ErrNotArtifact, ErrArtifactChecksum or an *ArtifactVersionError when
+// data is not an artifact it can read.
+func Load(data []byte) (*Artifact, error) {
+	if len(data) < artifactHeaderSize || !bytes.HasPrefix(data, []byte(artifactMagic)) {
+		return nil, ErrNotArtifact
+	}
+	version := binary.BigEndian.Uint16(data[len(artifactMagic):])
+	if version < MinArtifactVersion || version > ArtifactVersion {
+		return nil, &ArtifactVersionError{Version: version}
+	}
+	payload := data[artifactHeaderSize:]
+	checksum := sha256.Sum256(payload)
+	if !bytes.Equal(checksum[:], data[len(artifactMagic)+2:artifactHeaderSize]) {
+		return nil, ErrArtifactChecksum
+	}
+
+	var a Artifact
+	decoder := msgpack.NewDecoder(payload)
+	if err := a.Decode(&decoder); err != nil {
+		return nil, err
+	}
+	if a.Namespace == nil {
+		return nil, errors.New("artifact has no namespace")
+	}
+	a.Version = version
+	return &a, nil
+}
+
+func (o *Artifact) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	var _o Artifact
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "namespace":
+			_o.Namespace, err = msgpack.DecodeNillable[Namespace](decoder)
+		case "sourceMap":
+			_o.SourceMap, err = msgpack.DecodeNillable[SourceMap](decoder)
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	*o = _o
+
+	return nil
+}
+
+func (o *Artifact) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(2)
+	encoder.WriteString("namespace")
+	o.Namespace.Encode(encoder)
+	encoder.WriteString("sourceMap")
+	o.SourceMap.Encode(encoder)
+
+	return nil
+}
+
+// SourceMap locates the elements of a model in the files of its
+// specification. Elements are named by path: `User` for a definition,
+// `User.name` for a field, operation or enum value, `Store.get.id` for a
+// parameter and `@range` for a directive. Functions and their parameters
+// are `get` and `get.id`.
+type SourceMap struct {
+	Files   []string         `json:"files" yaml:"files" msgpack:"files"`
+	Entries []SourceMapEntry `json:"entries" yaml:"entries" msgpack:"entries"`
+}
+
+// SourceMapEntry is the location of the element at Path in Files[File].
+type SourceMapEntry struct {
+	Path   string `json:"path" yaml:"path" msgpack:"path"`
+	File   uint32 `json:"file" yaml:"file" msgpack:"file"`
+	Line   uint32 `json:"line" yaml:"line" msgpack:"line"`
+	Column uint32 `json:"column" yaml:"column" msgpack:"column"`
+}
+
+// Lookup returns the file and location of the element at path.
+func (m *SourceMap) Lookup(path string) (string, Location, bool) {
+	if m == nil {
+		return "", Location{}, false
+	}
+	for _, e := range m.Entries {
+		if e.Path == path && int(e.File) < len(m.Files) {
+			return m.Files[e.File], Location{Line: e.Line, Column: e.Column}, true
+		}
+	}
+	return "", Location{}, false
+}
+
+// NewSourceMap returns the source map of the definitions in doc, including
+// those imported. Definitions parsed without their source are left out.
+func NewSourceMap(doc *ast.Document) *SourceMap {
+	b := sourceMapBuilder{m: &SourceMap{}, files: make(map[string]uint32)}
+	for _, def := range doc.Definitions {
+		switch v := def.(type) {
+		case *ast.TypeDefinition:
+			b.add(v.Name.Value, v)
+			for _, f := range v.Fields {
+				b.add(v.Name.Value+"."+f.Name.Value, f)
+			}
+		case *ast.InterfaceDefinition:
+			b.add(v.Name.Value, v)
+			for _, oper := range v.Operations {
+				b.operation(v.Name.Value+"."+oper.Name.Value, oper)
+			}
+		case *ast.OperationDefinition:
+			b.operation(v.Name.Value, v)
+		case *ast.AliasDefinition:
+			b.add(v.Name.Value, v)
+		case *ast.UnionDefinition:
+			b.add(v.Name.Value, v)
+		case *ast.EnumDefinition:
+			b.add(v.Name.Value, v)
+			for _, value := range v.Values {
+				b.add(v.Name.Value+"."+value.Name.Value, value)
+			}
+		case *ast.DirectiveDefinition:
+			b.add("@"+v.Name.Value, v)
+			for _, param := range v.Parameters {
+				b.add("@"+v.Name.Value+"."+param.Name.Value, param)
+			}
+		}
+	}
+	return b.m
+}
+
+type sourceMapBuilder struct {
+	m     *SourceMap
+	files map[string]uint32
+}
+
+func (b *sourceMapBuilder) operation(path string, oper *ast.OperationDefinition) {
+	b.add(path, oper)
+	for _, param := range oper.Parameters {
+		b.add(path+"."+param.Name.Value, param)
+	}
+}
+
+func (b *sourceMapBuilder) add(path string, node ast.Node) {
+	loc := node.GetLoc()
+	if loc == nil || loc.Source == nil {
+		return
+	}
+	file, ok := b.files[loc.Source.Name]
+	if !ok {
+		file = uint32(len(b.m.Files))
+		b.files[loc.Source.Name] = file
+		b.m.Files = append(b.m.Files, loc.Source.Name)
+	}
+	l := location.GetLocation(loc.Source, loc.Start)
+	b.m.Entries = append(b.m.Entries, SourceMapEntry{
+		Path:   path,
+		File:   file,
+		Line:   uint32(l.Line),
+		Column: uint32(l.Column),
+	})
+}
+
+func (o *SourceMap) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	var _o SourceMap
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "files":
+			_o.Files, err = decodeStrings(decoder)
+		case "entries":
+			_o.Entries, err = decodeSourceMapEntries(decoder)
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	*o = _o
+
+	return nil
+}
+
+func (o *SourceMap) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(2)
+	encoder.WriteString("files")
+	encoder.WriteArraySize(uint32(len(o.Files)))
+	for _, v := range o.Files {
+		encoder.WriteString(v)
+	}
+	encoder.WriteString("entries")
+	encoder.WriteArraySize(uint32(len(o.Entries)))
+	for _, v := range o.Entries {
+		v.Encode(encoder)
+	}
+
+	return nil
+}
+
+func (o *SourceMapEntry) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	var _o SourceMapEntry
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "path":
+			_o.Path, err = decoder.ReadString()
+		case "file":
+			_o.File, err = decoder.ReadUint32()
+		case "line":
+			_o.Line, err = decoder.ReadUint32()
+		case "column":
+			_o.Column, err = decoder.ReadUint32()
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	*o = _o
+
+	return nil
+}
+
+func (o *SourceMapEntry) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(4)
+	encoder.WriteString("path")
+	encoder.WriteString(o.Path)
+	encoder.WriteString("file")
+	encoder.WriteUint32(o.File)
+	encoder.WriteString("line")
+	encoder.WriteUint32(o.Line)
+	encoder.WriteString("column")
+	encoder.WriteUint32(o.Column)
+
+	return nil
+}
+
+func decodeStrings(decoder msgpack.Reader) ([]string, error) {
+	listSize, err := decoder.ReadArraySize()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]string, 0, listSize)
+	for listSize > 0 {
+		listSize--
+		item, err := decoder.ReadString()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+func decodeSourceMapEntries(decoder msgpack.Reader) ([]SourceMapEntry, error) {
+	listSize, err := decoder.ReadArraySize()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]SourceMapEntry, 0, listSize)
+	for listSize > 0 {
+		listSize--
+		var item SourceMapEntry
+		if err := item.Decode(decoder); err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
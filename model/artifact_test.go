@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/apexlang/apex-go/model"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/source"
+)
+
+// header returns an artifact header for payload.
+func header(version uint16, payload []byte) []byte {
+	data := []byte("APXM")
+	data = binary.BigEndian.AppendUint16(data, version)
+	checksum := sha256.Sum256(payload)
+	return append(data, checksum[:]...)
+}
+
+func TestArtifactMarshalBinary(t *testing.T) {
+	a := model.Artifact{Namespace: convert(t, `namespace "shop"`)}
+	data, err := a.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := data[len(header(0, nil)):]
+	if want := header(model.ArtifactVersion, payload); !bytes.HasPrefix(data, want) {
+		t.Errorf("header = %x, want %x", data[:len(want)], want)
+	}
+
+	if _, err := (&model.Artifact{}).MarshalBinary(); err == nil {
+		t.Error("MarshalBinary without a namespace succeeded")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	payload := []byte{0x80}
+	corrupt := append(header(model.ArtifactVersion, payload), 0x81)
+	tests := []struct {
+		name    string
+		data    []byte
+		want    error
+		version uint16
+	}{
+		{name: "empty", data: nil, want: model.ErrNotArtifact},
+		{name: "short", data: []byte("APXM"), want: model.ErrNotArtifact},
+		{name: "magic", data: append(append([]byte("APXX"), header(1, payload)[4:]...), payload...), want: model.ErrNotArtifact},
+		{name: "old", data: append(header(model.MinArtifactVersion-1, payload), payload...), version: model.MinArtifactVersion - 1},
+		{name: "new", data: append(header(model.ArtifactVersion+1, payload), payload...), version: model.ArtifactVersion + 1},
+		{name: "checksum", data: corrupt, want: model.ErrArtifactChecksum},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := model.Load(tt.data)
+			if tt.want != nil {
+				if !errors.Is(err, tt.want) {
+					t.Errorf("Load() error = %v, want %v", err, tt.want)
+				}
+				return
+			}
+			var verr *model.ArtifactVersionError
+			if !errors.As(err, &verr) || verr.Version != tt.version {
+				t.Fatalf("Load() error = %v, want version %d", err, tt.version)
+			}
+			want := "artifact format version " + strconv.Itoa(int(tt.version)) + " is not supported (supported versions are 1 to 1)"
+			if err.Error() != want {
+				t.Errorf("error = %q, want %q", err, want)
+			}
+		})
+	}
+}
+
+func TestSourceMap(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource("main.apex", []byte(`namespace "shop"
+import * from "types.apex"
+
+interface Store {
+  get(id: string): Item
+}
+
+func ping(at: datetime): string
+
+enum Status {
+  active = 0
+}
+
+directive @range(min: i64) on FIELD
+`)),
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				return "type Item {\n  name: string\n}\n", nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := model.NewSourceMap(doc)
+
+	tests := []struct {
+		path         string
+		file         string
+		line, column uint32
+	}{
+		{"Item", "types.apex", 1, 1},
+		{"Item.name", "types.apex", 2, 3},
+		{"Store", "main.apex", 4, 1},
+		{"Store.get", "main.apex", 5, 3},
+		{"Store.get.id", "main.apex", 5, 7},
+		{"ping", "main.apex", 8, 1},
+		{"ping.at", "main.apex", 8, 11},
+		{"Status.active", "main.apex", 11, 3},
+		{"@range", "main.apex", 14, 1},
+		{"@range.min", "main.apex", 14, 18},
+	}
+	for _, tt := range tests {
+		file, loc, ok := m.Lookup(tt.path)
+		if !ok || file != tt.file || loc.Line != tt.line || loc.Column != tt.column {
+			t.Errorf("Lookup(%q) = %s %d:%d %v, want %s %d:%d", tt.path, file, loc.Line, loc.Column, ok, tt.file, tt.line, tt.column)
+		}
+	}
+	if _, _, ok := m.Lookup("Missing"); ok {
+		t.Error("Lookup(Missing) found an entry")
+	}
+	if _, _, ok := (*model.SourceMap)(nil).Lookup("Item"); ok {
+		t.Error("Lookup on a nil source map found an entry")
+	}
+}
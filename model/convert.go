@@ -59,18 +59,29 @@ type Converter struct {
 	_interfaces []*ast.InterfaceDefinition
 
 	named map[string]Named
+	// qualified are the types of the namespaces imported with an alias,
+	// by alias.
+	qualified map[string]map[string]Named
 
 	errors []error
 }
 
 func (c *Converter) Convert(doc *ast.Document) (*Namespace, []error) {
 	c.named = make(map[string]Named)
+	c.qualified = make(map[string]map[string]Named)
 	for _, def := range doc.Definitions {
 		switch t := def.(type) {
 		case *ast.NamespaceDefinition:
-			c._ns = t
+			// Imported and merged documents declare more than one
+			// namespace. The first is the document's own.
+			if c._ns == nil {
+				c._ns = t
+			}
 		case *ast.ImportDefinition:
 			c._imports = append(c._imports, t)
+			if t.Alias != nil && t.Document != nil && c.qualified[t.Alias.Value] == nil {
+				c.qualified[t.Alias.Value] = qualifiedNames(t.Alias.Value, t.Document)
+			}
 		case *ast.DirectiveDefinition:
 			c._directives = append(c._directives, t)
 		case *ast.AliasDefinition:
@@ -116,6 +127,7 @@ func (c *Converter) Convert(doc *ast.Document) (*Namespace, []error) {
 		Directives:  c.convertDirectives(c._directives),
 		Aliases:     c.convertAliases(c._aliases),
 		Unions:      c.convertUnions(c._unions),
+		Enums:       c.convertEnums(c._enums),
 		Functions:   c.convertOperations(c._functions),
 		Types:       c.convertTypes(c._types),
 		Interfaces:  c.convertInterfaces(c._interfaces),
@@ -139,6 +151,7 @@ func (c *Converter) convertInterfaces(items []*ast.InterfaceDefinition) []Interf
 			Name:        item.Name.Value,
 			Operations:  c.convertOperations(item.Operations),
 			Annotations: c.convertAnnotations(item.Annotations),
+			Deprecated:  convertDeprecated(item.Annotations),
 		}
 	}
 	return s
@@ -155,6 +168,7 @@ func (c *Converter) convertTypes(items []*ast.TypeDefinition) []Type {
 			Name:        item.Name.Value,
 			Fields:      c.convertFields(item.Fields),
 			Annotations: c.convertAnnotations(item.Annotations),
+			Deprecated:  convertDeprecated(item.Annotations),
 		}
 	}
 	return s
@@ -172,6 +186,7 @@ func (c *Converter) convertFields(items []*ast.FieldDefinition) []Field {
 			Type:         c.convertTypeRef(item.Type),
 			DefaultValue: c.convertValuePtr(item.Default),
 			Annotations:  c.convertAnnotations(item.Annotations),
+			Deprecated:   convertDeprecated(item.Annotations),
 		}
 	}
 	return s
@@ -196,6 +211,7 @@ func (c *Converter) convertOperations(items []*ast.OperationDefinition) []Operat
 			Parameters:  parameters,
 			Returns:     c.convertTypeRefPtr(item.Type),
 			Annotations: c.convertAnnotations(item.Annotations),
+			Deprecated:  convertDeprecated(item.Annotations),
 		}
 	}
 	return s
@@ -212,6 +228,7 @@ func (c *Converter) convertAliases(items []*ast.AliasDefinition) []Alias {
 			Name:        item.Name.Value,
 			Type:        c.convertTypeRef(item.Type),
 			Annotations: c.convertAnnotations(item.Annotations),
+			Deprecated:  convertDeprecated(item.Annotations),
 		}
 	}
 	return s
@@ -228,6 +245,7 @@ func (c *Converter) convertUnions(items []*ast.UnionDefinition) []Union {
 			Name:        item.Name.Value,
 			Members:     c.convertUnionMembers(item.Members),
 			Annotations: c.convertAnnotations(item.Annotations),
+			Deprecated:  convertDeprecated(item.Annotations),
 		}
 	}
 	return s
@@ -248,6 +266,41 @@ func (c *Converter) convertUnionMembers(items []*ast.UnionMemberDefinition) []Un
 	return s
 }
 
+func (c *Converter) convertEnums(items []*ast.EnumDefinition) []Enum {
+	if len(items) == 0 {
+		return nil
+	}
+	s := make([]Enum, len(items))
+	for i, item := range items {
+		s[i] = Enum{
+			Description: stringValuePtr(item.Description),
+			Name:        item.Name.Value,
+			Values:      c.convertEnumValues(item.Values),
+			Annotations: c.convertAnnotations(item.Annotations),
+			Deprecated:  convertDeprecated(item.Annotations),
+		}
+	}
+	return s
+}
+
+func (c *Converter) convertEnumValues(items []*ast.EnumValueDefinition) []EnumValue {
+	if len(items) == 0 {
+		return nil
+	}
+	s := make([]EnumValue, len(items))
+	for i, item := range items {
+		s[i] = EnumValue{
+			Description: stringValuePtr(item.Description),
+			Name:        item.Name.Value,
+			Index:       uint64(item.Index.Value),
+			Display:     stringValuePtr(item.Display),
+			Annotations: c.convertAnnotations(item.Annotations),
+			Deprecated:  convertDeprecated(item.Annotations),
+		}
+	}
+	return s
+}
+
 func (c *Converter) convertTypeRefPtr(t ast.Type) *TypeRef {
 	if named, ok := t.(*ast.Named); ok {
 		if named.Name.Value == "void" {
@@ -262,7 +315,13 @@ func (c *Converter) convertTypeRef(t ast.Type) TypeRef {
 	var m TypeRef
 	switch v := t.(type) {
 	case *ast.Named:
-		if s, ok := scalars[v.Name.Value]; ok {
+		if v.Namespace != nil {
+			if named, ok := c.qualified[v.Namespace.Value][v.Name.Value]; ok {
+				m.Named = &named
+			} else {
+				c.errors = append(c.errors, errors.New("unknown type "+v.QualifiedName()))
+			}
+		} else if s, ok := scalars[v.Name.Value]; ok {
 			m.Scalar = &s
 		} else if named, ok := c.named[v.Name.Value]; ok {
 			m.Named = &named
@@ -319,6 +378,7 @@ func (c *Converter) convertParameters(items []*ast.ParameterDefinition) []Parame
 			Type:         c.convertTypeRef(item.Type),
 			DefaultValue: c.convertValuePtr(item.Default),
 			Annotations:  c.convertAnnotations(item.Annotations),
+			Deprecated:   convertDeprecated(item.Annotations),
 		}
 	}
 	return s
@@ -349,6 +409,36 @@ func (c *Converter) convertRequires(items []*ast.DirectiveRequire) []DirectiveRe
 	return s
 }
 
+// qualifiedNames returns the types of doc, the namespace imported as
+// alias.
+func qualifiedNames(alias string, doc *ast.Document) map[string]Named {
+	var namespace *string
+	if ns := ast.NewContext(doc).Namespace; ns != nil {
+		namespace = &ns.Name.Value
+	}
+	named := make(map[string]Named)
+	for name, def := range ast.NamedDefinitions(doc) {
+		var kind Kind
+		switch def.(type) {
+		case *ast.AliasDefinition:
+			kind = KindAlias
+		case *ast.UnionDefinition:
+			kind = KindUnion
+		case *ast.EnumDefinition:
+			kind = KindEnum
+		case *ast.TypeDefinition:
+			kind = KindType
+		}
+		named[name] = Named{
+			Kind:      kind,
+			Qualifier: &alias,
+			Name:      name,
+			Namespace: namespace,
+		}
+	}
+	return named
+}
+
 func (c *Converter) convertImports(items []*ast.ImportDefinition) []Import {
 	if len(items) == 0 {
 		return nil
@@ -359,6 +449,7 @@ func (c *Converter) convertImports(items []*ast.ImportDefinition) []Import {
 			Description: stringValuePtr(item.Description),
 			All:         item.All,
 			Names:       c.convertImportNames(item.Names),
+			As:          nameValuePtr(item.Alias),
 			From:        item.From.Value,
 			Annotations: c.convertAnnotations(item.Annotations),
 		}
@@ -468,6 +559,31 @@ func (c *Converter) convertValue(v ast.Value) Value {
 	return Value{}
 }
 
+// convertDeprecated returns the structured form of a `@deprecated` annotation.
+// An unnamed `value` argument is treated as the reason.
+func convertDeprecated(annotations []*ast.Annotation) *Deprecated {
+	for _, a := range annotations {
+		if a.Name.Value != "deprecated" {
+			continue
+		}
+		var d Deprecated
+		for _, arg := range a.Arguments {
+			str, ok := arg.Value.(*ast.StringValue)
+			if !ok {
+				continue
+			}
+			switch arg.Name.Value {
+			case "reason", "value":
+				d.Reason = &str.Value
+			case "since":
+				d.Since = &str.Value
+			}
+		}
+		return &d
+	}
+	return nil
+}
+
 func stringValuePtr(value *ast.StringValue) *string {
 	if value == nil {
 		return nil
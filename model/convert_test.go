@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/model"
+	"github.com/apexlang/apex-go/parser"
+)
+
+// convert parses and converts src.
+func convert(t *testing.T, src string) *model.Namespace {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ns, errs := model.Convert(doc)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	return ns
+}
+
+func TestConvertDeprecated(t *testing.T) {
+	ns := convert(t, `namespace "test"
+
+type Old @deprecated(reason: "use New", since: "1.2") {
+  a: string @deprecated("gone")
+  b: string
+}
+enum Level { LOW = 0 @deprecated HIGH = 1 }
+`)
+	old := ns.Types[0]
+	if old.Deprecated == nil || deref(old.Deprecated.Reason) != "use New" || deref(old.Deprecated.Since) != "1.2" {
+		t.Errorf("type deprecated = %+v", old.Deprecated)
+	}
+	if d := old.Fields[0].Deprecated; d == nil || deref(d.Reason) != "gone" || d.Since != nil {
+		t.Errorf("field a deprecated = %+v", d)
+	}
+	if d := old.Fields[1].Deprecated; d != nil {
+		t.Errorf("field b deprecated = %+v, want nil", d)
+	}
+	values := ns.Enums[0].Values
+	if d := values[0].Deprecated; d == nil || d.Reason != nil {
+		t.Errorf("enum value LOW deprecated = %+v", d)
+	}
+	if d := values[1].Deprecated; d != nil {
+		t.Errorf("enum value HIGH deprecated = %+v, want nil", d)
+	}
+}
+
+func TestConvertQualified(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: `namespace "orders"
+
+import * as billing from "billing"
+
+type Order {
+  invoice: billing.Invoice
+  status: Status
+}
+
+enum Status { OPEN = 0 }
+`,
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				if location == "common" {
+					return `namespace "common"`, nil
+				}
+				return `import * from "common"
+
+namespace "billing"
+
+type Invoice {
+  total: f64
+}
+`, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ns, errs := model.Convert(doc)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if imp := ns.Imports[0]; !imp.All || deref(imp.As) != "billing" || imp.From != "billing" {
+		t.Errorf("import = %+v", imp)
+	}
+	fields := ns.Types[0].Fields
+	invoice := fields[0].Type.Named
+	if invoice == nil {
+		t.Fatalf("invoice type = %+v, want named", fields[0].Type)
+	}
+	want := model.Named{Kind: model.KindType, Name: "Invoice"}
+	if got := *invoice; got.Kind != want.Kind || got.Name != want.Name ||
+		deref(got.Qualifier) != "billing" || deref(got.Namespace) != "billing" {
+		t.Errorf("invoice type = %+v", got)
+	}
+	status := fields[1].Type.Named
+	if status == nil || status.Kind != model.KindEnum || status.Qualifier != nil || status.Namespace != nil {
+		t.Errorf("status type = %+v", status)
+	}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
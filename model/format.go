@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/wapc/tinygo-msgpack"
+)
+
+// Format is an encoding of a converted model.
+type Format int32
+
+const (
+	FormatJSON    Format = 1
+	FormatYAML    Format = 2
+	FormatMSGPACK Format = 3
+)
+
+var formatNames = map[Format]string{
+	FormatJSON:    "JSON",
+	FormatYAML:    "YAML",
+	FormatMSGPACK: "MSGPACK",
+}
+
+func (f Format) String() string {
+	name, ok := formatNames[f]
+	if !ok {
+		return "unknown"
+	}
+	return name
+}
+
+// FromString sets f to the Format named by str, e.g. "YAML".
+func (f *Format) FromString(str string) error {
+	for format, name := range formatNames {
+		if name == str {
+			*f = format
+			return nil
+		}
+	}
+	return errors.New("unknown value \"" + str + "\" for Format")
+}
+
+// ParseFormat returns the Format named by s, e.g. "yaml". Names are not
+// case sensitive.
+func ParseFormat(s string) (Format, error) {
+	var f Format
+	if err := f.FromString(strings.ToUpper(s)); err != nil {
+		return 0, errors.New("unknown format \"" + s + "\"")
+	}
+	return f, nil
+}
+
+// MarshalNamespace encodes ns in format.
+func MarshalNamespace(ns *Namespace, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return ns.MarshalJSON()
+	case FormatYAML:
+		data, err := ns.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		return jsonToYAML(data)
+	case FormatMSGPACK:
+		return msgpack.ToBytes(ns)
+	}
+	return nil, errors.New("unknown format " + format.String())
+}
+
+// UnmarshalNamespace decodes a namespace encoded in format by
+// MarshalNamespace.
+func UnmarshalNamespace(data []byte, format Format) (*Namespace, error) {
+	var ns Namespace
+	switch format {
+	case FormatJSON:
+		if err := ns.UnmarshalJSON(data); err != nil {
+			return nil, err
+		}
+	case FormatYAML:
+		data, err := yamlToJSON(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := ns.UnmarshalJSON(data); err != nil {
+			return nil, err
+		}
+	case FormatMSGPACK:
+		decoder := msgpack.NewDecoder(data)
+		if err := ns.Decode(&decoder); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("unknown format " + format.String())
+	}
+	return &ns, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+
+	"github.com/wapc/tinygo-msgpack"
+	"github.com/wapc/tinygo-msgpack/convert"
+)
+
+// FormatConverter converts specifications to models encoded in a Format
+// and loads the models it encoded. It extends the generated Parser
+// service and is registered with the waPC operations
+// apexlang.v1.Parser/convert and apexlang.v1.Parser/load.
+type FormatConverter interface {
+	// Convert parses and converts source, returning the model encoded in
+	// format.
+	Convert(ctx context.Context, source string, format Format) ([]byte, error)
+	// Load decodes a model previously converted to format.
+	Load(ctx context.Context, data []byte, format Format) (*ParserResult, error)
+}
+
+// ParserConvertArgs are the msgpack arguments of FormatConverter.Convert.
+type ParserConvertArgs struct {
+	Source string `json:"source" yaml:"source" msgpack:"source"`
+	Format Format `json:"format" yaml:"format" msgpack:"format"`
+}
+
+func (o *ParserConvertArgs) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	var _o ParserConvertArgs
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "source":
+			_o.Source, err = decoder.ReadString()
+		case "format":
+			_o.Format, err = convert.Numeric[Format](decoder.ReadInt32())
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+		*o = _o
+	}
+
+	return nil
+}
+
+func (o *ParserConvertArgs) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(2)
+	encoder.WriteString("source")
+	encoder.WriteString(o.Source)
+	encoder.WriteString("format")
+	encoder.WriteInt32(int32(o.Format))
+
+	return nil
+}
+
+// ParserLoadArgs are the msgpack arguments of FormatConverter.Load.
+type ParserLoadArgs struct {
+	Data   []byte `json:"data" yaml:"data" msgpack:"data"`
+	Format Format `json:"format" yaml:"format" msgpack:"format"`
+}
+
+func (o *ParserLoadArgs) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	var _o ParserLoadArgs
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "data":
+			_o.Data, err = decoder.ReadByteArray()
+		case "format":
+			_o.Format, err = convert.Numeric[Format](decoder.ReadInt32())
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+		*o = _o
+	}
+
+	return nil
+}
+
+func (o *ParserLoadArgs) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(2)
+	encoder.WriteString("data")
+	encoder.WriteByteArray(o.Data)
+	encoder.WriteString("format")
+	encoder.WriteInt32(int32(o.Format))
+
+	return nil
+}
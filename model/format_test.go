@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/model"
+)
+
+const formatSpec = `namespace "shop" @path("/shop")
+
+"An item."
+type Item {
+  name: string
+  price: f64 = 1.5
+  tags: [string]?
+  attributes: {string: i64}
+}
+
+enum Status {
+  active = 0 as "Active"
+  closed = 1
+}
+
+interface Store {
+  get(id: string): Item?
+}
+`
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		want model.Format
+	}{
+		{"json", model.FormatJSON},
+		{"YAML", model.FormatYAML},
+		{"MsgPack", model.FormatMSGPACK},
+	}
+	for _, tt := range tests {
+		got, err := model.ParseFormat(tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+		if got.String() != strings.ToUpper(tt.name) {
+			t.Errorf("%v.String() = %q", got, got.String())
+		}
+	}
+	if _, err := model.ParseFormat("xml"); err == nil || err.Error() != `unknown format "xml"` {
+		t.Errorf("ParseFormat(xml) error = %v", err)
+	}
+	if s := model.Format(0).String(); s != "unknown" {
+		t.Errorf("Format(0).String() = %q, want unknown", s)
+	}
+}
+
+func TestMarshalNamespace(t *testing.T) {
+	ns := convert(t, formatSpec)
+	for _, format := range []model.Format{model.FormatJSON, model.FormatYAML} {
+		t.Run(format.String(), func(t *testing.T) {
+			data, err := model.MarshalNamespace(ns, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := model.UnmarshalNamespace(data, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, ns) {
+				t.Errorf("decoded = %+v, want %+v", decoded, ns)
+			}
+		})
+	}
+
+	if _, err := model.MarshalNamespace(ns, 0); err == nil {
+		t.Error("MarshalNamespace with an unknown format succeeded")
+	}
+	if _, err := model.UnmarshalNamespace([]byte("{}"), 0); err == nil {
+		t.Error("UnmarshalNamespace with an unknown format succeeded")
+	}
+	if _, err := model.UnmarshalNamespace([]byte("name: [shop"), model.FormatYAML); err == nil {
+		t.Error("UnmarshalNamespace of invalid YAML succeeded")
+	}
+}
+
+type resolver map[string]string
+
+func (r resolver) Resolve(ctx context.Context, location, from string) (string, error) {
+	return r[location], nil
+}
+
+func TestFormatConverter(t *testing.T) {
+	ctx := context.Background()
+	c := model.NewFormatConverter(resolver{"items.apex": `type Item { name: string }`})
+	data, err := c.Convert(ctx, `namespace "shop"
+import * from "items.apex"
+
+type Order { item: Item }
+`, model.FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "name: shop") {
+		t.Errorf("yaml = %s", data)
+	}
+
+	result, err := c.Load(ctx, data, model.FormatYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ns := result.Namespace; ns == nil || ns.Name != "shop" || len(ns.Types) != 2 {
+		t.Errorf("loaded = %+v", result.Namespace)
+	}
+
+	if _, err := c.Convert(ctx, `type Item { name: string }`, model.FormatJSON); err == nil ||
+		!strings.Contains(err.Error(), "no namespace found") {
+		t.Errorf("Convert error = %v", err)
+	}
+	if _, err := c.Load(ctx, []byte("{"), model.FormatJSON); err == nil {
+		t.Error("Load of invalid JSON succeeded")
+	}
+}
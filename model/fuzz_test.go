@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/model"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+)
+
+// fuzzImports are the sources that fuzzed specs can import.
+var fuzzImports = map[string]string{
+	"common": `namespace "common"
+
+type Item @key {
+  id: string
+}
+
+directive @key() on TYPE
+`,
+	"self": `namespace "self"
+
+import * from "self"
+`,
+}
+
+var fuzzSeeds = []string{
+	``,
+	`namespace`,
+	`namespace "test" @info(version: "1.0")`,
+	`namespace "a"
+namespace "b"`,
+	`import * from "common"
+namespace "test"
+
+type Order @key { item: Item items: [Item] byId: {string: Item}? }`,
+	`namespace "test"
+import * as c from "common"
+import { Item as Thing, Missing } from "common"
+type Order { item: c.Item thing: Thing other: d.Item }`,
+	`import * from "self"`,
+	`namespace "test"
+union Any @deprecated = string | Any | [Any]
+enum Level { LOW = 0 as "low" HIGH = 0 }
+alias Id = Id
+func get(id: Id = 5): Any @n(a: [1, {b: true}])
+interface Service @service { op(id: i32, id: i32): void }
+directive @n(a: [value]) on FIELD | OPERATION require @m TYPE`,
+	`directive @a() on TYPE require @b NAMESPACE
+directive @b() on NAMESPACE
+type A @a { x: string }`,
+	`namespace "test"
+type A { a: [[[[[[[[[[string]]]]]]]]]] }`,
+	`namespace "test"
+type A @a(b: {c: {d: [[[[[[{e: 1}]]]]]]}}) { a: string = "x" }`,
+}
+
+// FuzzConvert checks that parsing, validating and converting arbitrary
+// specs, as the wasm exports do, returns errors rather than panicking.
+func FuzzConvert(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, spec string) {
+		doc, err := parser.Parse(parser.ParseParams{
+			Source: spec,
+			Options: parser.ParseOptions{
+				NoSource: true,
+				Resolver: func(location, from string) (string, error) {
+					return fuzzImports[location], nil
+				},
+			},
+		})
+		if err != nil {
+			return
+		}
+		validation := append(append([]rules.ValidationRule{}, rules.Rules...), rules.Lints...)
+		if errs := rules.Validate(doc, validation...); len(errs) > 0 {
+			return
+		}
+		ns, errs := model.Convert(doc)
+		if len(errs) > 0 {
+			return
+		}
+		if _, err := model.MarshalNamespace(ns, model.FormatJSON); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
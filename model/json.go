@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"github.com/CosmWasm/tinyjson/jlexer"
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// The enums marshal themselves for the generated tinyjson code so that
+// it does not call their MarshalJSON and UnmarshalJSON methods, which use
+// encoding/json.
+
+// MarshalTinyJSON writes the enum as a JSON string.
+func (e DirectiveLocation) MarshalTinyJSON(out *jwriter.Writer) {
+	out.String(e.String())
+}
+
+// UnmarshalTinyJSON reads the enum from a JSON string.
+func (e *DirectiveLocation) UnmarshalTinyJSON(in *jlexer.Lexer) {
+	in.AddError(e.FromString(in.String()))
+}
+
+// MarshalTinyJSON writes the enum as a JSON string.
+func (e Scalar) MarshalTinyJSON(out *jwriter.Writer) {
+	out.String(e.String())
+}
+
+// UnmarshalTinyJSON reads the enum from a JSON string.
+func (e *Scalar) UnmarshalTinyJSON(in *jlexer.Lexer) {
+	in.AddError(e.FromString(in.String()))
+}
+
+// MarshalTinyJSON writes the enum as a JSON string.
+func (e Kind) MarshalTinyJSON(out *jwriter.Writer) {
+	out.String(e.String())
+}
+
+// UnmarshalTinyJSON reads the enum from a JSON string.
+func (e *Kind) UnmarshalTinyJSON(in *jlexer.Lexer) {
+	in.AddError(e.FromString(in.String()))
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apexlang/apex-go/model"
+)
+
+func TestEnumJSON(t *testing.T) {
+	ref := model.TypeRef{Scalar: new(model.Scalar)}
+	*ref.Scalar = model.ScalarDatetime
+	data, err := ref.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"Scalar":"DATETIME"}`; string(data) != want {
+		t.Errorf("tinyjson = %s, want %s", data, want)
+	}
+	// The generated encoding/json methods agree.
+	std, err := json.Marshal(*ref.Scalar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(std) != `"DATETIME"` {
+		t.Errorf("encoding/json = %s", std)
+	}
+
+	var decoded model.TypeRef
+	if err := decoded.UnmarshalJSON([]byte(`{"Scalar":"DATETIME"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Scalar == nil || *decoded.Scalar != model.ScalarDatetime {
+		t.Errorf("decoded = %+v", decoded)
+	}
+	if err := decoded.UnmarshalJSON([]byte(`{"Scalar":"UNKNOWN"}`)); err == nil {
+		t.Error("decoded an unknown scalar")
+	}
+	decoded = model.TypeRef{}
+	if err := decoded.UnmarshalJSON([]byte(`{"Scalar":"DATE\u0054IME"}`)); err != nil || *decoded.Scalar != model.ScalarDatetime {
+		t.Errorf("decoding an escaped scalar = %v, %+v", err, decoded)
+	}
+}
@@ -61,6 +61,7 @@ type Namespace struct {
 	Interfaces  []Interface  `json:"interfaces,omitempty" yaml:"interfaces,omitempty" msgpack:"interfaces,omitempty"`
 	Types       []Type       `json:"types,omitempty" yaml:"types,omitempty" msgpack:"types,omitempty"`
 	Unions      []Union      `json:"unions,omitempty" yaml:"unions,omitempty" msgpack:"unions,omitempty"`
+	Enums       []Enum       `json:"enums,omitempty" yaml:"enums,omitempty" msgpack:"enums,omitempty"`
 }
 
 // DefaultNamespace returns a `Namespace` struct populated with its default values.
@@ -73,6 +74,7 @@ type Import struct {
 	Description *string      `json:"description,omitempty" yaml:"description,omitempty" msgpack:"description,omitempty"`
 	All         bool         `json:"all" yaml:"all" msgpack:"all"`
 	Names       []ImportRef  `json:"names,omitempty" yaml:"names,omitempty" msgpack:"names,omitempty"`
+	As          *string      `json:"as,omitempty" yaml:"as,omitempty" msgpack:"as,omitempty"`
 	From        string       `json:"from" yaml:"from" msgpack:"from"`
 	Annotations []Annotation `json:"annotations,omitempty" yaml:"annotations,omitempty" msgpack:"annotations,omitempty"`
 }
@@ -101,6 +103,7 @@ type Type struct {
 	Description *string      `json:"description,omitempty" yaml:"description,omitempty" msgpack:"description,omitempty"`
 	Fields      []Field      `json:"fields" yaml:"fields" msgpack:"fields"`
 	Annotations []Annotation `json:"annotations,omitempty" yaml:"annotations,omitempty" msgpack:"annotations,omitempty"`
+	Deprecated  *Deprecated  `json:"deprecated,omitempty" yaml:"deprecated,omitempty" msgpack:"deprecated,omitempty"`
 }
 
 // DefaultType returns a `Type` struct populated with its default values.
@@ -116,6 +119,7 @@ type Interface struct {
 	Description *string      `json:"description,omitempty" yaml:"description,omitempty" msgpack:"description,omitempty"`
 	Operations  []Operation  `json:"operations" yaml:"operations" msgpack:"operations"`
 	Annotations []Annotation `json:"annotations,omitempty" yaml:"annotations,omitempty" msgpack:"annotations,omitempty"`
+	Deprecated  *Deprecated  `json:"deprecated,omitempty" yaml:"deprecated,omitempty" msgpack:"deprecated,omitempty"`
 }
 
 // DefaultInterface returns a `Interface` struct populated with its default values.
@@ -130,6 +134,7 @@ type Alias struct {
 	Description *string      `json:"description,omitempty" yaml:"description,omitempty" msgpack:"description,omitempty"`
 	Type        TypeRef      `json:"type" yaml:"type" msgpack:"type"`
 	Annotations []Annotation `json:"annotations,omitempty" yaml:"annotations,omitempty" msgpack:"annotations,omitempty"`
+	Deprecated  *Deprecated  `json:"deprecated,omitempty" yaml:"deprecated,omitempty" msgpack:"deprecated,omitempty"`
 }
 
 // DefaultAlias returns a `Alias` struct populated with its default values.
@@ -144,6 +149,7 @@ type Operation struct {
 	Unary       *Parameter   `json:"unary,omitempty" yaml:"unary,omitempty" msgpack:"unary,omitempty"`
 	Returns     *TypeRef     `json:"returns,omitempty" yaml:"returns,omitempty" msgpack:"returns,omitempty"`
 	Annotations []Annotation `json:"annotations,omitempty" yaml:"annotations,omitempty" msgpack:"annotations,omitempty"`
+	Deprecated  *Deprecated  `json:"deprecated,omitempty" yaml:"deprecated,omitempty" msgpack:"deprecated,omitempty"`
 }
 
 // DefaultOperation returns a `Operation` struct populated with its default values.
@@ -157,6 +163,7 @@ type Parameter struct {
 	Type         TypeRef      `json:"type" yaml:"type" msgpack:"type"`
 	DefaultValue *Value       `json:"defaultValue,omitempty" yaml:"defaultValue,omitempty" msgpack:"defaultValue,omitempty"`
 	Annotations  []Annotation `json:"annotations,omitempty" yaml:"annotations,omitempty" msgpack:"annotations,omitempty"`
+	Deprecated   *Deprecated  `json:"deprecated,omitempty" yaml:"deprecated,omitempty" msgpack:"deprecated,omitempty"`
 }
 
 // DefaultParameter returns a `Parameter` struct populated with its default values.
@@ -170,6 +177,7 @@ type Field struct {
 	Type         TypeRef      `json:"type" yaml:"type" msgpack:"type"`
 	DefaultValue *Value       `json:"defaultValue,omitempty" yaml:"defaultValue,omitempty" msgpack:"defaultValue,omitempty"`
 	Annotations  []Annotation `json:"annotations,omitempty" yaml:"annotations,omitempty" msgpack:"annotations,omitempty"`
+	Deprecated   *Deprecated  `json:"deprecated,omitempty" yaml:"deprecated,omitempty" msgpack:"deprecated,omitempty"`
 }
 
 // DefaultField returns a `Field` struct populated with its default values.
@@ -183,6 +191,7 @@ type Union struct {
 	Description *string       `json:"description,omitempty" yaml:"description,omitempty" msgpack:"description,omitempty"`
 	Members     []UnionMember `json:"members" yaml:"members" msgpack:"members"`
 	Annotations []Annotation  `json:"annotations,omitempty" yaml:"annotations,omitempty" msgpack:"annotations,omitempty"`
+	Deprecated  *Deprecated   `json:"deprecated,omitempty" yaml:"deprecated,omitempty" msgpack:"deprecated,omitempty"`
 }
 
 // DefaultUnion returns a `Union` struct populated with its default values.
@@ -209,6 +218,7 @@ type Enum struct {
 	Description *string      `json:"description,omitempty" yaml:"description,omitempty" msgpack:"description,omitempty"`
 	Values      []EnumValue  `json:"values" yaml:"values" msgpack:"values"`
 	Annotations []Annotation `json:"annotations,omitempty" yaml:"annotations,omitempty" msgpack:"annotations,omitempty"`
+	Deprecated  *Deprecated  `json:"deprecated,omitempty" yaml:"deprecated,omitempty" msgpack:"deprecated,omitempty"`
 }
 
 // DefaultEnum returns a `Enum` struct populated with its default values.
@@ -222,6 +232,7 @@ type EnumValue struct {
 	Index       uint64       `json:"index" yaml:"index" msgpack:"index"`
 	Display     *string      `json:"display,omitempty" yaml:"display,omitempty" msgpack:"display,omitempty"`
 	Annotations []Annotation `json:"annotations,omitempty" yaml:"annotations,omitempty" msgpack:"annotations,omitempty"`
+	Deprecated  *Deprecated  `json:"deprecated,omitempty" yaml:"deprecated,omitempty" msgpack:"deprecated,omitempty"`
 }
 
 // DefaultEnumValue returns a `EnumValue` struct populated with its default values.
@@ -229,6 +240,18 @@ func DefaultEnumValue() EnumValue {
 	return EnumValue{}
 }
 
+// Deprecated marks an element as no longer recommended for use.
+type Deprecated struct {
+	Reason *string `json:"reason,omitempty" yaml:"reason,omitempty" msgpack:"reason,omitempty"`
+	Since  *string `json:"since,omitempty" yaml:"since,omitempty" msgpack:"since,omitempty"`
+}
+
+// DefaultDeprecated returns a `Deprecated` struct populated with its default
+// values.
+func DefaultDeprecated() Deprecated {
+	return Deprecated{}
+}
+
 // Directives are used to ensure that an annotation's arguments match an expected
 // format.
 type Directive struct {
@@ -280,8 +303,12 @@ func DefaultArgument() Argument {
 }
 
 type Named struct {
-	Kind Kind   `json:"kind" yaml:"kind" msgpack:"kind"`
-	Name string `json:"name" yaml:"name" msgpack:"name"`
+	Kind Kind `json:"kind" yaml:"kind" msgpack:"kind"`
+	// The alias of the namespace import that qualifies the name.
+	Qualifier *string `json:"qualifier,omitempty" yaml:"qualifier,omitempty" msgpack:"qualifier,omitempty"`
+	Name      string  `json:"name" yaml:"name" msgpack:"name"`
+	// The namespace that defines a type imported with a namespace alias.
+	Namespace *string `json:"namespace,omitempty" yaml:"namespace,omitempty" msgpack:"namespace,omitempty"`
 }
 
 // DefaultNamed returns a `Named` struct populated with its default values.
@@ -411,6 +411,16 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel2(in *jlexer.Lexer, out *
 				}
 				in.Delim(']')
 			}
+		case "deprecated":
+			if in.IsNull() {
+				in.Skip()
+				out.Deprecated = nil
+			} else {
+				if out.Deprecated == nil {
+					out.Deprecated = new(Deprecated)
+				}
+				(*out.Deprecated).UnmarshalTinyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -465,6 +475,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel2(out *jwriter.Writer, in
 			out.RawByte(']')
 		}
 	}
+	if in.Deprecated != nil {
+		const prefix string = ",\"deprecated\":"
+		out.RawString(prefix)
+		(*in.Deprecated).MarshalTinyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -518,9 +533,7 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel3(in *jlexer.Lexer, out *
 				if out.Scalar == nil {
 					out.Scalar = new(Scalar)
 				}
-				if data := in.Raw(); in.Ok() {
-					in.AddError((*out.Scalar).UnmarshalJSON(data))
-				}
+				(*out.Scalar).UnmarshalTinyJSON(in)
 			}
 		case "Named":
 			if in.IsNull() {
@@ -590,7 +603,7 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel3(out *jwriter.Writer, in
 		const prefix string = ",\"Scalar\":"
 		first = false
 		out.RawString(prefix[1:])
-		out.Raw((*in.Scalar).MarshalJSON())
+		(*in.Scalar).MarshalTinyJSON(out)
 	}
 	if in.Named != nil {
 		const prefix string = ",\"Named\":"
@@ -745,6 +758,16 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel4(in *jlexer.Lexer, out *
 				}
 				in.Delim(']')
 			}
+		case "deprecated":
+			if in.IsNull() {
+				in.Skip()
+				out.Deprecated = nil
+			} else {
+				if out.Deprecated == nil {
+					out.Deprecated = new(Deprecated)
+				}
+				(*out.Deprecated).UnmarshalTinyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -799,6 +822,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel4(out *jwriter.Writer, in
 			out.RawByte(']')
 		}
 	}
+	if in.Deprecated != nil {
+		const prefix string = ",\"deprecated\":"
+		out.RawString(prefix)
+		(*in.Deprecated).MarshalTinyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -1140,6 +1168,16 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel8(in *jlexer.Lexer, out *
 				}
 				in.Delim(']')
 			}
+		case "deprecated":
+			if in.IsNull() {
+				in.Skip()
+				out.Deprecated = nil
+			} else {
+				if out.Deprecated == nil {
+					out.Deprecated = new(Deprecated)
+				}
+				(*out.Deprecated).UnmarshalTinyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1188,6 +1226,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel8(out *jwriter.Writer, in
 			out.RawByte(']')
 		}
 	}
+	if in.Deprecated != nil {
+		const prefix string = ",\"deprecated\":"
+		out.RawString(prefix)
+		(*in.Deprecated).MarshalTinyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -1377,6 +1420,16 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel10(in *jlexer.Lexer, out
 				}
 				in.Delim(']')
 			}
+		case "deprecated":
+			if in.IsNull() {
+				in.Skip()
+				out.Deprecated = nil
+			} else {
+				if out.Deprecated == nil {
+					out.Deprecated = new(Deprecated)
+				}
+				(*out.Deprecated).UnmarshalTinyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1439,6 +1492,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel10(out *jwriter.Writer, i
 			out.RawByte(']')
 		}
 	}
+	if in.Deprecated != nil {
+		const prefix string = ",\"deprecated\":"
+		out.RawString(prefix)
+		(*in.Deprecated).MarshalTinyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -1851,6 +1909,29 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel13(in *jlexer.Lexer, out
 				}
 				in.Delim(']')
 			}
+		case "enums":
+			if in.IsNull() {
+				in.Skip()
+				out.Enums = nil
+			} else {
+				in.Delim('[')
+				if out.Enums == nil {
+					if !in.IsDelim(']') {
+						out.Enums = make([]Enum, 0, 0)
+					} else {
+						out.Enums = []Enum{}
+					}
+				} else {
+					out.Enums = (out.Enums)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v39 Enum
+					(v39).UnmarshalTinyJSON(in)
+					out.Enums = append(out.Enums, v39)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1880,11 +1961,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel13(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v39, v40 := range in.Annotations {
-				if v39 > 0 {
+			for v40, v41 := range in.Annotations {
+				if v40 > 0 {
 					out.RawByte(',')
 				}
-				(v40).MarshalTinyJSON(out)
+				(v41).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1894,11 +1975,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel13(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v41, v42 := range in.Imports {
-				if v41 > 0 {
+			for v42, v43 := range in.Imports {
+				if v42 > 0 {
 					out.RawByte(',')
 				}
-				(v42).MarshalTinyJSON(out)
+				(v43).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1908,11 +1989,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel13(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v43, v44 := range in.Directives {
-				if v43 > 0 {
+			for v44, v45 := range in.Directives {
+				if v44 > 0 {
 					out.RawByte(',')
 				}
-				(v44).MarshalTinyJSON(out)
+				(v45).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1922,11 +2003,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel13(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v45, v46 := range in.Aliases {
-				if v45 > 0 {
+			for v46, v47 := range in.Aliases {
+				if v46 > 0 {
 					out.RawByte(',')
 				}
-				(v46).MarshalTinyJSON(out)
+				(v47).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1936,11 +2017,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel13(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v47, v48 := range in.Functions {
-				if v47 > 0 {
+			for v48, v49 := range in.Functions {
+				if v48 > 0 {
 					out.RawByte(',')
 				}
-				(v48).MarshalTinyJSON(out)
+				(v49).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1950,11 +2031,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel13(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v49, v50 := range in.Interfaces {
-				if v49 > 0 {
+			for v50, v51 := range in.Interfaces {
+				if v50 > 0 {
 					out.RawByte(',')
 				}
-				(v50).MarshalTinyJSON(out)
+				(v51).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1964,11 +2045,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel13(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v51, v52 := range in.Types {
-				if v51 > 0 {
+			for v52, v53 := range in.Types {
+				if v52 > 0 {
 					out.RawByte(',')
 				}
-				(v52).MarshalTinyJSON(out)
+				(v53).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1978,11 +2059,25 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel13(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v53, v54 := range in.Unions {
-				if v53 > 0 {
+			for v54, v55 := range in.Unions {
+				if v54 > 0 {
 					out.RawByte(',')
 				}
-				(v54).MarshalTinyJSON(out)
+				(v55).MarshalTinyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Enums) != 0 {
+		const prefix string = ",\"enums\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v56, v57 := range in.Enums {
+				if v56 > 0 {
+					out.RawByte(',')
+				}
+				(v57).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2033,11 +2128,29 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel14(in *jlexer.Lexer, out
 		}
 		switch key {
 		case "kind":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Kind).UnmarshalJSON(data))
+			(out.Kind).UnmarshalTinyJSON(in)
+		case "qualifier":
+			if in.IsNull() {
+				in.Skip()
+				out.Qualifier = nil
+			} else {
+				if out.Qualifier == nil {
+					out.Qualifier = new(string)
+				}
+				*out.Qualifier = string(in.String())
 			}
 		case "name":
 			out.Name = string(in.String())
+		case "namespace":
+			if in.IsNull() {
+				in.Skip()
+				out.Namespace = nil
+			} else {
+				if out.Namespace == nil {
+					out.Namespace = new(string)
+				}
+				*out.Namespace = string(in.String())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -2055,13 +2168,23 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel14(out *jwriter.Writer, i
 	{
 		const prefix string = ",\"kind\":"
 		out.RawString(prefix[1:])
-		out.Raw((in.Kind).MarshalJSON())
+		(in.Kind).MarshalTinyJSON(out)
+	}
+	if in.Qualifier != nil {
+		const prefix string = ",\"qualifier\":"
+		out.RawString(prefix)
+		out.String(string(*in.Qualifier))
 	}
 	{
 		const prefix string = ",\"name\":"
 		out.RawString(prefix)
 		out.String(string(in.Name))
 	}
+	if in.Namespace != nil {
+		const prefix string = ",\"namespace\":"
+		out.RawString(prefix)
+		out.String(string(*in.Namespace))
+	}
 	out.RawByte('}')
 }
 
@@ -2269,9 +2392,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel17(in *jlexer.Lexer, out
 					out.Values = (out.Values)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v55 Value
-					(v55).UnmarshalTinyJSON(in)
-					out.Values = append(out.Values, v55)
+					var v58 Value
+					(v58).UnmarshalTinyJSON(in)
+					out.Values = append(out.Values, v58)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2297,11 +2420,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel17(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v56, v57 := range in.Values {
-				if v56 > 0 {
+			for v59, v60 := range in.Values {
+				if v59 > 0 {
 					out.RawByte(',')
 				}
-				(v57).MarshalTinyJSON(out)
+				(v60).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2445,9 +2568,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel19(in *jlexer.Lexer, out
 					out.Operations = (out.Operations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v58 Operation
-					(v58).UnmarshalTinyJSON(in)
-					out.Operations = append(out.Operations, v58)
+					var v61 Operation
+					(v61).UnmarshalTinyJSON(in)
+					out.Operations = append(out.Operations, v61)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2468,13 +2591,23 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel19(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v59 Annotation
-					(v59).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v59)
+					var v62 Annotation
+					(v62).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v62)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "deprecated":
+			if in.IsNull() {
+				in.Skip()
+				out.Deprecated = nil
+			} else {
+				if out.Deprecated == nil {
+					out.Deprecated = new(Deprecated)
+				}
+				(*out.Deprecated).UnmarshalTinyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -2506,11 +2639,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel19(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v60, v61 := range in.Operations {
-				if v60 > 0 {
+			for v63, v64 := range in.Operations {
+				if v63 > 0 {
 					out.RawByte(',')
 				}
-				(v61).MarshalTinyJSON(out)
+				(v64).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2520,15 +2653,20 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel19(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v62, v63 := range in.Annotations {
-				if v62 > 0 {
+			for v65, v66 := range in.Annotations {
+				if v65 > 0 {
 					out.RawByte(',')
 				}
-				(v63).MarshalTinyJSON(out)
+				(v66).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	if in.Deprecated != nil {
+		const prefix string = ",\"deprecated\":"
+		out.RawString(prefix)
+		(*in.Deprecated).MarshalTinyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -2683,13 +2821,23 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel21(in *jlexer.Lexer, out
 					out.Names = (out.Names)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v64 ImportRef
-					(v64).UnmarshalTinyJSON(in)
-					out.Names = append(out.Names, v64)
+					var v67 ImportRef
+					(v67).UnmarshalTinyJSON(in)
+					out.Names = append(out.Names, v67)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "as":
+			if in.IsNull() {
+				in.Skip()
+				out.As = nil
+			} else {
+				if out.As == nil {
+					out.As = new(string)
+				}
+				*out.As = string(in.String())
+			}
 		case "from":
 			out.From = string(in.String())
 		case "annotations":
@@ -2708,9 +2856,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel21(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v65 Annotation
-					(v65).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v65)
+					var v68 Annotation
+					(v68).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v68)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2750,15 +2898,20 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel21(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v66, v67 := range in.Names {
-				if v66 > 0 {
+			for v69, v70 := range in.Names {
+				if v69 > 0 {
 					out.RawByte(',')
 				}
-				(v67).MarshalTinyJSON(out)
+				(v70).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	if in.As != nil {
+		const prefix string = ",\"as\":"
+		out.RawString(prefix)
+		out.String(string(*in.As))
+	}
 	{
 		const prefix string = ",\"from\":"
 		out.RawString(prefix)
@@ -2769,11 +2922,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel21(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v68, v69 := range in.Annotations {
-				if v68 > 0 {
+			for v71, v72 := range in.Annotations {
+				if v71 > 0 {
 					out.RawByte(',')
 				}
-				(v69).MarshalTinyJSON(out)
+				(v72).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2863,13 +3016,23 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel22(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v70 Annotation
-					(v70).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v70)
+					var v73 Annotation
+					(v73).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v73)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "deprecated":
+			if in.IsNull() {
+				in.Skip()
+				out.Deprecated = nil
+			} else {
+				if out.Deprecated == nil {
+					out.Deprecated = new(Deprecated)
+				}
+				(*out.Deprecated).UnmarshalTinyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -2909,15 +3072,20 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel22(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v71, v72 := range in.Annotations {
-				if v71 > 0 {
+			for v74, v75 := range in.Annotations {
+				if v74 > 0 {
 					out.RawByte(',')
 				}
-				(v72).MarshalTinyJSON(out)
+				(v75).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	if in.Deprecated != nil {
+		const prefix string = ",\"deprecated\":"
+		out.RawString(prefix)
+		(*in.Deprecated).MarshalTinyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -2981,9 +3149,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel23(in *jlexer.Lexer, out
 					out.Positions = (out.Positions)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v73 uint32
-					v73 = uint32(in.Uint32())
-					out.Positions = append(out.Positions, v73)
+					var v76 uint32
+					v76 = uint32(in.Uint32())
+					out.Positions = append(out.Positions, v76)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3004,9 +3172,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel23(in *jlexer.Lexer, out
 					out.Locations = (out.Locations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v74 Location
-					(v74).UnmarshalTinyJSON(in)
-					out.Locations = append(out.Locations, v74)
+					var v77 Location
+					(v77).UnmarshalTinyJSON(in)
+					out.Locations = append(out.Locations, v77)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3037,11 +3205,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel23(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v75, v76 := range in.Positions {
-				if v75 > 0 {
+			for v78, v79 := range in.Positions {
+				if v78 > 0 {
 					out.RawByte(',')
 				}
-				out.Uint32(uint32(v76))
+				out.Uint32(uint32(v79))
 			}
 			out.RawByte(']')
 		}
@@ -3053,11 +3221,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel23(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v77, v78 := range in.Locations {
-				if v77 > 0 {
+			for v80, v81 := range in.Locations {
+				if v80 > 0 {
 					out.RawByte(',')
 				}
-				(v78).MarshalTinyJSON(out)
+				(v81).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3147,13 +3315,23 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel24(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v79 Annotation
-					(v79).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v79)
+					var v82 Annotation
+					(v82).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v82)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "deprecated":
+			if in.IsNull() {
+				in.Skip()
+				out.Deprecated = nil
+			} else {
+				if out.Deprecated == nil {
+					out.Deprecated = new(Deprecated)
+				}
+				(*out.Deprecated).UnmarshalTinyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -3193,15 +3371,20 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel24(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v80, v81 := range in.Annotations {
-				if v80 > 0 {
+			for v83, v84 := range in.Annotations {
+				if v83 > 0 {
 					out.RawByte(',')
 				}
-				(v81).MarshalTinyJSON(out)
+				(v84).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	if in.Deprecated != nil {
+		const prefix string = ",\"deprecated\":"
+		out.RawString(prefix)
+		(*in.Deprecated).MarshalTinyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -3267,7 +3450,7 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel25(in *jlexer.Lexer, out
 				in.Delim('[')
 				if out.Values == nil {
 					if !in.IsDelim(']') {
-						out.Values = make([]EnumValue, 0, 1)
+						out.Values = make([]EnumValue, 0, 0)
 					} else {
 						out.Values = []EnumValue{}
 					}
@@ -3275,9 +3458,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel25(in *jlexer.Lexer, out
 					out.Values = (out.Values)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v82 EnumValue
-					(v82).UnmarshalTinyJSON(in)
-					out.Values = append(out.Values, v82)
+					var v85 EnumValue
+					(v85).UnmarshalTinyJSON(in)
+					out.Values = append(out.Values, v85)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3298,13 +3481,23 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel25(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v83 Annotation
-					(v83).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v83)
+					var v86 Annotation
+					(v86).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v86)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "deprecated":
+			if in.IsNull() {
+				in.Skip()
+				out.Deprecated = nil
+			} else {
+				if out.Deprecated == nil {
+					out.Deprecated = new(Deprecated)
+				}
+				(*out.Deprecated).UnmarshalTinyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -3336,11 +3529,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel25(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v84, v85 := range in.Values {
-				if v84 > 0 {
+			for v87, v88 := range in.Values {
+				if v87 > 0 {
 					out.RawByte(',')
 				}
-				(v85).MarshalTinyJSON(out)
+				(v88).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3350,15 +3543,20 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel25(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v86, v87 := range in.Annotations {
-				if v86 > 0 {
+			for v89, v90 := range in.Annotations {
+				if v89 > 0 {
 					out.RawByte(',')
 				}
-				(v87).MarshalTinyJSON(out)
+				(v90).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	if in.Deprecated != nil {
+		const prefix string = ",\"deprecated\":"
+		out.RawString(prefix)
+		(*in.Deprecated).MarshalTinyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -3422,11 +3620,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel26(in *jlexer.Lexer, out
 					out.Locations = (out.Locations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v88 DirectiveLocation
-					if data := in.Raw(); in.Ok() {
-						in.AddError((v88).UnmarshalJSON(data))
-					}
-					out.Locations = append(out.Locations, v88)
+					var v91 DirectiveLocation
+					(v91).UnmarshalTinyJSON(in)
+					out.Locations = append(out.Locations, v91)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3457,11 +3653,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel26(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v89, v90 := range in.Locations {
-				if v89 > 0 {
+			for v92, v93 := range in.Locations {
+				if v92 > 0 {
 					out.RawByte(',')
 				}
-				out.Raw((v90).MarshalJSON())
+				(v93).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3539,9 +3735,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel27(in *jlexer.Lexer, out
 					out.Parameters = (out.Parameters)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v91 Parameter
-					(v91).UnmarshalTinyJSON(in)
-					out.Parameters = append(out.Parameters, v91)
+					var v94 Parameter
+					(v94).UnmarshalTinyJSON(in)
+					out.Parameters = append(out.Parameters, v94)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3562,11 +3758,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel27(in *jlexer.Lexer, out
 					out.Locations = (out.Locations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v92 DirectiveLocation
-					if data := in.Raw(); in.Ok() {
-						in.AddError((v92).UnmarshalJSON(data))
-					}
-					out.Locations = append(out.Locations, v92)
+					var v95 DirectiveLocation
+					(v95).UnmarshalTinyJSON(in)
+					out.Locations = append(out.Locations, v95)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3587,9 +3781,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel27(in *jlexer.Lexer, out
 					out.Require = (out.Require)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v93 DirectiveRequire
-					(v93).UnmarshalTinyJSON(in)
-					out.Require = append(out.Require, v93)
+					var v96 DirectiveRequire
+					(v96).UnmarshalTinyJSON(in)
+					out.Require = append(out.Require, v96)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3623,11 +3817,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel27(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v94, v95 := range in.Parameters {
-				if v94 > 0 {
+			for v97, v98 := range in.Parameters {
+				if v97 > 0 {
 					out.RawByte(',')
 				}
-				(v95).MarshalTinyJSON(out)
+				(v98).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3639,11 +3833,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel27(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v96, v97 := range in.Locations {
-				if v96 > 0 {
+			for v99, v100 := range in.Locations {
+				if v99 > 0 {
 					out.RawByte(',')
 				}
-				out.Raw((v97).MarshalJSON())
+				(v100).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3655,11 +3849,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel27(out *jwriter.Writer, i
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v98, v99 := range in.Require {
-				if v98 > 0 {
+			for v101, v102 := range in.Require {
+				if v101 > 0 {
 					out.RawByte(',')
 				}
-				(v99).MarshalTinyJSON(out)
+				(v102).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3690,7 +3884,102 @@ func (v *Directive) UnmarshalJSON(data []byte) error {
 func (v *Directive) UnmarshalTinyJSON(l *jlexer.Lexer) {
 	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel27(l, v)
 }
-func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel28(in *jlexer.Lexer, out *Argument) {
+func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel28(in *jlexer.Lexer, out *Deprecated) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reason":
+			if in.IsNull() {
+				in.Skip()
+				out.Reason = nil
+			} else {
+				if out.Reason == nil {
+					out.Reason = new(string)
+				}
+				*out.Reason = string(in.String())
+			}
+		case "since":
+			if in.IsNull() {
+				in.Skip()
+				out.Since = nil
+			} else {
+				if out.Since == nil {
+					out.Since = new(string)
+				}
+				*out.Since = string(in.String())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel28(out *jwriter.Writer, in Deprecated) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Reason != nil {
+		const prefix string = ",\"reason\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(*in.Reason))
+	}
+	if in.Since != nil {
+		const prefix string = ",\"since\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(*in.Since))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Deprecated) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalTinyJSON supports tinyjson.Marshaler interface
+func (v Deprecated) MarshalTinyJSON(w *jwriter.Writer) {
+	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Deprecated) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
+func (v *Deprecated) UnmarshalTinyJSON(l *jlexer.Lexer) {
+	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel28(l, v)
+}
+func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel29(in *jlexer.Lexer, out *Argument) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3723,7 +4012,7 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel28(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel28(out *jwriter.Writer, in Argument) {
+func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel29(out *jwriter.Writer, in Argument) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3743,27 +4032,27 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel28(out *jwriter.Writer, i
 // MarshalJSON supports json.Marshaler interface
 func (v Argument) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel28(&w, v)
+	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel29(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalTinyJSON supports tinyjson.Marshaler interface
 func (v Argument) MarshalTinyJSON(w *jwriter.Writer) {
-	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel28(w, v)
+	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel29(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Argument) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel28(&r, v)
+	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel29(&r, v)
 	return r.Error()
 }
 
 // UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
 func (v *Argument) UnmarshalTinyJSON(l *jlexer.Lexer) {
-	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel28(l, v)
+	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel29(l, v)
 }
-func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel29(in *jlexer.Lexer, out *Annotation) {
+func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel30(in *jlexer.Lexer, out *Annotation) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3800,9 +4089,9 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel29(in *jlexer.Lexer, out
 					out.Arguments = (out.Arguments)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v100 Argument
-					(v100).UnmarshalTinyJSON(in)
-					out.Arguments = append(out.Arguments, v100)
+					var v103 Argument
+					(v103).UnmarshalTinyJSON(in)
+					out.Arguments = append(out.Arguments, v103)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -3817,7 +4106,7 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel29(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel29(out *jwriter.Writer, in Annotation) {
+func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel30(out *jwriter.Writer, in Annotation) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3831,11 +4120,11 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel29(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v101, v102 := range in.Arguments {
-				if v101 > 0 {
+			for v104, v105 := range in.Arguments {
+				if v104 > 0 {
 					out.RawByte(',')
 				}
-				(v102).MarshalTinyJSON(out)
+				(v105).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -3846,27 +4135,27 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel29(out *jwriter.Writer, i
 // MarshalJSON supports json.Marshaler interface
 func (v Annotation) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel29(&w, v)
+	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel30(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalTinyJSON supports tinyjson.Marshaler interface
 func (v Annotation) MarshalTinyJSON(w *jwriter.Writer) {
-	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel29(w, v)
+	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel30(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Annotation) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel29(&r, v)
+	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel30(&r, v)
 	return r.Error()
 }
 
 // UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
 func (v *Annotation) UnmarshalTinyJSON(l *jlexer.Lexer) {
-	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel29(l, v)
+	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel30(l, v)
 }
-func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel30(in *jlexer.Lexer, out *Alias) {
+func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel31(in *jlexer.Lexer, out *Alias) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3915,13 +4204,23 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel30(in *jlexer.Lexer, out
 					out.Annotations = (out.Annotations)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v103 Annotation
-					(v103).UnmarshalTinyJSON(in)
-					out.Annotations = append(out.Annotations, v103)
+					var v106 Annotation
+					(v106).UnmarshalTinyJSON(in)
+					out.Annotations = append(out.Annotations, v106)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "deprecated":
+			if in.IsNull() {
+				in.Skip()
+				out.Deprecated = nil
+			} else {
+				if out.Deprecated == nil {
+					out.Deprecated = new(Deprecated)
+				}
+				(*out.Deprecated).UnmarshalTinyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -3932,7 +4231,7 @@ func tinyjson85aaecc5DecodeGithubComApexlangApexGoModel30(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel30(out *jwriter.Writer, in Alias) {
+func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel31(out *jwriter.Writer, in Alias) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3956,38 +4255,43 @@ func tinyjson85aaecc5EncodeGithubComApexlangApexGoModel30(out *jwriter.Writer, i
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v104, v105 := range in.Annotations {
-				if v104 > 0 {
+			for v107, v108 := range in.Annotations {
+				if v107 > 0 {
 					out.RawByte(',')
 				}
-				(v105).MarshalTinyJSON(out)
+				(v108).MarshalTinyJSON(out)
 			}
 			out.RawByte(']')
 		}
 	}
+	if in.Deprecated != nil {
+		const prefix string = ",\"deprecated\":"
+		out.RawString(prefix)
+		(*in.Deprecated).MarshalTinyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Alias) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel30(&w, v)
+	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel31(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalTinyJSON supports tinyjson.Marshaler interface
 func (v Alias) MarshalTinyJSON(w *jwriter.Writer) {
-	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel30(w, v)
+	tinyjson85aaecc5EncodeGithubComApexlangApexGoModel31(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Alias) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel30(&r, v)
+	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel31(&r, v)
 	return r.Error()
 }
 
 // UnmarshalTinyJSON supports tinyjson.Unmarshaler interface
 func (v *Alias) UnmarshalTinyJSON(l *jlexer.Lexer) {
-	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel30(l, v)
+	tinyjson85aaecc5DecodeGithubComApexlangApexGoModel31(l, v)
 }
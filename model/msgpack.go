@@ -422,6 +422,21 @@ func (o *Namespace) Decode(decoder msgpack.Reader) error {
 				}
 				_o.Unions = append(_o.Unions, nonNilItem)
 			}
+		case "enums":
+			listSize, err := decoder.ReadArraySize()
+			if err != nil {
+				return err
+			}
+			_o.Enums = make([]Enum, 0, listSize)
+			for listSize > 0 {
+				listSize--
+				var nonNilItem Enum
+				err = nonNilItem.Decode(decoder)
+				if err != nil {
+					return err
+				}
+				_o.Enums = append(_o.Enums, nonNilItem)
+			}
 		default:
 			err = decoder.Skip()
 		}
@@ -439,7 +454,7 @@ func (o *Namespace) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(10)
+	encoder.WriteMapSize(11)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
 	encoder.WriteString("description")
@@ -484,6 +499,11 @@ func (o *Namespace) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Unions {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("enums")
+	encoder.WriteArraySize(uint32(len(o.Enums)))
+	for _, v := range o.Enums {
+		v.Encode(encoder)
+	}
 
 	return nil
 }
@@ -521,6 +541,8 @@ func (o *Import) Decode(decoder msgpack.Reader) error {
 				}
 				_o.Names = append(_o.Names, nonNilItem)
 			}
+		case "as":
+			_o.As, err = decoder.ReadNillableString()
 		case "from":
 			_o.From, err = decoder.ReadString()
 		case "annotations":
@@ -555,7 +577,7 @@ func (o *Import) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(5)
+	encoder.WriteMapSize(6)
 	encoder.WriteString("description")
 	encoder.WriteNillableString(o.Description)
 	encoder.WriteString("all")
@@ -565,6 +587,8 @@ func (o *Import) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Names {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("as")
+	encoder.WriteNillableString(o.As)
 	encoder.WriteString("from")
 	encoder.WriteString(o.From)
 	encoder.WriteString("annotations")
@@ -668,6 +692,8 @@ func (o *Type) Decode(decoder msgpack.Reader) error {
 				}
 				_o.Annotations = append(_o.Annotations, nonNilItem)
 			}
+		case "deprecated":
+			_o.Deprecated, err = msgpack.DecodeNillable[Deprecated](decoder)
 		default:
 			err = decoder.Skip()
 		}
@@ -685,7 +711,7 @@ func (o *Type) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(4)
+	encoder.WriteMapSize(5)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
 	encoder.WriteString("description")
@@ -700,6 +726,8 @@ func (o *Type) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Annotations {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("deprecated")
+	o.Deprecated.Encode(encoder)
 
 	return nil
 }
@@ -752,6 +780,8 @@ func (o *Interface) Decode(decoder msgpack.Reader) error {
 				}
 				_o.Annotations = append(_o.Annotations, nonNilItem)
 			}
+		case "deprecated":
+			_o.Deprecated, err = msgpack.DecodeNillable[Deprecated](decoder)
 		default:
 			err = decoder.Skip()
 		}
@@ -769,7 +799,7 @@ func (o *Interface) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(4)
+	encoder.WriteMapSize(5)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
 	encoder.WriteString("description")
@@ -784,6 +814,8 @@ func (o *Interface) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Annotations {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("deprecated")
+	o.Deprecated.Encode(encoder)
 
 	return nil
 }
@@ -823,6 +855,8 @@ func (o *Alias) Decode(decoder msgpack.Reader) error {
 				}
 				_o.Annotations = append(_o.Annotations, nonNilItem)
 			}
+		case "deprecated":
+			_o.Deprecated, err = msgpack.DecodeNillable[Deprecated](decoder)
 		default:
 			err = decoder.Skip()
 		}
@@ -840,7 +874,7 @@ func (o *Alias) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(4)
+	encoder.WriteMapSize(5)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
 	encoder.WriteString("description")
@@ -852,6 +886,8 @@ func (o *Alias) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Annotations {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("deprecated")
+	o.Deprecated.Encode(encoder)
 
 	return nil
 }
@@ -908,6 +944,8 @@ func (o *Operation) Decode(decoder msgpack.Reader) error {
 				}
 				_o.Annotations = append(_o.Annotations, nonNilItem)
 			}
+		case "deprecated":
+			_o.Deprecated, err = msgpack.DecodeNillable[Deprecated](decoder)
 		default:
 			err = decoder.Skip()
 		}
@@ -925,7 +963,7 @@ func (o *Operation) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(6)
+	encoder.WriteMapSize(7)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
 	encoder.WriteString("description")
@@ -948,6 +986,8 @@ func (o *Operation) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Annotations {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("deprecated")
+	o.Deprecated.Encode(encoder)
 
 	return nil
 }
@@ -989,6 +1029,8 @@ func (o *Parameter) Decode(decoder msgpack.Reader) error {
 				}
 				_o.Annotations = append(_o.Annotations, nonNilItem)
 			}
+		case "deprecated":
+			_o.Deprecated, err = msgpack.DecodeNillable[Deprecated](decoder)
 		default:
 			err = decoder.Skip()
 		}
@@ -1006,7 +1048,7 @@ func (o *Parameter) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(5)
+	encoder.WriteMapSize(6)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
 	encoder.WriteString("description")
@@ -1024,6 +1066,8 @@ func (o *Parameter) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Annotations {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("deprecated")
+	o.Deprecated.Encode(encoder)
 
 	return nil
 }
@@ -1065,6 +1109,8 @@ func (o *Field) Decode(decoder msgpack.Reader) error {
 				}
 				_o.Annotations = append(_o.Annotations, nonNilItem)
 			}
+		case "deprecated":
+			_o.Deprecated, err = msgpack.DecodeNillable[Deprecated](decoder)
 		default:
 			err = decoder.Skip()
 		}
@@ -1082,7 +1128,7 @@ func (o *Field) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(5)
+	encoder.WriteMapSize(6)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
 	encoder.WriteString("description")
@@ -1100,6 +1146,8 @@ func (o *Field) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Annotations {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("deprecated")
+	o.Deprecated.Encode(encoder)
 
 	return nil
 }
@@ -1152,6 +1200,8 @@ func (o *Union) Decode(decoder msgpack.Reader) error {
 				}
 				_o.Annotations = append(_o.Annotations, nonNilItem)
 			}
+		case "deprecated":
+			_o.Deprecated, err = msgpack.DecodeNillable[Deprecated](decoder)
 		default:
 			err = decoder.Skip()
 		}
@@ -1169,7 +1219,7 @@ func (o *Union) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(4)
+	encoder.WriteMapSize(5)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
 	encoder.WriteString("description")
@@ -1184,6 +1234,8 @@ func (o *Union) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Annotations {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("deprecated")
+	o.Deprecated.Encode(encoder)
 
 	return nil
 }
@@ -1300,6 +1352,8 @@ func (o *Enum) Decode(decoder msgpack.Reader) error {
 				}
 				_o.Annotations = append(_o.Annotations, nonNilItem)
 			}
+		case "deprecated":
+			_o.Deprecated, err = msgpack.DecodeNillable[Deprecated](decoder)
 		default:
 			err = decoder.Skip()
 		}
@@ -1317,7 +1371,7 @@ func (o *Enum) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(4)
+	encoder.WriteMapSize(5)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
 	encoder.WriteString("description")
@@ -1332,6 +1386,8 @@ func (o *Enum) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Annotations {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("deprecated")
+	o.Deprecated.Encode(encoder)
 
 	return nil
 }
@@ -1373,6 +1429,8 @@ func (o *EnumValue) Decode(decoder msgpack.Reader) error {
 				}
 				_o.Annotations = append(_o.Annotations, nonNilItem)
 			}
+		case "deprecated":
+			_o.Deprecated, err = msgpack.DecodeNillable[Deprecated](decoder)
 		default:
 			err = decoder.Skip()
 		}
@@ -1390,7 +1448,7 @@ func (o *EnumValue) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(5)
+	encoder.WriteMapSize(6)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
 	encoder.WriteString("description")
@@ -1404,6 +1462,52 @@ func (o *EnumValue) Encode(encoder msgpack.Writer) error {
 	for _, v := range o.Annotations {
 		v.Encode(encoder)
 	}
+	encoder.WriteString("deprecated")
+	o.Deprecated.Encode(encoder)
+
+	return nil
+}
+
+func (o *Deprecated) Decode(decoder msgpack.Reader) error {
+	numFields, err := decoder.ReadMapSize()
+	if err != nil {
+		return err
+	}
+
+	var _o Deprecated
+	for numFields > 0 {
+		numFields--
+		field, err := decoder.ReadString()
+		if err != nil {
+			return err
+		}
+		switch field {
+		case "reason":
+			_o.Reason, err = decoder.ReadNillableString()
+		case "since":
+			_o.Since, err = decoder.ReadNillableString()
+		default:
+			err = decoder.Skip()
+		}
+		if err != nil {
+			return err
+		}
+		*o = _o
+	}
+
+	return nil
+}
+
+func (o *Deprecated) Encode(encoder msgpack.Writer) error {
+	if o == nil {
+		encoder.WriteNil()
+		return nil
+	}
+	encoder.WriteMapSize(2)
+	encoder.WriteString("reason")
+	encoder.WriteNillableString(o.Reason)
+	encoder.WriteString("since")
+	encoder.WriteNillableString(o.Since)
 
 	return nil
 }
@@ -1692,8 +1796,12 @@ func (o *Named) Decode(decoder msgpack.Reader) error {
 		switch field {
 		case "kind":
 			_o.Kind, err = convert.Numeric[Kind](decoder.ReadInt32())
+		case "qualifier":
+			_o.Qualifier, err = decoder.ReadNillableString()
 		case "name":
 			_o.Name, err = decoder.ReadString()
+		case "namespace":
+			_o.Namespace, err = decoder.ReadNillableString()
 		default:
 			err = decoder.Skip()
 		}
@@ -1711,11 +1819,15 @@ func (o *Named) Encode(encoder msgpack.Writer) error {
 		encoder.WriteNil()
 		return nil
 	}
-	encoder.WriteMapSize(2)
+	encoder.WriteMapSize(4)
 	encoder.WriteString("kind")
 	encoder.WriteInt32(int32(o.Kind))
+	encoder.WriteString("qualifier")
+	encoder.WriteNillableString(o.Qualifier)
 	encoder.WriteString("name")
 	encoder.WriteString(o.Name)
+	encoder.WriteString("namespace")
+	encoder.WriteNillableString(o.Namespace)
 
 	return nil
 }
@@ -0,0 +1,543 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	apex "github.com/apexlang/apex-go"
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/format"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/source"
+)
+
+// Print returns the Apex source of ns in canonical form. The source is
+// derived from the annotations of the model types in model.axdl, which are
+// read as follows:
+//
+//   - Fields are printed in the order they are declared. @docs fields are
+//     descriptions, printed before the element, and @derived fields are
+//     not printed because the syntax implies them.
+//   - @keyword(k) is printed before each item of a field. With
+//     @delimiters, it is printed once before the items.
+//   - @prefix(p) is printed before a value or each item of a list,
+//     @before(t) and @after(t) before and after a field that is present.
+//   - @suffix(s) joins a value that is present to the next token, as the
+//     qualifier of a name.
+//   - @body(open, close, or) encloses a value. An empty list is printed as
+//     `or`, unless another @body field of the element is present. On a
+//     type, @body encloses its list fields without annotations, printed
+//     after the other fields, or all of its fields when there are none.
+//   - @delimiters separates the items of a list with its first delimiter.
+//   - @quoted strings are printed as string literals, other strings as
+//     names. Strings in unions are literals and enums in unions, the
+//     scalars of TypeRef, are lower case.
+//
+// The annotations only describe the syntax the code generators need, so
+// the rest of it is added by implied.
+func Print(ns *Namespace) ([]byte, error) {
+	g, err := modelGrammar()
+	if err != nil {
+		return nil, err
+	}
+	data, err := ns.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	n, err := parseJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	p := modelPrinter{g: g}
+	if err := p.element("Namespace", n); err != nil {
+		return nil, err
+	}
+	return format.Source(source.NewSource(ns.Name+".apex", []byte(p.b.String())))
+}
+
+// syntaxType is the syntax of a model type.
+type syntaxType struct {
+	keyword string
+	body    *syntaxBody
+	fields  []*syntaxField
+	// enclosed are the fields in the body.
+	enclosed map[*syntaxField]bool
+}
+
+type syntaxBody struct {
+	open, close, or string
+}
+
+// syntaxField is the syntax of a field of a model type.
+type syntaxField struct {
+	name     string
+	typeName string
+	list     bool
+
+	keyword   string
+	prefix    string
+	before    string
+	after     string
+	suffix    string
+	body      *syntaxBody
+	delimiter string
+	quoted    bool
+	docs      bool
+	derived   bool
+}
+
+func (f *syntaxField) annotated() bool {
+	return f.keyword != "" || f.prefix != "" || f.before != "" || f.after != "" ||
+		f.suffix != "" || f.body != nil || f.delimiter != "" || f.quoted || f.docs || f.derived
+}
+
+// grammar is the syntax of the model types.
+type grammar struct {
+	types  map[string]*syntaxType
+	unions map[string]bool
+	enums  map[string]bool
+}
+
+var (
+	grammarOnce sync.Once
+	grammarMain *grammar
+	grammarErr  error
+)
+
+func modelGrammar() (*grammar, error) {
+	grammarOnce.Do(func() {
+		grammarMain, grammarErr = newGrammar(apex.ModelSpec)
+	})
+	return grammarMain, grammarErr
+}
+
+func newGrammar(spec string) (*grammar, error) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource("model.axdl", []byte(spec)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g := grammar{
+		types:  make(map[string]*syntaxType),
+		unions: make(map[string]bool),
+		enums:  make(map[string]bool),
+	}
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.UnionDefinition:
+			g.unions[d.Name.Value] = true
+		case *ast.EnumDefinition:
+			g.enums[d.Name.Value] = true
+		case *ast.TypeDefinition:
+			t := syntaxType{
+				keyword:  stringArgument(d.Annotation("keyword"), "value"),
+				body:     bodyArguments(d.Annotation("body")),
+				enclosed: make(map[*syntaxField]bool),
+			}
+			for _, field := range d.Fields {
+				t.fields = append(t.fields, newSyntaxField(field))
+			}
+			g.types[d.Name.Value] = &t
+		}
+	}
+	if err := g.imply(); err != nil {
+		return nil, err
+	}
+	for _, t := range g.types {
+		if t.body == nil {
+			continue
+		}
+		for _, f := range t.fields {
+			if f.list && !f.annotated() {
+				t.enclosed[f] = true
+			}
+		}
+		if len(t.enclosed) == 0 {
+			for _, f := range t.fields {
+				t.enclosed[f] = true
+			}
+		}
+	}
+	return &g, nil
+}
+
+// imply adds the syntax of the model types that model.axdl does not
+// annotate, as the parser reads it.
+func (g *grammar) imply() error {
+	var err error
+	field := func(typeName, name string) *syntaxField {
+		if t, ok := g.types[typeName]; ok {
+			for _, f := range t.fields {
+				if f.name == name {
+					return f
+				}
+			}
+		}
+		if err == nil {
+			err = errors.New("model: no field " + typeName + "." + name)
+		}
+		return &syntaxField{}
+	}
+	// move prints the field name of a type before the field next.
+	move := func(typeName, name, next string) {
+		t := g.types[typeName]
+		f, n := field(typeName, name), field(typeName, next)
+		fields := make([]*syntaxField, 0, len(t.fields))
+		for _, other := range t.fields {
+			if other == n {
+				fields = append(fields, f)
+			}
+			if other != f {
+				fields = append(fields, other)
+			}
+		}
+		t.fields = fields
+	}
+	body := func(typeName, open, close string) {
+		if t, ok := g.types[typeName]; ok {
+			t.body = &syntaxBody{open: open, close: close}
+		} else if err == nil {
+			err = errors.New("model: no type " + typeName)
+		}
+	}
+
+	if t, ok := g.types["Namespace"]; ok {
+		t.keyword = "namespace"
+	}
+	// Deprecation is read from the @deprecated annotation, imports of all
+	// definitions have no names and the kinds of named types are those of
+	// their definitions.
+	for _, t := range g.types {
+		for _, f := range t.fields {
+			if f.typeName == "Deprecated" {
+				f.derived = true
+			}
+		}
+	}
+	field("Import", "all").derived = true
+	field("Named", "kind").derived = true
+
+	// Operations and directives without parameters have empty ones, and
+	// the annotations of operations are each prefixed like the others.
+	field("Operation", "parameters").body.or = "()"
+	field("Directive", "parameters").body.or = "()"
+	operationAnnotations := field("Operation", "annotations")
+	operationAnnotations.before, operationAnnotations.prefix = "", "@"
+
+	// Annotations precede the members of unions and the display names of
+	// enum values.
+	field("Union", "name").after = ""
+	field("Union", "members").before = "="
+	move("Union", "annotations", "members")
+	move("EnumValue", "annotations", "display")
+	field("EnumValue", "display").quoted = true
+	body("Enum", "{", "}")
+
+	// Required directives are separated like their locations.
+	field("DirectiveRequire", "directive").after = ""
+	field("Directive", "require").delimiter = "|"
+
+	body("List", "[", "]")
+	body("Map", "{", "}")
+	field("Map", "keyType").after = ":"
+	if t, ok := g.types["Stream"]; ok {
+		t.keyword = "stream"
+	}
+	field("Optional", "type").after = "?"
+	body("ListValue", "[", "]")
+	field("ListValue", "values").delimiter = ","
+	body("ObjectValue", "{", "}")
+	field("ObjectValue", "fields").delimiter = ","
+	field("ObjectField", "name").after = ":"
+	return err
+}
+
+func newSyntaxField(field *ast.FieldDefinition) *syntaxField {
+	f := syntaxField{
+		name:    field.Name.Value,
+		keyword: stringArgument(field.Annotation("keyword"), "value"),
+		prefix:  stringArgument(field.Annotation("prefix"), "value"),
+		before:  stringArgument(field.Annotation("before"), "value"),
+		after:   stringArgument(field.Annotation("after"), "value"),
+		suffix:  stringArgument(field.Annotation("suffix"), "value"),
+		body:    bodyArguments(field.Annotation("body")),
+		quoted:  field.Annotation("quoted") != nil,
+		docs:    field.Annotation("docs") != nil,
+		derived: field.Annotation("derived") != nil,
+	}
+	if a := field.Annotation("delimiters"); a != nil && len(a.Arguments) > 0 {
+		if list, ok := a.Arguments[0].Value.(*ast.ListValue); ok && len(list.Values) > 0 {
+			if s, ok := list.Values[0].(*ast.StringValue); ok {
+				f.delimiter = s.Value
+			}
+		}
+	}
+
+	t := field.Type
+	if o, ok := t.(*ast.Optional); ok {
+		t = o.Type
+	}
+	if l, ok := t.(*ast.ListType); ok {
+		f.list = true
+		t = l.Type
+	}
+	if named, ok := t.(*ast.Named); ok {
+		f.typeName = named.Name.Value
+	}
+	return &f
+}
+
+func stringArgument(a *ast.Annotation, name string) string {
+	if a == nil {
+		return ""
+	}
+	if arg := a.Argument(name); arg != nil {
+		if s, ok := arg.Value.(*ast.StringValue); ok {
+			return s.Value
+		}
+	}
+	return ""
+}
+
+func bodyArguments(a *ast.Annotation) *syntaxBody {
+	if a == nil {
+		return nil
+	}
+	return &syntaxBody{
+		open:  stringArgument(a, "open"),
+		close: stringArgument(a, "close"),
+		or:    stringArgument(a, "or"),
+	}
+}
+
+// modelPrinter writes the tokens of a model separated by spaces. The
+// result is formatted by parsing it.
+type modelPrinter struct {
+	g *grammar
+	b strings.Builder
+	// glue joins the next token to the previous one.
+	glue bool
+	// lead is the keyword and prefix of the next value, which follow its
+	// description.
+	lead []string
+}
+
+func (p *modelPrinter) token(s string) {
+	if p.b.Len() > 0 && !p.glue {
+		p.b.WriteByte(' ')
+	}
+	p.b.WriteString(s)
+	p.glue = false
+}
+
+func (p *modelPrinter) prefix(s string) {
+	if s != "" {
+		p.token(s)
+		p.glue = true
+	}
+}
+
+// flushLead prints the keyword and prefix of the current value.
+func (p *modelPrinter) flushLead() {
+	lead := p.lead
+	p.lead = nil
+	if len(lead) == 2 {
+		p.optional(lead[0])
+		p.prefix(lead[1])
+	}
+}
+
+func (p *modelPrinter) optional(s string) {
+	if s != "" {
+		p.token(s)
+	}
+}
+
+func (p *modelPrinter) element(typeName string, n *node) error {
+	t, ok := p.g.types[typeName]
+	if !ok {
+		return errors.New("model: no syntax for type " + typeName)
+	}
+	if n.kind != nodeMap {
+		return errors.New("model: " + typeName + " is not an object")
+	}
+
+	for _, f := range t.fields {
+		if v := n.get(f.name); f.docs && v != nil && v.kind == nodeString {
+			p.token(quote(v.text))
+		}
+	}
+	p.flushLead()
+	p.optional(t.keyword)
+	for _, f := range t.fields {
+		if !t.enclosed[f] {
+			if err := p.field(t, f, n); err != nil {
+				return err
+			}
+		}
+	}
+	if t.body == nil {
+		return nil
+	}
+	p.token(t.body.open)
+	for _, f := range t.fields {
+		if t.enclosed[f] {
+			if err := p.field(t, f, n); err != nil {
+				return err
+			}
+		}
+	}
+	p.token(t.body.close)
+	return nil
+}
+
+func (p *modelPrinter) field(t *syntaxType, f *syntaxField, n *node) error {
+	if f.docs || f.derived {
+		return nil
+	}
+	v := n.get(f.name)
+	if v != nil && v.kind == nodeNull {
+		v = nil
+	}
+	if !f.list {
+		if v == nil {
+			return nil
+		}
+		p.optional(f.before)
+		if f.body != nil {
+			p.token(f.body.open)
+		}
+		p.lead = []string{"", f.prefix}
+		if err := p.value(f.typeName, f.quoted, v); err != nil {
+			return err
+		}
+		if f.body != nil {
+			p.token(f.body.close)
+		}
+		p.optional(f.after)
+		if f.suffix != "" {
+			p.glue = true
+			p.prefix(f.suffix)
+		}
+		return nil
+	}
+
+	var items []*node
+	if v != nil {
+		items = v.values
+	}
+	if len(items) == 0 {
+		if f.body != nil && f.body.or != "" && !p.otherBody(t, f, n) {
+			p.optional(f.before)
+			p.token(f.body.or)
+			p.optional(f.after)
+		}
+		return nil
+	}
+	p.optional(f.before)
+	if f.delimiter != "" {
+		p.optional(f.keyword)
+	}
+	if f.body != nil {
+		p.token(f.body.open)
+	}
+	for i, item := range items {
+		if i > 0 && f.delimiter != "" {
+			p.token(f.delimiter)
+		}
+		keyword := f.keyword
+		if f.delimiter != "" {
+			keyword = ""
+		}
+		p.lead = []string{keyword, f.prefix}
+		if err := p.value(f.typeName, f.quoted, item); err != nil {
+			return err
+		}
+	}
+	if f.body != nil {
+		p.token(f.body.close)
+	}
+	p.optional(f.after)
+	return nil
+}
+
+// otherBody reports whether the element n has a @body field other than f.
+func (p *modelPrinter) otherBody(t *syntaxType, f *syntaxField, n *node) bool {
+	for _, other := range t.fields {
+		if other != f && other.body != nil {
+			if v := n.get(other.name); v != nil && v.kind != nodeNull {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *modelPrinter) value(typeName string, quoted bool, v *node) error {
+	if p.g.types[typeName] != nil {
+		return p.element(typeName, v)
+	}
+	p.flushLead()
+	switch typeName {
+	case "string":
+		if v.kind != nodeString {
+			return errors.New("model: expected a string")
+		}
+		if quoted {
+			p.token(quote(v.text))
+		} else {
+			p.token(v.text)
+		}
+		return nil
+	case "bool", "i8", "i16", "i32", "i64", "u8", "u16", "u32", "u64":
+		p.token(v.text)
+		return nil
+	case "f32", "f64":
+		s := v.text
+		if !strings.ContainsAny(s, ".eE") {
+			s += ".0"
+		}
+		p.token(s)
+		return nil
+	}
+	if p.g.enums[typeName] {
+		p.token(v.text)
+		return nil
+	}
+	if !p.g.unions[typeName] {
+		return errors.New("model: no syntax for type " + typeName)
+	}
+
+	if v.kind != nodeMap || len(v.keys) != 1 {
+		return errors.New("model: " + typeName + " must have one member")
+	}
+	member, value := v.keys[0], v.values[0]
+	switch {
+	case p.g.enums[member]:
+		p.token(strings.ToLower(value.text))
+		return nil
+	case member == "string":
+		return p.value(member, true, value)
+	}
+	return p.value(member, false, value)
+}
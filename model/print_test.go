@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apexlang/apex-go/format"
+	"github.com/apexlang/apex-go/model"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/source"
+)
+
+const printSpec = `"The shop API."
+namespace "shop" @path("/shop")
+
+"A range of values."
+directive @range(min: f64, max: f64 = 100.0) on FIELD | PARAMETER require @entity TYPE | INTERFACE
+
+directive @entity() on TYPE
+
+alias ID = string @format("uuid")
+
+func ping(): string
+
+func echo[value: string]: string
+
+interface Store @service {
+  "Gets an item."
+  get(id: ID, expand: bool = false @deprecated("always expanded")): Item?
+  list(tags: [string], limit: u32 = 10): stream Item
+  clear() @deprecated(reason: "unsafe", since: "1.2")
+}
+
+type Item @entity {
+  "The identifier."
+  id: ID
+  price: f64 @range(min: 0.0)
+  tags: [string]?
+  attributes: {string: [i64]}
+  owner: Owner?
+  meta: {string: any} = {a: [1, 2], b: {c: "d"}}
+}
+
+type Owner @entity {
+  name: string
+}
+
+union Owned @deprecated = Item | Owner
+
+enum Status @flags {
+  active = 0 as "Active"
+  closed = 1 @deprecated
+  pending = 2 @label("p") as "Pending"
+}
+`
+
+var printImports = map[string]string{
+	"common.apex": `type Common { name: string }`,
+	"money.apex":  `type Money { amount: f64 } enum Currency { USD = 0 }`,
+}
+
+// convertSource parses and converts a specification importing
+// printImports.
+func convertSource(t *testing.T, name, body string) *model.Namespace {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(name, []byte(body)),
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				return printImports[location], nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ns, errs := model.Convert(doc)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	return ns
+}
+
+func TestPrint(t *testing.T) {
+	ns := convertSource(t, "shop.apex", printSpec)
+	data, err := model.Print(ns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	printed := convertSource(t, "printed.apex", string(data))
+	if !reflect.DeepEqual(printed, ns) {
+		t.Errorf("printed model differs:\n%s", data)
+	}
+	again, err := model.Print(printed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("second print:\n%s\nwant:\n%s", again, data)
+	}
+	formatted, err := format.Source(source.NewSource("printed.apex", data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(formatted) != string(data) {
+		t.Errorf("print is not formatted:\n%s\nwant:\n%s", data, formatted)
+	}
+}
+
+func TestPrintImports(t *testing.T) {
+	ns := convertSource(t, "shop.apex", `namespace "shop"
+
+import * from "common.apex"
+import { Money, Currency as Cur } from "money.apex"
+`)
+	data, err := model.Print(ns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The model does not distinguish the definitions of imports, so they
+	// are printed too.
+	want := `namespace "shop"
+
+import * from "common.apex"
+import { Money, Currency as Cur } from "money.apex"
+
+type Common {
+  name: string
+}
+
+type Money {
+  amount: f64
+}
+
+enum Cur {
+  USD = 0
+}
+`
+	if string(data) != want {
+		t.Errorf("print:\n%s\nwant:\n%s", data, want)
+	}
+}
@@ -18,6 +18,7 @@ package model
 
 import (
 	"context"
+	stderrs "errors"
 
 	"github.com/apexlang/apex-go/errors"
 	"github.com/apexlang/apex-go/location"
@@ -35,32 +36,46 @@ func NewParser(resolver Resolver) Parser {
 	}
 }
 
+// NewFormatConverter returns a FormatConverter resolving imports with
+// resolver.
+func NewFormatConverter(resolver Resolver) FormatConverter {
+	return &parserImpl{
+		resolver: resolver,
+	}
+}
+
 func (p *parserImpl) Parse(ctx context.Context, source string) (*ParserResult, error) {
-	doc, err := parser.Parse(parser.ParseParams{
-		Source: source,
-		Options: parser.ParseOptions{
-			NoSource: true,
-			Resolver: func(location, from string) (string, error) {
-				return p.resolver.Resolve(ctx, location, from)
-			},
-		},
-	})
+	ns, errs, err := p.parse(ctx, source)
 	if err != nil {
 		return nil, err
 	}
-
-	errs := rules.Validate(doc, rules.Rules...)
 	if len(errs) > 0 {
 		return &ParserResult{
 			Errors: convertErrors(errs),
 		}, nil
 	}
 
-	ns, errs := Convert(doc)
+	return &ParserResult{
+		Namespace: ns,
+	}, nil
+}
+
+func (p *parserImpl) Convert(ctx context.Context, source string, format Format) ([]byte, error) {
+	ns, errs, err := p.parse(ctx, source)
+	if err != nil {
+		return nil, err
+	}
 	if len(errs) > 0 {
-		return &ParserResult{
-			Errors: convertErrors(errs),
-		}, nil
+		return nil, stderrs.Join(errs...)
+	}
+
+	return MarshalNamespace(ns, format)
+}
+
+func (p *parserImpl) Load(ctx context.Context, data []byte, format Format) (*ParserResult, error) {
+	ns, err := UnmarshalNamespace(data, format)
+	if err != nil {
+		return nil, err
 	}
 
 	return &ParserResult{
@@ -68,6 +83,35 @@ func (p *parserImpl) Parse(ctx context.Context, source string) (*ParserResult, e
 	}, nil
 }
 
+// parse returns the namespace converted from source, or the errors that
+// prevent converting it.
+func (p *parserImpl) parse(ctx context.Context, source string) (*Namespace, []error, error) {
+	doc, err := parser.ParseContext(ctx, parser.ParseParams{
+		Source: source,
+		// The zero Limits bound the untrusted source and its imports
+		// with the default limits.
+		Options: parser.ParseOptions{
+			NoSource:        true,
+			ContextResolver: p.resolver,
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	errs := rules.Validate(doc, rules.Rules...)
+	if len(errs) > 0 {
+		return nil, errs, nil
+	}
+
+	ns, errs := Convert(doc)
+	if len(errs) > 0 {
+		return nil, errs, nil
+	}
+
+	return ns, nil, nil
+}
+
 func convertErrors(errs []error) []Error {
 	e := make([]Error, len(errs))
 	for i, err := range errs {
@@ -0,0 +1,981 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/CosmWasm/tinyjson/jwriter"
+)
+
+// YAML is converted to and from the JSON written and read by the generated
+// tinyjson marshalers, which keeps YAML libraries and their reflection out
+// of wasm builds. The reader accepts the YAML this package writes and the
+// common block and flow styles: mappings, sequences, plain and quoted
+// scalars, literal (|) and folded (>) block scalars and comments. Anchors,
+// tags and multiple documents are not supported.
+
+// nodeKind is the kind of a value in a JSON or YAML document.
+type nodeKind int
+
+const (
+	nodeNull nodeKind = iota
+	nodeBool
+	nodeNumber
+	nodeString
+	nodeList
+	nodeMap
+)
+
+// node is a value in a JSON or YAML document. Booleans and numbers keep
+// their text.
+type node struct {
+	kind   nodeKind
+	text   string
+	keys   []string
+	values []*node
+}
+
+// jsonToYAML converts a JSON document to YAML in block style.
+func jsonToYAML(data []byte) ([]byte, error) {
+	n, err := parseJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	writeYAML(&b, n, 0)
+	return []byte(b.String()), nil
+}
+
+// yamlToJSON converts a YAML document to JSON.
+func yamlToJSON(data []byte) ([]byte, error) {
+	p, err := newYAMLParser(data)
+	if err != nil {
+		return nil, err
+	}
+	n, err := p.document()
+	if err != nil {
+		return nil, err
+	}
+	w := jwriter.Writer{}
+	writeJSON(&w, n)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// get returns the value of key in a map, or nil.
+func (n *node) get(key string) *node {
+	for i, k := range n.keys {
+		if k == key {
+			return n.values[i]
+		}
+	}
+	return nil
+}
+
+// parseJSON reads a JSON document into nodes.
+func parseJSON(data []byte) (*node, error) {
+	p := jsonParser{data: data}
+	n, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.data) {
+		return nil, p.errorf("unexpected %q after value", p.data[p.pos])
+	}
+	return n, nil
+}
+
+// jsonParser reads JSON into nodes.
+type jsonParser struct {
+	data []byte
+	pos  int
+}
+
+func (p *jsonParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("json: offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *jsonParser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *jsonParser) value() (*node, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, p.errorf("unexpected end of input")
+	}
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		p.pos++
+		n := &node{kind: nodeMap}
+		for {
+			p.skipSpace()
+			if p.pos < len(p.data) && p.data[p.pos] == '}' && len(n.keys) == 0 {
+				p.pos++
+				return n, nil
+			}
+			key, err := p.string()
+			if err != nil {
+				return nil, err
+			}
+			if p.skipSpace(); p.pos >= len(p.data) || p.data[p.pos] != ':' {
+				return nil, p.errorf("expected ':'")
+			}
+			p.pos++
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			n.keys = append(n.keys, key)
+			n.values = append(n.values, v)
+			if done, err := p.next('}'); done || err != nil {
+				return n, err
+			}
+		}
+	case c == '[':
+		p.pos++
+		n := &node{kind: nodeList}
+		for {
+			p.skipSpace()
+			if p.pos < len(p.data) && p.data[p.pos] == ']' && len(n.values) == 0 {
+				p.pos++
+				return n, nil
+			}
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			n.values = append(n.values, v)
+			if done, err := p.next(']'); done || err != nil {
+				return n, err
+			}
+		}
+	case c == '"':
+		s, err := p.string()
+		return &node{kind: nodeString, text: s}, err
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := p.pos
+		for p.pos < len(p.data) && strings.IndexByte("+-.eE0123456789", p.data[p.pos]) >= 0 {
+			p.pos++
+		}
+		return &node{kind: nodeNumber, text: string(p.data[start:p.pos])}, nil
+	}
+	for _, word := range []string{"true", "false", "null"} {
+		if strings.HasPrefix(string(p.data[p.pos:]), word) {
+			p.pos += len(word)
+			if word == "null" {
+				return &node{kind: nodeNull}, nil
+			}
+			return &node{kind: nodeBool, text: word}, nil
+		}
+	}
+	return nil, p.errorf("unexpected %q", p.data[p.pos])
+}
+
+// next consumes the comma between elements or the closing delimiter, and
+// reports whether it was the closing delimiter.
+func (p *jsonParser) next(closing byte) (bool, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return false, p.errorf("unexpected end of input")
+	}
+	switch p.data[p.pos] {
+	case ',':
+		p.pos++
+		return false, nil
+	case closing:
+		p.pos++
+		return true, nil
+	}
+	return false, p.errorf("expected ',' or %q", closing)
+}
+
+func (p *jsonParser) string() (string, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) || p.data[p.pos] != '"' {
+		return "", p.errorf("expected string")
+	}
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch {
+		case c == '"':
+			return b.String(), nil
+		case c != '\\':
+			b.WriteByte(c)
+		case p.pos >= len(p.data):
+			return "", p.errorf("unterminated string")
+		default:
+			e := p.data[p.pos]
+			p.pos++
+			switch e {
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				r, err := p.unicode()
+				if err != nil {
+					return "", err
+				}
+				b.WriteRune(r)
+			default:
+				b.WriteByte(e)
+			}
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// unicode reads the hex digits of a \u escape, combining surrogate pairs.
+func (p *jsonParser) unicode() (rune, error) {
+	hex := func() (rune, error) {
+		if p.pos+4 > len(p.data) {
+			return 0, p.errorf("invalid unicode escape")
+		}
+		v, err := strconv.ParseUint(string(p.data[p.pos:p.pos+4]), 16, 16)
+		if err != nil {
+			return 0, p.errorf("invalid unicode escape")
+		}
+		p.pos += 4
+		return rune(v), nil
+	}
+	r, err := hex()
+	if err != nil || r < 0xd800 || r >= 0xdc00 {
+		return r, err
+	}
+	if p.pos+2 <= len(p.data) && p.data[p.pos] == '\\' && p.data[p.pos+1] == 'u' {
+		p.pos += 2
+		low, err := hex()
+		if err != nil {
+			return 0, err
+		}
+		return (r-0xd800)<<10 + (low - 0xdc00) + 0x10000, nil
+	}
+	return utf8.RuneError, nil
+}
+
+// writeYAML writes n as the value of a mapping key or sequence item at
+// indent. Collections start on a new line.
+func writeYAML(b *strings.Builder, n *node, indent int) {
+	prefix := strings.Repeat("  ", indent)
+	switch n.kind {
+	case nodeMap:
+		if len(n.keys) == 0 {
+			b.WriteString("{}\n")
+			return
+		}
+		for i, key := range n.keys {
+			b.WriteString(prefix)
+			b.WriteString(yamlScalar(key))
+			b.WriteString(":")
+			writeYAMLValue(b, n.values[i], indent+1)
+		}
+	case nodeList:
+		if len(n.values) == 0 {
+			b.WriteString("[]\n")
+			return
+		}
+		for _, v := range n.values {
+			b.WriteString(prefix)
+			b.WriteString("-")
+			if v.kind == nodeMap && len(v.keys) > 0 {
+				// The first key follows the dash.
+				var item strings.Builder
+				writeYAML(&item, v, indent+1)
+				b.WriteString(" ")
+				b.WriteString(strings.TrimPrefix(item.String(), prefix+"  "))
+				continue
+			}
+			writeYAMLValue(b, v, indent+1)
+		}
+	default:
+		b.WriteString(prefix)
+		b.WriteString(yamlValue(n))
+		b.WriteString("\n")
+	}
+}
+
+// writeYAMLValue writes n after a key or dash. Multiline strings are
+// written as literal block scalars when they read back the same.
+func writeYAMLValue(b *strings.Builder, n *node, indent int) {
+	if (n.kind == nodeMap && len(n.keys) > 0) || (n.kind == nodeList && len(n.values) > 0) {
+		b.WriteString("\n")
+		writeYAML(b, n, indent)
+		return
+	}
+	if n.kind == nodeString && literalScalar(n.text) {
+		text := n.text
+		if strings.HasSuffix(text, "\n") {
+			b.WriteString(" |\n")
+			text = text[:len(text)-1]
+		} else {
+			b.WriteString(" |-\n")
+		}
+		prefix := strings.Repeat("  ", indent)
+		for _, line := range strings.Split(text, "\n") {
+			if line != "" {
+				b.WriteString(prefix)
+				b.WriteString(line)
+			}
+			b.WriteString("\n")
+		}
+		return
+	}
+	b.WriteString(" ")
+	writeYAML(b, n, 0)
+}
+
+func yamlValue(n *node) string {
+	switch n.kind {
+	case nodeNull:
+		return "null"
+	case nodeString:
+		return yamlScalar(n.text)
+	}
+	return n.text
+}
+
+// yamlScalar returns s plain when it reads back as the same string, and
+// double quoted otherwise.
+func yamlScalar(s string) string {
+	if s == "" || plainScalar(s).kind != nodeString || s != strings.TrimSpace(s) ||
+		strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return quote(s)
+	}
+	for _, r := range s {
+		if r < ' ' || r == 0x7f {
+			return quote(s)
+		}
+	}
+	return s
+}
+
+// literalScalar reports whether s has several lines and can be written
+// as a literal block scalar.
+func literalScalar(s string) bool {
+	text := strings.TrimSuffix(s, "\n")
+	if !strings.Contains(text, "\n") || strings.HasSuffix(text, "\n") ||
+		strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\n") {
+		return false
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasSuffix(line, " ") {
+			return false
+		}
+		for _, r := range line {
+			if r < ' ' || r == 0x7f {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// quote double quotes s with JSON escapes, which are also valid in YAML
+// and Apex.
+func quote(s string) string {
+	w := jwriter.Writer{}
+	w.String(s)
+	return string(w.Buffer.BuildBytes())
+}
+
+func writeJSON(w *jwriter.Writer, n *node) {
+	switch n.kind {
+	case nodeNull:
+		w.RawString("null")
+	case nodeBool, nodeNumber:
+		w.RawString(n.text)
+	case nodeString:
+		w.String(n.text)
+	case nodeList:
+		w.RawByte('[')
+		for i, v := range n.values {
+			if i > 0 {
+				w.RawByte(',')
+			}
+			writeJSON(w, v)
+		}
+		w.RawByte(']')
+	case nodeMap:
+		w.RawByte('{')
+		for i, key := range n.keys {
+			if i > 0 {
+				w.RawByte(',')
+			}
+			w.String(key)
+			w.RawByte(':')
+			writeJSON(w, n.values[i])
+		}
+		w.RawByte('}')
+	}
+}
+
+// plainScalar resolves the type of an unquoted scalar.
+func plainScalar(s string) *node {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return &node{kind: nodeNull}
+	case "true", "True", "TRUE":
+		return &node{kind: nodeBool, text: "true"}
+	case "false", "False", "FALSE":
+		return &node{kind: nodeBool, text: "false"}
+	}
+	if isNumber(s) {
+		return &node{kind: nodeNumber, text: strings.TrimPrefix(s, "+")}
+	}
+	return &node{kind: nodeString, text: s}
+}
+
+// isNumber reports whether s is a decimal integer or float that is also
+// valid JSON once a leading plus sign is removed.
+func isNumber(s string) bool {
+	s = strings.TrimPrefix(s, "+")
+	s = strings.TrimPrefix(s, "-")
+	digits := func() int {
+		n := 0
+		for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+			n++
+		}
+		return n
+	}
+	n := digits()
+	if n == 0 || (n > 1 && s[0] == '0') {
+		return false
+	}
+	s = s[n:]
+	if strings.HasPrefix(s, ".") {
+		s = s[1:]
+		if digits() == 0 {
+			return false
+		}
+		s = s[digits():]
+	}
+	if len(s) > 0 && (s[0] == 'e' || s[0] == 'E') {
+		s = strings.TrimLeft(s[1:], "+-")
+		if digits() == 0 {
+			return false
+		}
+		s = s[digits():]
+	}
+	return s == ""
+}
+
+// yamlLine is a line of a YAML document without its indentation and
+// trailing comment.
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+	// raw is the line as written, for block scalars.
+	raw string
+}
+
+// yamlParser reads YAML into nodes from its significant lines.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func newYAMLParser(data []byte) (*yamlParser, error) {
+	p := yamlParser{}
+	started := false
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		text := strings.TrimLeft(raw, " ")
+		indent := len(raw) - len(text)
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed for indentation", i+1)
+		}
+		text = strings.TrimSpace(stripComment(text))
+		if text == "---" && !started {
+			continue
+		}
+		if text == "..." || text == "---" {
+			break
+		}
+		started = started || text != ""
+		p.lines = append(p.lines, yamlLine{number: i + 1, indent: indent, text: text, raw: raw})
+	}
+	return &p, nil
+}
+
+// stripComment removes a comment that is outside quotes.
+func stripComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				if quote == '\'' && i+1 < len(s) && s[i+1] == '\'' {
+					i++
+				} else {
+					quote = 0
+				}
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.IndexByte(" :[{,-", s[i-1]) >= 0 {
+				quote = c
+			}
+		case c == '#' && (i == 0 || s[i-1] == ' '):
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func (p *yamlParser) errorf(format string, args ...any) error {
+	line := 0
+	if p.pos < len(p.lines) {
+		line = p.lines[p.pos].number
+	} else if len(p.lines) > 0 {
+		line = p.lines[len(p.lines)-1].number
+	}
+	return fmt.Errorf("yaml: line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// skipBlank moves past empty lines.
+func (p *yamlParser) skipBlank() {
+	for p.pos < len(p.lines) && p.lines[p.pos].text == "" {
+		p.pos++
+	}
+}
+
+func (p *yamlParser) document() (*node, error) {
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return &node{kind: nodeNull}, nil
+	}
+	n, err := p.block(p.lines[p.pos].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.skipBlank(); p.pos < len(p.lines) {
+		return nil, p.errorf("unexpected %q", p.lines[p.pos].text)
+	}
+	return n, nil
+}
+
+// block reads the collection or scalar starting at the current line,
+// which is indented by indent.
+func (p *yamlParser) block(indent int) (*node, error) {
+	line := p.lines[p.pos]
+	switch {
+	case line.text == "-" || strings.HasPrefix(line.text, "- "):
+		return p.sequence(indent)
+	case mappingKey(line.text) >= 0:
+		return p.mapping(indent)
+	}
+	p.pos++
+	return p.flow(line.text)
+}
+
+func (p *yamlParser) sequence(indent int) (*node, error) {
+	n := &node{kind: nodeList}
+	for p.skipBlank(); p.pos < len(p.lines); p.skipBlank() {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf("bad indentation")
+		}
+		if line.text != "-" && !strings.HasPrefix(line.text, "- ") {
+			break
+		}
+		item := strings.TrimLeft(line.text[1:], " ")
+		var v *node
+		var err error
+		if item == "" {
+			p.pos++
+			v, err = p.nested(indent, true)
+		} else if item[0] == '|' || item[0] == '>' {
+			p.pos++
+			v, err = p.blockScalar(indent, item)
+		} else {
+			// The item is parsed as if it started on its own line,
+			// indented past the dash.
+			p.lines[p.pos].indent = indent + len(line.text) - len(item)
+			p.lines[p.pos].text = item
+			v, err = p.value(p.lines[p.pos].indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.values = append(n.values, v)
+	}
+	return n, nil
+}
+
+func (p *yamlParser) mapping(indent int) (*node, error) {
+	n := &node{kind: nodeMap}
+	for p.skipBlank(); p.pos < len(p.lines); p.skipBlank() {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, p.errorf("bad indentation")
+		}
+		i := mappingKey(line.text)
+		if i < 0 {
+			return nil, p.errorf("expected a mapping key in %q", line.text)
+		}
+		key, err := p.flow(strings.TrimSpace(line.text[:i]))
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range n.keys {
+			if k == key.text {
+				return nil, p.errorf("duplicate key %q", key.text)
+			}
+		}
+		rest := strings.TrimSpace(line.text[i+1:])
+		var v *node
+		switch {
+		case rest == "":
+			p.pos++
+			v, err = p.nested(indent, false)
+		case rest[0] == '|' || rest[0] == '>':
+			p.pos++
+			v, err = p.blockScalar(indent, rest)
+		default:
+			p.pos++
+			v, err = p.flowLines(rest, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.keys = append(n.keys, key.text)
+		n.values = append(n.values, v)
+	}
+	return n, nil
+}
+
+// value reads the scalar or collection at the current line, which is the
+// content of a sequence item.
+func (p *yamlParser) value(indent int) (*node, error) {
+	line := p.lines[p.pos]
+	if line.text == "-" || strings.HasPrefix(line.text, "- ") || mappingKey(line.text) >= 0 {
+		return p.block(indent)
+	}
+	p.pos++
+	return p.flowLines(line.text, indent)
+}
+
+// nested reads the value of a key or dash with nothing after it: a block
+// indented past parent, or null. A sequence may be at the same indentation
+// as the key that holds it.
+func (p *yamlParser) nested(parent int, item bool) (*node, error) {
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return &node{kind: nodeNull}, nil
+	}
+	line := p.lines[p.pos]
+	sequence := line.text == "-" || strings.HasPrefix(line.text, "- ")
+	if line.indent > parent || (!item && line.indent == parent && sequence) {
+		return p.block(line.indent)
+	}
+	return &node{kind: nodeNull}, nil
+}
+
+// flowLines reads a flow value. Plain scalars continue on more indented
+// lines, flow collections and quoted scalars until they are closed.
+func (p *yamlParser) flowLines(text string, indent int) (*node, error) {
+	for p.pos < len(p.lines) {
+		next := p.lines[p.pos]
+		if !unterminated(text) &&
+			(next.text == "" || next.indent <= indent || strings.IndexByte("\"'[{", text[0]) >= 0) {
+			break
+		}
+		text += " " + next.text
+		p.pos++
+	}
+	return p.flow(text)
+}
+
+// unterminated reports whether a flow collection or quoted scalar in text
+// is not closed yet.
+func unterminated(text string) bool {
+	f := flowParser{text: text}
+	_, err := f.value()
+	return err == errFlowEnd
+}
+
+// blockScalar reads the lines of a literal (|) or folded (>) scalar.
+func (p *yamlParser) blockScalar(parent int, header string) (*node, error) {
+	folded := header[0] == '>'
+	chomp := byte(0)
+	if len(header) > 1 {
+		chomp = header[1]
+	}
+	var lines []string
+	indent := -1
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		trimmed := strings.TrimLeft(line.raw, " ")
+		if trimmed == "" {
+			lines = append(lines, "")
+			p.pos++
+			continue
+		}
+		if indent < 0 {
+			indent = len(line.raw) - len(trimmed)
+		}
+		if indent <= parent || len(line.raw)-len(trimmed) < indent {
+			break
+		}
+		lines = append(lines, line.raw[indent:])
+		p.pos++
+	}
+	// Trailing blank lines belong to the chomping, or to what follows.
+	end := len(lines)
+	for end > 0 && lines[end-1] == "" {
+		end--
+	}
+	p.pos -= len(lines) - end
+	lines = lines[:end]
+
+	var b strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			prev := lines[i-1]
+			switch {
+			case !folded:
+				b.WriteByte('\n')
+			case l != "" && prev != "" && !strings.HasPrefix(l, " ") && !strings.HasPrefix(prev, " "):
+				b.WriteByte(' ')
+			case l == "" && prev != "":
+				// The line break before empty lines is folded away.
+			default:
+				b.WriteByte('\n')
+			}
+		}
+		b.WriteString(l)
+	}
+	s := b.String()
+	if chomp != '-' && len(lines) > 0 {
+		s += "\n"
+	}
+	return &node{kind: nodeString, text: s}, nil
+}
+
+// mappingKey returns the index of the colon ending a mapping key in text,
+// or -1.
+func mappingKey(text string) int {
+	if text == "" || text[0] == '[' || text[0] == '{' {
+		return -1
+	}
+	if text[0] == '"' || text[0] == '\'' {
+		f := flowParser{text: text}
+		if _, err := f.scalar(); err != nil {
+			return -1
+		}
+		if rest := text[f.pos:]; strings.HasPrefix(strings.TrimLeft(rest, " "), ":") {
+			return f.pos + strings.IndexByte(rest, ':')
+		}
+		return -1
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *yamlParser) flow(text string) (*node, error) {
+	f := flowParser{text: text}
+	n, err := f.value()
+	if err == nil {
+		if f.skipSpace(); f.pos < len(f.text) {
+			err = fmt.Errorf("unexpected %q", f.text[f.pos:])
+		}
+	}
+	if err == errFlowEnd {
+		err = errors.New("unexpected end of value")
+	}
+	if err != nil {
+		return nil, p.errorf("%v", err)
+	}
+	return n, nil
+}
+
+var errFlowEnd = errors.New("unterminated flow value")
+
+// flowParser reads a flow scalar or collection from a single line.
+type flowParser struct {
+	text string
+	pos  int
+	// depth is the number of enclosing flow collections.
+	depth int
+}
+
+func (f *flowParser) skipSpace() {
+	for f.pos < len(f.text) && f.text[f.pos] == ' ' {
+		f.pos++
+	}
+}
+
+func (f *flowParser) value() (*node, error) {
+	f.skipSpace()
+	if f.pos >= len(f.text) {
+		if f.depth > 0 {
+			return nil, errFlowEnd
+		}
+		return &node{kind: nodeNull}, nil
+	}
+	switch f.text[f.pos] {
+	case '[':
+		f.pos++
+		f.depth++
+		n := &node{kind: nodeList}
+		for {
+			if f.skipSpace(); f.pos < len(f.text) && f.text[f.pos] == ']' {
+				f.pos++
+				f.depth--
+				return n, nil
+			}
+			v, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			n.values = append(n.values, v)
+			if err := f.separator(']'); err != nil {
+				return nil, err
+			}
+		}
+	case '{':
+		f.pos++
+		f.depth++
+		n := &node{kind: nodeMap}
+		for {
+			if f.skipSpace(); f.pos < len(f.text) && f.text[f.pos] == '}' {
+				f.pos++
+				f.depth--
+				return n, nil
+			}
+			key, err := f.scalar()
+			if err != nil {
+				return nil, err
+			}
+			if f.skipSpace(); f.pos >= len(f.text) {
+				return nil, errFlowEnd
+			}
+			if f.text[f.pos] != ':' {
+				return nil, fmt.Errorf("expected ':' after %q", key.text)
+			}
+			f.pos++
+			v, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			n.keys = append(n.keys, key.text)
+			n.values = append(n.values, v)
+			if err := f.separator('}'); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return f.scalar()
+}
+
+// separator consumes a comma, or the closing delimiter which is left for
+// the caller.
+func (f *flowParser) separator(closing byte) error {
+	if f.skipSpace(); f.pos >= len(f.text) {
+		return errFlowEnd
+	}
+	switch f.text[f.pos] {
+	case ',':
+		f.pos++
+		return nil
+	case closing:
+		return nil
+	}
+	return fmt.Errorf("expected ',' or %q", closing)
+}
+
+func (f *flowParser) scalar() (*node, error) {
+	f.skipSpace()
+	if f.pos >= len(f.text) {
+		return nil, errFlowEnd
+	}
+	switch f.text[f.pos] {
+	case '"':
+		// Double quoted YAML uses the JSON escapes, and a few more that
+		// are not written here.
+		p := jsonParser{data: []byte(f.text), pos: f.pos}
+		s, err := p.string()
+		if err != nil {
+			return nil, errFlowEnd
+		}
+		f.pos = p.pos
+		return &node{kind: nodeString, text: s}, nil
+	case '\'':
+		var b strings.Builder
+		for i := f.pos + 1; i < len(f.text); i++ {
+			if f.text[i] != '\'' {
+				b.WriteByte(f.text[i])
+				continue
+			}
+			if i+1 < len(f.text) && f.text[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			f.pos = i + 1
+			return &node{kind: nodeString, text: b.String()}, nil
+		}
+		return nil, errFlowEnd
+	}
+
+	start := f.pos
+	for f.pos < len(f.text) {
+		c := f.text[f.pos]
+		if c == ':' && (f.pos+1 == len(f.text) || f.text[f.pos+1] == ' ' || f.depth > 0) {
+			break
+		}
+		if f.depth > 0 && strings.IndexByte(",[]{}", c) >= 0 {
+			break
+		}
+		f.pos++
+	}
+	return plainScalar(strings.TrimSpace(f.text[start:f.pos])), nil
+}
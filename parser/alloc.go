@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/kinds"
+	"github.com/apexlang/apex-go/source"
+)
+
+// chunkSize is the number of nodes of one type that are allocated at once.
+const chunkSize = 128
+
+// allocator hands out the locations, names and named types of a parse,
+// which are most of its nodes, from chunks instead of allocating each one.
+// A chunk stays in memory while any of its nodes is referenced.
+type allocator struct {
+	locations []ast.Location
+	names     []ast.Name
+	named     []ast.Named
+}
+
+func (a *allocator) location(start, end uint, src *source.Source) *ast.Location {
+	if len(a.locations) == 0 {
+		a.locations = make([]ast.Location, chunkSize)
+	}
+	l := &a.locations[0]
+	a.locations = a.locations[1:]
+	l.Start, l.End, l.Source = start, end, src
+	return l
+}
+
+func (a *allocator) name(loc *ast.Location, value string) *ast.Name {
+	if len(a.names) == 0 {
+		a.names = make([]ast.Name, chunkSize)
+	}
+	n := &a.names[0]
+	a.names = a.names[1:]
+	n.Kind, n.Loc, n.Value = kinds.Name, loc, value
+	return n
+}
+
+func (a *allocator) namedType(loc *ast.Location, namespace, name *ast.Name) *ast.Named {
+	if len(a.named) == 0 {
+		a.named = make([]ast.Named, chunkSize)
+	}
+	n := &a.named[0]
+	a.named = a.named[1:]
+	n.Kind, n.Loc, n.Namespace, n.Name = kinds.Named, loc, namespace, name
+	return n
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/source"
+)
+
+// benchSpec returns a specification with n definitions of each kind that
+// exercises every token and most of the grammar.
+func benchSpec(n int) []byte {
+	var b bytes.Buffer
+	b.WriteString(`namespace "bench.v1" @info(version: "1.0.0", tags: ["bench", "generated"])
+
+# Directives used by the generated definitions.
+directive @key() on FIELD
+directive @range(min: f64 = 0.0, max: f64 = 1.5e3) on FIELD | PARAMETER
+"""
+Marks an interface as a service.
+  Indented lines keep their relative indentation.
+"""
+directive @service(name: string?, version: i32 = 1) on INTERFACE require @key FIELD
+
+`)
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, `"Status%[1]d is the state of a record.\nIt has \"escapes\" and é."
+enum Status%[1]d {
+  UNKNOWN = 0 as "Unknown"
+  ACTIVE = 1 as "Active" # trailing comment
+  DISABLED = 2
+}
+
+alias ID%[1]d = string @key
+
+"Record%[1]d is a generated type."
+type Record%[1]d {
+  "The id."
+  id: ID%[1]d @key
+  name: string
+  score: f64 = -12.5e-3 @range(min: 0, max: 100)
+  count: i64 = 42
+  tags: [string]
+  labels: {string: string}?
+  status: Status%[1]d = ACTIVE
+  nested: [{string: [Record%[1]d]}]
+  flag: bool = true
+  data: bytes?
+}
+
+union Any%[1]d = Record%[1]d | Status%[1]d | string
+
+"""
+Service%[1]d manages Record%[1]d values.
+"""
+interface Service%[1]d @service(name: "svc%[1]d", version: 2) {
+  get(id: ID%[1]d, verbose: bool = false): Record%[1]d
+  list[filter: {string: string}]: [Record%[1]d]
+  "Deletes a record."
+  delete(id: ID%[1]d): void
+}
+
+func lookup%[1]d(id: ID%[1]d, status: Status%[1]d = UNKNOWN): Any%[1]d
+
+`, i)
+	}
+	return b.Bytes()
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, n := range []int{10, 1000} {
+		body := benchSpec(n)
+		b.Run(fmt.Sprintf("defs=%d", n), func(b *testing.B) {
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, err := parser.Parse(parser.ParseParams{
+					Source: source.NewSource("bench.apex", body),
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// The benchmark specification parses.
+func TestBenchSpec(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource("bench.apex", benchSpec(2))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(doc.Definitions); got != 4+2*6 {
+		t.Errorf("definitions = %d, want %d", got, 4+2*6)
+	}
+}
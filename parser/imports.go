@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/source"
+)
+
+// parseState is shared by the parsers of a source and its imports, which
+// can run concurrently.
+type parseState struct {
+	mu sync.Mutex
+	// resolves and definitions count against the limits of the whole
+	// parse.
+	resolves    int
+	definitions int
+	// sources are the imported sources parsed so far, by name.
+	sources map[string]*parsedSource
+}
+
+// parsedSource is an imported source and its document.
+type parsedSource struct {
+	contents string
+	source   *source.Source
+	doc      *ast.Document
+}
+
+// parsed returns the source named name and its document when it has been
+// parsed with contents, or a nil document.
+func (s *parseState) parsed(name, contents string) (*source.Source, *ast.Document) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.sources[name]; ok && p.contents == contents {
+		return p.source, p.doc
+	}
+	return nil, nil
+}
+
+// store records doc, parsed from src, so that later imports of name with
+// the same contents use it.
+func (s *parseState) store(name, contents string, src *source.Source, doc *ast.Document) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sources == nil {
+		s.sources = make(map[string]*parsedSource)
+	}
+	s.sources[name] = &parsedSource{contents, src, doc}
+}
+
+// importCycle returns the chain of imports, e.g. "a imports b imports a",
+// when imp of the source that parser parses refers to a source named name
+// that imports it, directly or not. The cycle is reported at its first
+// import, in the source returned with its position.
+func importCycle(parser *Parser, imp *ast.ImportDefinition, name string) (string, *source.Source, uint) {
+	chain := []string{name}
+	for p := parser; p != nil; p = p.importer {
+		chain = append(chain, p.Source.Name)
+		if p.Source.Name != name {
+			imp = p.imported
+			continue
+		}
+		for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+			chain[i], chain[j] = chain[j], chain[i]
+		}
+		return strings.Join(chain, " imports "), p.Source, importPosition(imp)
+	}
+	return "", nil, 0
+}
+
+// importPosition returns the position of imp in its source.
+func importPosition(imp *ast.ImportDefinition) uint {
+	if loc := imp.GetLoc(); loc != nil {
+		return loc.Start
+	}
+	return 0
+}
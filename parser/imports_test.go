@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+)
+
+const billingSpec = `namespace "billing"
+
+type Invoice {
+  total: f64
+}
+`
+
+func TestParseImportAlias(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: `namespace "orders"
+
+import * as billing from "billing"
+
+type Order {
+  invoice: billing.Invoice
+  items: [billing.Invoice]
+}
+`,
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				return billingSpec, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The definitions of an aliased import are not brought into scope.
+	if n := len(doc.Definitions); n != 3 {
+		t.Fatalf("got %d definitions, want 3", n)
+	}
+	imp := doc.Definitions[1].(*ast.ImportDefinition)
+	if !imp.All || imp.Alias == nil || imp.Alias.Value != "billing" || len(imp.Names) != 0 {
+		t.Errorf("import = all %t, alias %v, names %v", imp.All, imp.Alias, imp.Names)
+	}
+	if imp.Document == nil {
+		t.Fatal("imported document not set")
+	}
+
+	context := ast.NewContext(doc)
+	fields := doc.Definitions[2].(*ast.TypeDefinition).Fields
+	invoice := fields[0].Type.(*ast.Named)
+	if invoice.Namespace == nil || invoice.Namespace.Value != "billing" || invoice.Name.Value != "Invoice" {
+		t.Errorf("invoice type = %q", invoice.QualifiedName())
+	}
+	if def, ok := context.Lookup(invoice).(*ast.TypeDefinition); !ok || def.Name.Value != "Invoice" {
+		t.Errorf("lookup of %q = %v", invoice.QualifiedName(), def)
+	}
+	item := fields[1].Type.(*ast.ListType).Type.(*ast.Named)
+	if got := item.QualifiedName(); got != "billing.Invoice" {
+		t.Errorf("item type = %q, want billing.Invoice", got)
+	}
+}
+
+func TestParseImportAliasErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{`import * as from "billing"`, `Expected "from", found String`},
+		{`import * as "billing" from "billing"`, `Expected Name, found String`},
+		{`import { Invoice } as billing from "billing"`, `Expected "from", found Name "as"`},
+		{`type Order { invoice: billing. }`, `Invalid qualified name "billing."`},
+	}
+	for _, tt := range tests {
+		_, err := parser.Parse(parser.ParseParams{Source: tt.src})
+		if err == nil {
+			t.Errorf("%s: no error, want %q", tt.src, tt.want)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error %q, want %q", tt.src, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"context"
+	stderrs "errors"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/lexer"
+	"github.com/apexlang/apex-go/query"
+	"github.com/apexlang/apex-go/source"
+)
+
+type ReparseParams struct {
+	// Document is the result of a previous Parse or Reparse. When Reparse
+	// succeeds, the nodes it reuses are updated in place and Document must
+	// not be used afterwards.
+	Document *ast.Document
+	// Edit is applied to the source of Document.
+	Edit    source.Edit
+	Options ParseOptions
+}
+
+// Changes describes the top level definitions that Reparse replaced.
+// Definitions brought in by an import are listed with it.
+type Changes struct {
+	// Removed are the definitions of the previous document that were
+	// reparsed.
+	Removed []ast.Node
+	// Added are the definitions parsed in their place.
+	Added []ast.Node
+	// Full is true when the whole source was parsed again, which happens
+	// when the previous document has no locations or sources.
+	Full bool
+}
+
+// Reparse applies an edit to the source of a previously parsed document and
+// parses only the top level definitions the edit can affect. Unaffected
+// definitions, and the imports they bring in, are reused with their
+// locations moved to the new source.
+//
+// The returned document and error are the same as Parse would return for
+// the edited source, except that unchanged imports are not resolved again.
+func Reparse(p ReparseParams) (*ast.Document, *Changes, error) {
+	return ReparseContext(context.Background(), p)
+}
+
+// ReparseContext reparses like Reparse, resolving imports as ParseContext
+// does.
+func ReparseContext(ctx context.Context, p ReparseParams) (*ast.Document, *Changes, error) {
+	prev := p.Document
+	if prev == nil {
+		return nil, nil, stderrs.New("no document to reparse")
+	}
+	if prev.Loc == nil || prev.Loc.Source == nil || p.Options.NoLocation || p.Options.NoSource {
+		return reparseFull(ctx, p)
+	}
+	old := prev.Loc.Source
+	edit := p.Edit
+	body, err := source.ApplyEdits(old.Body, []source.Edit{edit})
+	if err != nil {
+		return nil, nil, err
+	}
+	src := source.NewSource(old.Name, body)
+	if err := checkSourceSize(src, p.Options); err != nil {
+		return nil, nil, err
+	}
+	delta := len(edit.NewText) - int(edit.End-edit.Start)
+
+	groups, trailing := topLevelGroups(prev, old)
+
+	// The definition before the edit is reparsed too when the edit can
+	// change what follows it, because text added after a definition can
+	// continue it, e.g. `| C` after a union.
+	first := 0
+	for first < len(groups) && groups[first].end < edit.Start {
+		first++
+	}
+	if first > 0 && (first == len(groups) || edit.Start <= firstTokenEnd(old, groups[first].start)) {
+		first--
+	}
+	var position uint
+	if first > 0 {
+		position = groups[first].start
+	}
+
+	parser, err := makeParserAt(src, p.Options, position)
+	if err != nil {
+		return nil, nil, err
+	}
+	parser.ctx = ctx
+	// Definitions reused from before and after the edit count against
+	// the limits like those parsed again.
+	for _, g := range groups[:first] {
+		if err := count(parser, g.nodes[len(g.nodes)-1], g.start); err != nil {
+			return nil, nil, err
+		}
+	}
+	start := prev.Loc.Start
+	if first == 0 {
+		start = parser.Token.Start
+	}
+
+	// Parse until EOF or until a definition starts where an unchanged
+	// definition after the edit used to start, from which point the
+	// source is the same as before.
+	var added []ast.Node
+	next := first
+	resync := -1
+	for parser.Token.Kind != lexer.EOF {
+		for next < len(groups) && (groups[next].start < edit.End || shift(groups[next].start, delta) < parser.Token.Start) {
+			next++
+		}
+		if next < len(groups) && shift(groups[next].start, delta) == parser.Token.Start {
+			resync = next
+			break
+		}
+		def, err := parseTopLevelDefinition(parser)
+		if err != nil {
+			return nil, nil, err
+		}
+		added = append(added, def)
+	}
+	end := parser.Token.End
+	reused := len(groups)
+	if resync >= 0 {
+		end = shift(prev.Loc.End, delta)
+		reused = resync
+	}
+	for _, g := range groups[reused:] {
+		if err := count(parser, g.nodes[len(g.nodes)-1], shift(g.start, delta)); err != nil {
+			return nil, nil, err
+		}
+	}
+	if added, err = resolveImports(parser, added); err != nil {
+		return nil, nil, err
+	}
+
+	changes := Changes{Added: added}
+	definitions := make([]ast.Node, 0, len(prev.Definitions)+len(added))
+	for _, g := range groups[:first] {
+		for _, n := range g.nodes {
+			relocate(n, old, src, 0)
+		}
+		definitions = append(definitions, g.nodes...)
+	}
+	definitions = append(definitions, added...)
+	for _, g := range groups[first:reused] {
+		changes.Removed = append(changes.Removed, g.nodes...)
+	}
+	for _, g := range groups[reused:] {
+		for _, n := range g.nodes {
+			relocate(n, old, src, delta)
+		}
+		definitions = append(definitions, g.nodes...)
+	}
+	definitions = append(definitions, trailing...)
+
+	return ast.NewDocument(
+		ast.NewLocation(start, end, src),
+		definitions,
+	), &changes, nil
+}
+
+func reparseFull(ctx context.Context, p ReparseParams) (*ast.Document, *Changes, error) {
+	prev := p.Document
+	var old *source.Source
+	if prev.Loc != nil {
+		old = prev.Loc.Source
+	}
+	if old == nil {
+		return nil, nil, stderrs.New("document has no source to reparse")
+	}
+	body, err := source.ApplyEdits(old.Body, []source.Edit{p.Edit})
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := ParseContext(ctx, ParseParams{
+		Source:  source.NewSource(old.Name, body),
+		Options: p.Options,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return doc, &Changes{
+		Removed: prev.Definitions,
+		Added:   doc.Definitions,
+		Full:    true,
+	}, nil
+}
+
+// group is a top level definition of a source and the imported
+// definitions that precede it in Document.Definitions.
+type group struct {
+	start, end uint
+	nodes      []ast.Node
+}
+
+// topLevelGroups splits the definitions of doc into groups ending with a
+// definition parsed from s. Definitions left over at the end are returned
+// separately.
+func topLevelGroups(doc *ast.Document, s *source.Source) ([]group, []ast.Node) {
+	// Definitions imported with a new name have the location of the name
+	// in the import, which follows them.
+	var imports []*ast.Location
+	for _, def := range doc.Definitions {
+		if imp, ok := def.(*ast.ImportDefinition); ok && imp.Loc != nil && imp.Loc.Source == s {
+			imports = append(imports, imp.Loc)
+		}
+	}
+	renamed := func(loc *ast.Location) bool {
+		for _, imp := range imports {
+			if imp != loc && imp.Start <= loc.Start && loc.End <= imp.End {
+				return true
+			}
+		}
+		return false
+	}
+
+	var groups []group
+	pending := 0
+	for i, def := range doc.Definitions {
+		loc := def.GetLoc()
+		if loc == nil || loc.Source != s || renamed(loc) {
+			continue
+		}
+		groups = append(groups, group{
+			start: loc.Start,
+			end:   loc.End,
+			nodes: doc.Definitions[pending : i+1],
+		})
+		pending = i + 1
+	}
+	return groups, doc.Definitions[pending:]
+}
+
+// relocate moves the locations in s under node to the source to, shifted
+// by delta. Locations shared by several nodes are moved once, as they no
+// longer refer to s afterwards.
+func relocate(node ast.Node, s, to *source.Source, delta int) {
+	query.Inspect(node, func(path query.Path) bool {
+		loc := path.Node().GetLoc()
+		if loc == nil || loc.Source != s {
+			return true
+		}
+		loc.Start = shift(loc.Start, delta)
+		loc.End = shift(loc.End, delta)
+		loc.Source = to
+		return true
+	})
+}
+
+// firstTokenEnd returns the end of the token at position in s.
+func firstTokenEnd(s *source.Source, position uint) uint {
+	token, err := lexer.Lex(s)(position)
+	if err != nil {
+		return position
+	}
+	return token.End
+}
+
+func shift(position uint, delta int) uint {
+	return uint(int(position) + delta)
+}
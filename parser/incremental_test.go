@@ -0,0 +1,313 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/query"
+	"github.com/apexlang/apex-go/source"
+)
+
+const reparseSpec = `namespace "test"
+
+import { Address } from "address"
+
+type User {
+  name: string
+  home: Address
+}
+
+union Any = User | Address
+
+func get(id: string): User
+`
+
+// resolver returns the address import and counts the resolutions.
+type resolver struct {
+	calls int
+}
+
+func (r *resolver) resolve(location, from string) (string, error) {
+	r.calls++
+	return "type Address { line: string }\n", nil
+}
+
+// dump describes every node of doc with its location, so that documents
+// can be compared.
+func dump(doc *ast.Document) string {
+	var b strings.Builder
+	query.Inspect(doc, func(path query.Path) bool {
+		node := path.Node()
+		fmt.Fprintf(&b, "%s%s", strings.Repeat(" ", len(path)), node.GetKind())
+		if loc := node.GetLoc(); loc != nil {
+			name := "<nil>"
+			if loc.Source != nil {
+				name = loc.Source.Name
+			}
+			fmt.Fprintf(&b, " %s:%d-%d", name, loc.Start, loc.End)
+		}
+		if n, ok := node.(*ast.Name); ok {
+			fmt.Fprintf(&b, " %s", n.Value)
+		}
+		b.WriteByte('\n')
+		return true
+	})
+	return b.String()
+}
+
+// edit returns the edit replacing the n'th occurrence of old in body.
+func edit(t *testing.T, body, old string, n int, newText string) source.Edit {
+	t.Helper()
+	offset := 0
+	for i := 0; i <= n; i++ {
+		next := strings.Index(body[offset:], old)
+		if next < 0 {
+			t.Fatalf("%q not found", old)
+		}
+		offset += next
+		if i < n {
+			offset += len(old)
+		}
+	}
+	return source.Edit{Start: uint(offset), End: uint(offset + len(old)), NewText: newText}
+}
+
+func TestReparse(t *testing.T) {
+	tests := []struct {
+		name    string
+		old     string
+		n       int
+		newText string
+		// removed and added are the names of the changed definitions.
+		removed string
+		added   string
+	}{
+		{
+			name: "field", old: "name: string", newText: "name: i32",
+			removed: "User", added: "User",
+		},
+		{
+			// The union before the edit is reparsed because the edit
+			// continues it.
+			name: "continue a union", old: "\n\nfunc", newText: " | string\n\nfunc",
+			removed: "Any get", added: "Any get",
+		},
+		{
+			name: "continue before a definition", old: "func", newText: "| string\n\nfunc",
+			removed: "Any get", added: "Any get",
+		},
+		{
+			name: "insert a definition", old: "union", newText: "alias ID = string\n\nunion",
+			removed: "User Any", added: "User ID Any",
+		},
+		{
+			name: "delete a definition", old: "union Any = User | Address\n\n", newText: "",
+			removed: "User Any", added: "User",
+		},
+		{
+			name: "namespace", old: `"test"`, newText: `"other"`,
+			removed: "test", added: "other",
+		},
+		{
+			name: "last definition", old: "User", n: 2, newText: "Address",
+			removed: "get", added: "get",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r resolver
+			opts := parser.ParseOptions{Resolver: r.resolve}
+			doc, err := parser.Parse(parser.ParseParams{
+				Source:  source.NewSource("spec.apex", []byte(reparseSpec)),
+				Options: opts,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			e := edit(t, reparseSpec, tt.old, tt.n, tt.newText)
+			body, err := source.ApplyEdits([]byte(reparseSpec), []source.Edit{e})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := parser.Parse(parser.ParseParams{
+				Source:  source.NewSource("spec.apex", body),
+				Options: opts,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			calls := r.calls
+
+			got, changes, err := parser.Reparse(parser.ReparseParams{Document: doc, Edit: e, Options: opts})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dump(got) != dump(want) {
+				t.Errorf("reparsed:\n%s\nparsed:\n%s", dump(got), dump(want))
+			}
+			if string(got.Loc.Source.Body) != string(body) {
+				t.Errorf("source = %q", got.Loc.Source.Body)
+			}
+			if r.calls != calls {
+				t.Errorf("resolved %d imports again", r.calls-calls)
+			}
+			if changes.Full {
+				t.Error("the whole source was parsed again")
+			}
+			if got := names(changes.Removed); got != tt.removed {
+				t.Errorf("removed %q, want %q", got, tt.removed)
+			}
+			if got := names(changes.Added); got != tt.added {
+				t.Errorf("added %q, want %q", got, tt.added)
+			}
+		})
+	}
+}
+
+// Reparsing after any single character edit agrees with parsing the
+// edited source.
+func TestReparseEveryOffset(t *testing.T) {
+	opts := parser.ParseOptions{Resolver: (&resolver{}).resolve}
+	for _, newText := range []string{"", " ", "x", "|", "}"} {
+		for offset := 0; offset < len(reparseSpec); offset++ {
+			e := source.Edit{Start: uint(offset), End: uint(offset), NewText: newText}
+			if newText == "" {
+				e.End++
+			}
+			doc, err := parser.Parse(parser.ParseParams{
+				Source:  source.NewSource("spec.apex", []byte(reparseSpec)),
+				Options: opts,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := source.ApplyEdits([]byte(reparseSpec), []source.Edit{e})
+			want, wantErr := parser.Parse(parser.ParseParams{
+				Source:  source.NewSource("spec.apex", body),
+				Options: opts,
+			})
+			got, _, err := parser.Reparse(parser.ReparseParams{Document: doc, Edit: e, Options: opts})
+			if (err != nil) != (wantErr != nil) {
+				t.Errorf("edit %+v: error = %v, want %v", e, err, wantErr)
+				continue
+			}
+			if err == nil && dump(got) != dump(want) {
+				t.Errorf("edit %+v: reparsed:\n%s\nparsed:\n%s", e, dump(got), dump(want))
+			}
+		}
+	}
+}
+
+func names(nodes []ast.Node) string {
+	var names []string
+	for _, node := range nodes {
+		if name := query.NameOf(node); name != nil {
+			names = append(names, name.Value)
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// Editing an import resolves it again along with the definitions it
+// brings in.
+func TestReparseImport(t *testing.T) {
+	var r resolver
+	opts := parser.ParseOptions{Resolver: r.resolve}
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  source.NewSource("spec.apex", []byte(reparseSpec)),
+		Options: opts,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, changes, err := parser.Reparse(parser.ReparseParams{
+		Document: doc,
+		Edit:     edit(t, reparseSpec, `"address"`, 0, `"./address"`),
+		Options:  opts,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.calls != 2 {
+		t.Errorf("resolutions = %d, want 2", r.calls)
+	}
+	if _, ok := changes.Added[len(changes.Added)-1].(*ast.ImportDefinition); !ok {
+		t.Errorf("added = %v, want the import last", changes.Added)
+	}
+	if got := names(changes.Added); got != "Address" {
+		t.Errorf("added %q", got)
+	}
+	if len(got.Definitions) != len(doc.Definitions) {
+		t.Errorf("definitions = %d, want %d", len(got.Definitions), len(doc.Definitions))
+	}
+}
+
+func TestReparseFull(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source:  source.NewSource("spec.apex", []byte(`type A { a: string }`)),
+		Options: parser.ParseOptions{NoLocation: true},
+	})
+	if err == nil {
+		_, _, err = parser.Reparse(parser.ReparseParams{Document: doc})
+	}
+	if err == nil || err.Error() != "document has no source to reparse" {
+		t.Errorf("error = %v", err)
+	}
+
+	doc, err = parser.Parse(parser.ParseParams{Source: source.NewSource("spec.apex", []byte(`type A { a: string }`))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, changes, err := parser.Reparse(parser.ReparseParams{
+		Document: doc,
+		Edit:     source.Edit{Start: 5, End: 6, NewText: "B"},
+		Options:  parser.ParseOptions{NoSource: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changes.Full || names(got.Definitions) != "B" || got.Loc.Source != nil {
+		t.Errorf("reparsed %q, changes = %+v", names(got.Definitions), changes)
+	}
+}
+
+func TestReparseErrors(t *testing.T) {
+	src := `type A { a: string }` + "\n" + `type B { b: A }`
+	parse := func() *ast.Document {
+		doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource("spec.apex", []byte(src))})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+	if _, _, err := parser.Reparse(parser.ReparseParams{}); err == nil {
+		t.Error("reparsed without a document")
+	}
+	_, _, err := parser.Reparse(parser.ReparseParams{Document: parse(), Edit: source.Edit{Start: 5, End: 100}})
+	if err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Errorf("out of range edit: error = %v", err)
+	}
+	_, _, err = parser.Reparse(parser.ReparseParams{Document: parse(), Edit: edit(t, src, "}", 0, "")})
+	if err == nil || !strings.Contains(err.Error(), "Syntax Error") {
+		t.Errorf("syntax error: error = %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"strconv"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/source"
+)
+
+// Default limits used for the zero fields of Limits.
+const (
+	DefaultMaxSourceSize       = 4 << 20
+	DefaultMaxDepth            = 100
+	DefaultMaxDefinitions      = 10000
+	DefaultMaxImports          = 100
+	DefaultMaxImportDepth      = 16
+	DefaultMaxResolves         = 1000
+	DefaultMaxTotalDefinitions = 100000
+)
+
+// Limits bound the resources used to parse a source and its imports, so
+// that a large or malicious spec fails with an error instead of
+// exhausting memory or stack. A zero field uses the default limit and a
+// negative field disables it.
+type Limits struct {
+	// MaxSourceSize is the size in bytes of each source, including
+	// imported ones.
+	MaxSourceSize int
+	// MaxDepth is how deeply types and values can be nested, e.g. the
+	// lists in `[[[string]]]`.
+	MaxDepth int
+	// MaxDefinitions is the number of top level definitions of each
+	// source, not counting imported ones.
+	MaxDefinitions int
+	// MaxImports is the number of imports each source can resolve.
+	MaxImports int
+	// MaxImportDepth is how deeply imports can be resolved from the
+	// parsed source.
+	MaxImportDepth int
+	// MaxResolves is the number of resolver calls made for the parsed
+	// source and all of its imports.
+	MaxResolves int
+	// MaxTotalDefinitions is the number of top level definitions of the
+	// parsed source and all of its imports, counting again those that
+	// each import brings in.
+	MaxTotalDefinitions int
+}
+
+// limit returns value, or def when value is zero. Negative values mean
+// no limit and are returned as is.
+func limit(value, def int) int {
+	if value == 0 {
+		return def
+	}
+	return value
+}
+
+// exceeds reports whether n is over the limit max.
+func exceeds(n, max int) bool {
+	return max >= 0 && n > max
+}
+
+// checkSourceSize returns an error when s is larger than the limit.
+func checkSourceSize(s *source.Source, opts ParseOptions) error {
+	max := limit(opts.Limits.MaxSourceSize, DefaultMaxSourceSize)
+	if !exceeds(len(s.Body), max) {
+		return nil
+	}
+	return errors.NewLimitError(s, 0,
+		"Source is "+strconv.Itoa(len(s.Body))+" bytes, more than the limit of "+strconv.Itoa(max)+".")
+}
+
+// enter starts a nested type or value, returning an error when it is
+// deeper than the limit. Each successful enter is followed by leave.
+func enter(parser *Parser) error {
+	parser.depth++
+	max := limit(parser.Options.Limits.MaxDepth, DefaultMaxDepth)
+	if exceeds(parser.depth, max) {
+		return errors.NewLimitError(parser.Source, parser.Token.Start,
+			"Nested more than "+strconv.Itoa(max)+" levels deep.")
+	}
+	return nil
+}
+
+// leave ends a nested type or value started by enter.
+func leave(parser *Parser) {
+	parser.depth--
+}
+
+// count records node, a top level definition of the source starting at
+// start, returning an error when the source has more definitions or
+// imports than the limits allow.
+func count(parser *Parser, node ast.Node, start uint) error {
+	limits := parser.Options.Limits
+	parser.definitions++
+	max := limit(limits.MaxDefinitions, DefaultMaxDefinitions)
+	if exceeds(parser.definitions, max) {
+		return errors.NewLimitError(parser.Source, start,
+			"More than "+strconv.Itoa(max)+" definitions.")
+	}
+	if err := countDefinitions(parser, 1, start); err != nil {
+		return err
+	}
+	if _, ok := node.(*ast.ImportDefinition); !ok || parser.Options.resolver() == nil {
+		return nil
+	}
+	parser.imports++
+	max = limit(limits.MaxImports, DefaultMaxImports)
+	if exceeds(parser.imports, max) {
+		return errors.NewLimitError(parser.Source, start,
+			"More than "+strconv.Itoa(max)+" imports.")
+	}
+	max = limit(limits.MaxImportDepth, DefaultMaxImportDepth)
+	if exceeds(parser.importDepth+1, max) {
+		return errors.NewLimitError(parser.Source, start,
+			"Imports nested more than "+strconv.Itoa(max)+" levels deep.")
+	}
+	return nil
+}
+
+// countDefinitions adds n definitions to those of the whole parse,
+// returning an error at position when there are more than the limit.
+func countDefinitions(parser *Parser, n int, position uint) error {
+	state := parser.state
+	state.mu.Lock()
+	state.definitions += n
+	total := state.definitions
+	state.mu.Unlock()
+	max := limit(parser.Options.Limits.MaxTotalDefinitions, DefaultMaxTotalDefinitions)
+	if exceeds(total, max) {
+		return errors.NewLimitError(parser.Source, position,
+			"More than "+strconv.Itoa(max)+" definitions with those of imports.")
+	}
+	return nil
+}
+
+// countResolve records a resolver call for the import at position,
+// returning an error instead when the whole parse would make more calls
+// than the limit.
+func countResolve(parser *Parser, position uint) error {
+	state := parser.state
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	max := limit(parser.Options.Limits.MaxResolves, DefaultMaxResolves)
+	if exceeds(state.resolves+1, max) {
+		return errors.NewLimitError(parser.Source, position,
+			"More than "+strconv.Itoa(max)+" imports resolved.")
+	}
+	state.resolves++
+	return nil
+}
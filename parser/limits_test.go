@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+)
+
+// levels returns a resolver of "level<n>" to a source that imports
+// "level<n+1>" twice, so that each level brings in twice the definitions
+// of the next, up to level last.
+func levels(last int) parser.Resolver {
+	return func(location, from string) (string, error) {
+		var n int
+		if _, err := fmt.Sscanf(location, "level%d", &n); err != nil {
+			return "", err
+		}
+		if n == last {
+			return fmt.Sprintf("type T%d { id: string }\n", n), nil
+		}
+		return fmt.Sprintf(`import * from "level%d"
+import * from "level%d"
+
+type T%d { id: string }
+`, n+1, n+1, n), nil
+	}
+}
+
+func TestHostileInput(t *testing.T) {
+	sources := map[string]string{
+		"a":    `import * from "b"`,
+		"b":    `import * from "c"`,
+		"c":    `import * from "a"`,
+		"self": `import * from "self"`,
+		"type": `type Item { id: string }`,
+	}
+	files := func(location, from string) (string, error) {
+		return sources[location], nil
+	}
+	tests := []struct {
+		name     string
+		src      string
+		limits   parser.Limits
+		resolver parser.Resolver
+		want     string
+	}{
+		{
+			name:   "source size",
+			src:    `namespace "test"`,
+			limits: parser.Limits{MaxSourceSize: 10},
+			want:   "(1:1) Source is 16 bytes, more than the limit of 10.",
+		},
+		{
+			name: "nested types",
+			src:  "type A { a: " + strings.Repeat("[", 1000) + "string" + strings.Repeat("]", 1000) + " }",
+			want: "Nested more than 100 levels deep.",
+		},
+		{
+			name: "nested values",
+			src:  "type A @a(v: " + strings.Repeat("{v: ", 1000) + "1" + strings.Repeat("}", 1000) + ") { a: string }",
+			want: "Nested more than 100 levels deep.",
+		},
+		{
+			name:   "definitions",
+			src:    strings.Repeat("type A { a: string }\n", 4),
+			limits: parser.Limits{MaxDefinitions: 3},
+			want:   "More than 3 definitions.",
+		},
+		{
+			name:     "imports",
+			src:      strings.Repeat("import * from \"type\"\n", 3),
+			limits:   parser.Limits{MaxImports: 2},
+			resolver: files,
+			want:     "More than 2 imports.",
+		},
+		{
+			name:     "import depth",
+			src:      `import * from "level0"`,
+			resolver: levels(-1),
+			want:     "Imports nested more than 16 levels deep.",
+		},
+		{
+			name:     "resolves",
+			src:      strings.Repeat("import * from \"type\"\n", 30),
+			limits:   parser.Limits{MaxResolves: 20},
+			resolver: files,
+			want:     "More than 20 imports resolved.",
+		},
+		{
+			name:     "total definitions",
+			src:      `import * from "level0"`,
+			limits:   parser.Limits{MaxTotalDefinitions: 5000},
+			resolver: levels(12),
+			want:     "More than 5000 definitions with those of imports.",
+		},
+		{
+			name:     "cycle",
+			src:      `import * from "a"`,
+			resolver: files,
+			want:     "Import Error a (1:1) Import cycle: a imports b imports c imports a.",
+		},
+		{
+			name:     "self import",
+			src:      `import * from "self"`,
+			resolver: files,
+			want:     "Import Error self (1:1) Import cycle: self imports self.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parser.Parse(parser.ParseParams{
+				Source: tt.src,
+				Options: parser.ParseOptions{
+					Resolver: tt.resolver,
+					Limits:   tt.limits,
+				},
+			})
+			if err == nil {
+				t.Fatalf("no error, want %q", tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportsParsedOnce(t *testing.T) {
+	resolves := 0
+	resolve := levels(9)
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: `import * from "level0"`,
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				resolves++
+				return resolve(location, from)
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each level after the first is resolved by both imports of the one
+	// document of the level before.
+	if resolves != 19 {
+		t.Errorf("%d resolves, want 19", resolves)
+	}
+	level0 := doc.Definitions[len(doc.Definitions)-1].(*ast.ImportDefinition).Document
+	var docs []*ast.Document
+	for _, def := range level0.Definitions {
+		if imp, ok := def.(*ast.ImportDefinition); ok && imp.From.Value == "level1" {
+			docs = append(docs, imp.Document)
+		}
+	}
+	if len(docs) != 2 || docs[0] != docs[1] {
+		t.Errorf("imports of level1 do not share a document")
+	}
+}
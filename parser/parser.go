@@ -17,10 +17,12 @@ limitations under the License.
 package parser
 
 import (
+	"context"
 	stderrs "errors"
-	"fmt"
+	"path"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/apexlang/apex-go/ast"
 	"github.com/apexlang/apex-go/errors"
@@ -33,34 +35,83 @@ type parseFn func(parser *Parser) (ast.Node, error)
 // parse operation, fragment, typeSystem{schema, type..., extension, directives} definition
 type parseDefinitionFn func(parser *Parser) (ast.Node, error)
 
-var tokenDefinitionFn map[string]parseDefinitionFn
-
-func init() {
-	tokenDefinitionFn = make(map[string]parseDefinitionFn)
-	{
-		// for sign
-		tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.STRING)] = parseTypeSystemDefinition
-		tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.BLOCK_STRING)] = parseTypeSystemDefinition
-		tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.NAME)] = parseTypeSystemDefinition
-		// for NAME
-		tokenDefinitionFn[lexer.NAMESPACE] = parseNamespaceDefinition
-		tokenDefinitionFn[lexer.IMPORT] = parseImportDefinition
-		tokenDefinitionFn[lexer.ALIAS] = parseAliasDefinition
-		tokenDefinitionFn[lexer.TYPE] = parseTypeDefinition
-		tokenDefinitionFn[lexer.FUNC] = parseFunctionDefinition
-		tokenDefinitionFn[lexer.INTERFACE] = parseInterfaceDefinition
-		tokenDefinitionFn[lexer.UNION] = parseUnionDefinition
-		tokenDefinitionFn[lexer.ENUM] = parseEnumDefinition
-		tokenDefinitionFn[lexer.DIRECTIVE] = parseDirectiveDefinition
+// definitionFn returns the function that parses the definition starting
+// with keyword.
+func definitionFn(keyword string) parseDefinitionFn {
+	switch keyword {
+	case lexer.NAMESPACE:
+		return parseNamespaceDefinition
+	case lexer.IMPORT:
+		return parseImportDefinition
+	case lexer.ALIAS:
+		return parseAliasDefinition
+	case lexer.TYPE:
+		return parseTypeDefinition
+	case lexer.FUNC:
+		return parseFunctionDefinition
+	case lexer.INTERFACE:
+		return parseInterfaceDefinition
+	case lexer.UNION:
+		return parseUnionDefinition
+	case lexer.ENUM:
+		return parseEnumDefinition
+	case lexer.DIRECTIVE:
+		return parseDirectiveDefinition
 	}
+	return nil
 }
 
 type Resolver func(location string, from string) (string, error)
 
+// ContextResolver resolves imports like Resolver. Resolutions should stop
+// with the error of ctx when ctx is done.
+type ContextResolver interface {
+	Resolve(ctx context.Context, location string, from string) (string, error)
+}
+
+// Resolve implements ContextResolver. r cannot be canceled, so ctx is only
+// checked before calling it.
+func (r Resolver) Resolve(ctx context.Context, location string, from string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return r(location, from)
+}
+
+// Locator returns the name of the source that an import location refers
+// to, such as the path of the file a Resolver reads.
+type Locator func(location string, from string) (string, error)
+
+// ImportSourceName returns the name given to the source of an import.
+// Locations starting with "./" or "../" are relative to the importing
+// source; all others are used as is.
+func ImportSourceName(location, from string) string {
+	if from != "" && (strings.HasPrefix(location, "./") || strings.HasPrefix(location, "../")) {
+		return path.Join(path.Dir(from), location)
+	}
+	return location
+}
+
 type ParseOptions struct {
 	NoLocation bool
 	NoSource   bool
 	Resolver   Resolver
+	// ContextResolver is used instead of Resolver when set.
+	ContextResolver ContextResolver
+	// Locator names imported sources. ImportSourceName is used when nil.
+	Locator Locator
+	// Limits bound the size of sources, nesting and imports.
+	Limits Limits
+}
+
+func (o *ParseOptions) resolver() ContextResolver {
+	if o.ContextResolver != nil {
+		return o.ContextResolver
+	}
+	if o.Resolver != nil {
+		return o.Resolver
+	}
+	return nil
 }
 
 type ParseParams struct {
@@ -74,9 +125,56 @@ type Parser struct {
 	Options  ParseOptions
 	PrevEnd  uint
 	Token    lexer.Token
+
+	// ahead is the token after Token when it has been read by lookahead.
+	ahead    lexer.Token
+	hasAhead bool
+
+	// ctx bounds the resolution of imports.
+	ctx context.Context
+	// depth is the nesting of the type or value being parsed.
+	depth int
+	// definitions and imports count the top level definitions parsed.
+	definitions int
+	imports     int
+	// importDepth is the number of imports that led to the source.
+	importDepth int
+	// importer is the parser of the source that imports this one with
+	// imported.
+	importer *Parser
+	imported *ast.ImportDefinition
+	// state is shared with the parsers of the imported sources.
+	state *parseState
+	// alloc allocates the most common nodes.
+	alloc allocator
+	// listStack holds the nodes of the lists being parsed.
+	listStack []ast.Node
+}
+
+// context returns the context that bounds the resolution of imports.
+func (p *Parser) context() context.Context {
+	if p.ctx == nil {
+		return context.Background()
+	}
+	return p.ctx
 }
 
 func Parse(p ParseParams) (*ast.Document, error) {
+	return ParseContext(context.Background(), p)
+}
+
+// ParseContext parses like Parse. Resolution stops with the error of ctx
+// when ctx is done. A ContextResolver resolves the imports of each source
+// concurrently, while a Resolver, which cannot be canceled, resolves them
+// one at a time between checks of ctx. Imported definitions are in the
+// same order as with Parse.
+func ParseContext(ctx context.Context, p ParseParams) (*ast.Document, error) {
+	return parseContext(ctx, p, nil, nil)
+}
+
+// parseContext parses a source imported by imp of importer, or the parsed
+// source when importer is nil.
+func parseContext(ctx context.Context, p ParseParams, importer *Parser, imp *ast.ImportDefinition) (*ast.Document, error) {
 	var sourceObj *source.Source
 	switch src := p.Source.(type) {
 	case *source.Source:
@@ -88,10 +186,20 @@ func Parse(p ParseParams) (*ast.Document, error) {
 	default:
 		return nil, stderrs.New("unexpected value for Source")
 	}
+	if err := checkSourceSize(sourceObj, p.Options); err != nil {
+		return nil, err
+	}
 	parser, err := makeParser(sourceObj, p.Options)
 	if err != nil {
 		return nil, err
 	}
+	parser.ctx = ctx
+	if importer != nil {
+		parser.importDepth = importer.importDepth + 1
+		parser.importer = importer
+		parser.imported = imp
+		parser.state = importer.state
+	}
 	doc, err := parseDocument(parser)
 	if err != nil {
 		return nil, err
@@ -101,19 +209,22 @@ func Parse(p ParseParams) (*ast.Document, error) {
 
 // Converts a name lex token into a name parse node.
 func parseName(parser *Parser) (*ast.Name, error) {
-	token, err := expect(parser, lexer.TokenKind[lexer.NAME])
+	token, err := expect(parser, lexer.NAME)
 	if err != nil {
 		return nil, err
 	}
-	return ast.NewName(
-		loc(parser, token.Start),
-		token.Value,
-	), nil
+	return parser.alloc.name(loc(parser, token.Start), token.Value), nil
 }
 
 func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
+	return makeParserAt(s, opts, 0)
+}
+
+// makeParserAt returns a parser whose first token is the one at or after
+// position.
+func makeParserAt(s *source.Source, opts ParseOptions, position uint) (*Parser, error) {
 	lexToken := lexer.Lex(s)
-	token, err := lexToken(0)
+	token, err := lexToken(position)
 	if err != nil {
 		return &Parser{}, err
 	}
@@ -121,163 +232,310 @@ func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
 		LexToken: lexToken,
 		Source:   s,
 		Options:  opts,
-		PrevEnd:  0,
+		PrevEnd:  position,
 		Token:    token,
+		state:    &parseState{},
 	}, nil
 }
 
 /* Implements the parsing rules in the Document section. */
 
 func parseDocument(parser *Parser) (*ast.Document, error) {
-	var (
-		nodes []ast.Node
-		node  ast.Node
-		item  parseDefinitionFn
-		err   error
-	)
+	var nodes []ast.Node
 	start := parser.Token.Start
 	for {
-		if skp, err := skip(parser, lexer.TokenKind[lexer.EOF]); err != nil {
+		if skp, err := skip(parser, lexer.EOF); err != nil {
 			return nil, err
 		} else if skp {
 			break
 		}
-		switch parser.Token.Kind {
-		case lexer.TokenKind[lexer.BRACE_L]:
-			item = tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.TokenKind[lexer.BRACE_L])]
-		case lexer.TokenKind[lexer.NAME]:
-			item = tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.TokenKind[lexer.NAME])]
-		case lexer.TokenKind[lexer.STRING]:
-			item = tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.TokenKind[lexer.STRING])]
-		case lexer.TokenKind[lexer.BLOCK_STRING]:
-			item = tokenDefinitionFn[lexer.GetTokenKindDesc(lexer.TokenKind[lexer.BLOCK_STRING])]
-		default:
-			return nil, unexpected(parser, lexer.Token{})
-		}
-		if node, err = item(parser); err != nil {
+		def, err := parseTopLevelDefinition(parser)
+		if err != nil {
 			return nil, err
 		}
+		nodes = append(nodes, def)
+	}
+	nodes, err := resolveImports(parser, nodes)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewDocument(
+		loc(parser, start),
+		nodes,
+	), nil
+}
 
-		if imp, ok := node.(*ast.ImportDefinition); ok && parser.Options.Resolver != nil {
-			source, err := parser.Options.Resolver(imp.From.Value, "")
-			if err != nil {
-				return nil, err
-			}
-			if strings.HasPrefix(source, "error:") {
-				return nil, stderrs.New(source)
-			}
-			doc, err := Parse(ParseParams{
-				Source:  source,
-				Options: parser.Options,
-			})
+// parseTopLevelDefinition parses the definition at the current token and
+// counts it against the limits.
+func parseTopLevelDefinition(parser *Parser) (ast.Node, error) {
+	start := parser.Token.Start
+	switch parser.Token.Kind {
+	case lexer.NAME, lexer.STRING, lexer.BLOCK_STRING:
+	default:
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	def, err := parseTypeSystemDefinition(parser)
+	if err != nil {
+		return nil, err
+	}
+	if err := count(parser, def, start); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// resolveImports returns nodes with the definitions that each import
+// brings in before it when the parser has a resolver.
+func resolveImports(parser *Parser, nodes []ast.Node) ([]ast.Node, error) {
+	resolver := parser.Options.resolver()
+	if resolver == nil {
+		return nodes, nil
+	}
+	var imports []*ast.ImportDefinition
+	for _, node := range nodes {
+		if imp, ok := node.(*ast.ImportDefinition); ok {
+			imports = append(imports, imp)
+		}
+	}
+	if len(imports) == 0 {
+		return nodes, nil
+	}
+
+	var results [][]ast.Node
+	var err error
+	if r, ok := resolver.(Resolver); ok {
+		results, err = resolveSequentially(parser, r, imports)
+	} else {
+		results, err = resolveConcurrently(parser, resolver, imports)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]ast.Node, 0, len(nodes))
+	i := 0
+	for _, node := range nodes {
+		if _, ok := node.(*ast.ImportDefinition); ok {
+			resolved = append(resolved, results[i]...)
+			i++
+		}
+		resolved = append(resolved, node)
+	}
+	return resolved, nil
+}
+
+// resolveSequentially resolves imports one at a time with a Resolver,
+// stopping at the first that fails.
+func resolveSequentially(parser *Parser, resolver Resolver, imports []*ast.ImportDefinition) ([][]ast.Node, error) {
+	ctx := parser.context()
+	results := make([][]ast.Node, len(imports))
+	for i, imp := range imports {
+		nodes, err := resolveImport(ctx, parser, resolver, imp)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = nodes
+	}
+	return results, nil
+}
+
+// resolveConcurrently resolves imports concurrently with a ContextResolver.
+// An import that fails cancels those after it, so the error returned is
+// that of the first import in source order to fail, as when resolving them
+// one at a time.
+func resolveConcurrently(parser *Parser, resolver ContextResolver, imports []*ast.ImportDefinition) ([][]ast.Node, error) {
+	parent := parser.context()
+	ctxs := make([]context.Context, len(imports))
+	cancels := make([]context.CancelFunc, len(imports))
+	ctx := parent
+	for i := range imports {
+		// Canceling the context of an import cancels those after it.
+		ctx, cancels[i] = context.WithCancel(ctx)
+		ctxs[i] = ctx
+	}
+	defer func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}()
+	type result struct {
+		nodes []ast.Node
+		err   error
+	}
+	results := make([]result, len(imports))
+	var wg sync.WaitGroup
+	for i, imp := range imports {
+		wg.Add(1)
+		async(func() {
+			defer wg.Done()
+			nodes, err := resolveImport(ctxs[i], parser, resolver, imp)
 			if err != nil {
-				return nil, err
+				cancels[i]()
 			}
+			results[i] = result{nodes, err}
+		})
+	}
+	wg.Wait()
+
+	if err := parent.Err(); err != nil {
+		return nil, err
+	}
+	resolved := make([][]ast.Node, len(imports))
+	for i, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+		resolved[i] = r.nodes
+	}
+	return resolved, nil
+}
+
+// resolveImport parses the source that imp refers to and returns the
+// definitions that imp brings in. A source resolved again with the same
+// contents is not parsed again.
+func resolveImport(ctx context.Context, parser *Parser, resolver ContextResolver, imp *ast.ImportDefinition) ([]ast.Node, error) {
+	var nodes []ast.Node
+	from := parser.Source.Name
+	position := importPosition(imp)
+	name := ImportSourceName(imp.From.Value, from)
+	if parser.Options.Locator != nil {
+		var err error
+		if name, err = parser.Options.Locator(imp.From.Value, from); err != nil {
+			return nil, err
+		}
+	}
+	if cycle, src, at := importCycle(parser, imp, name); cycle != "" {
+		return nil, errors.NewImportError(src, at,
+			"Import cycle: "+cycle+".")
+	}
+	if err := countResolve(parser, position); err != nil {
+		return nil, err
+	}
+	contents, err := resolver.Resolve(ctx, imp.From.Value, from)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(contents, "error:") {
+		return nil, stderrs.New(contents)
+	}
+	src, doc := parser.state.parsed(name, contents)
+	if doc == nil {
+		src = source.NewSource(name, []byte(contents))
+		if doc, err = parseContext(ctx, ParseParams{
+			Source:  src,
+			Options: parser.Options,
+		}, parser, imp); err != nil {
+			return nil, err
+		}
+		parser.state.store(name, contents, src, doc)
+	}
 
-			if imp.All {
-				nodes = append(nodes, doc.Definitions...)
-			} else {
-				allDefs := make(map[string]ast.Definition)
-				for _, def := range doc.Definitions {
-					switch v := def.(type) {
-					case *ast.InterfaceDefinition:
-						allDefs[v.Name.Value] = v
-					case *ast.TypeDefinition:
-						allDefs[v.Name.Value] = v
-					case *ast.EnumDefinition:
-						allDefs[v.Name.Value] = v
-					case *ast.UnionDefinition:
-						allDefs[v.Name.Value] = v
-					case *ast.DirectiveDefinition:
-						allDefs[v.Name.Value] = v
-					case *ast.AliasDefinition:
-						allDefs[v.Name.Value] = v
-					}
-				}
-
-				for _, n := range imp.Names {
-					def, ok := allDefs[n.Name.Value]
-					if !ok {
-						return nil, fmt.Errorf(
-							"could not find %q in %q", n.Name.Value, imp.From.Value)
-					}
-					name := n.Alias
-					if name == nil {
-						name = n.Name
-					}
-					switch v := def.(type) {
-					case *ast.InterfaceDefinition:
-						renamedType := ast.NewInterfaceDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Annotations,
-							v.Operations,
-						)
-						nodes = append(nodes, renamedType)
-
-					case *ast.TypeDefinition:
-						renamedType := ast.NewTypeDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Interfaces,
-							v.Annotations,
-							v.Fields,
-						)
-						nodes = append(nodes, renamedType)
-
-					case *ast.EnumDefinition:
-						renamedEnum := ast.NewEnumDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Annotations,
-							v.Values,
-						)
-						nodes = append(nodes, renamedEnum)
-
-					case *ast.UnionDefinition:
-						renamedUnion := ast.NewUnionDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Annotations,
-							v.Members,
-						)
-						nodes = append(nodes, renamedUnion)
-
-					case *ast.DirectiveDefinition:
-						renamedDirective := ast.NewDirectiveDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Parameters,
-							v.Locations,
-							v.Requires,
-						)
-						nodes = append(nodes, renamedDirective)
-
-					case *ast.AliasDefinition:
-						renamedAlias := ast.NewAliasDefinition(
-							name.Loc,
-							name,
-							v.Description,
-							v.Type,
-							v.Annotations,
-						)
-						nodes = append(nodes, renamedAlias)
-					}
-				}
+	imp.Source = src
+	imp.Document = doc
+	switch {
+	case imp.Alias != nil:
+		// The definitions of a namespace imported with an alias are
+		// referred to by qualified names.
+	case imp.All:
+		nodes = append(nodes, doc.Definitions...)
+	default:
+		allDefs := make(map[string]ast.Definition)
+		for _, def := range doc.Definitions {
+			switch v := def.(type) {
+			case *ast.InterfaceDefinition:
+				allDefs[v.Name.Value] = v
+			case *ast.TypeDefinition:
+				allDefs[v.Name.Value] = v
+			case *ast.EnumDefinition:
+				allDefs[v.Name.Value] = v
+			case *ast.UnionDefinition:
+				allDefs[v.Name.Value] = v
+			case *ast.DirectiveDefinition:
+				allDefs[v.Name.Value] = v
+			case *ast.AliasDefinition:
+				allDefs[v.Name.Value] = v
 			}
 		}
 
-		nodes = append(nodes, node)
+		for _, n := range imp.Names {
+			def, ok := allDefs[n.Name.Value]
+			if !ok {
+				// Reported by the ValidImports rule.
+				continue
+			}
+			name := n.Alias
+			if name == nil {
+				name = n.Name
+			}
+			switch v := def.(type) {
+			case *ast.InterfaceDefinition:
+				renamedType := ast.NewInterfaceDefinition(
+					name.Loc,
+					name,
+					v.Description,
+					v.Annotations,
+					v.Operations,
+				)
+				nodes = append(nodes, renamedType)
+
+			case *ast.TypeDefinition:
+				renamedType := ast.NewTypeDefinition(
+					name.Loc,
+					name,
+					v.Description,
+					v.Interfaces,
+					v.Annotations,
+					v.Fields,
+				)
+				nodes = append(nodes, renamedType)
+
+			case *ast.EnumDefinition:
+				renamedEnum := ast.NewEnumDefinition(
+					name.Loc,
+					name,
+					v.Description,
+					v.Annotations,
+					v.Values,
+				)
+				nodes = append(nodes, renamedEnum)
+
+			case *ast.UnionDefinition:
+				renamedUnion := ast.NewUnionDefinition(
+					name.Loc,
+					name,
+					v.Description,
+					v.Annotations,
+					v.Members,
+				)
+				nodes = append(nodes, renamedUnion)
+
+			case *ast.DirectiveDefinition:
+				renamedDirective := ast.NewDirectiveDefinition(
+					name.Loc,
+					name,
+					v.Description,
+					v.Parameters,
+					v.Locations,
+					v.Requires,
+				)
+				nodes = append(nodes, renamedDirective)
+
+			case *ast.AliasDefinition:
+				renamedAlias := ast.NewAliasDefinition(
+					name.Loc,
+					name,
+					v.Description,
+					v.Type,
+					v.Annotations,
+				)
+				nodes = append(nodes, renamedAlias)
+			}
+		}
 	}
-	return ast.NewDocument(
-		loc(parser, start),
-		nodes,
-	), nil
+	if err := countDefinitions(parser, len(nodes), position); err != nil {
+		return nil, err
+	}
+	return nodes, nil
 }
 
 /* Implements the parsing rules in the Operations section. */
@@ -286,20 +544,10 @@ func parseDocument(parser *Parser) (*ast.Document, error) {
  * Arguments : ( Argument+ )
  */
 func parseArguments(parser *Parser) ([]*ast.Argument, error) {
-	arguments := []*ast.Argument{}
-	if peek(parser, lexer.TokenKind[lexer.PAREN_L]) {
-		if iArguments, err := reverse(parser,
-			lexer.TokenKind[lexer.PAREN_L], parseArgument, lexer.TokenKind[lexer.PAREN_R],
-			true,
-		); err != nil {
-			return arguments, err
-		} else {
-			for _, iArgument := range iArguments {
-				arguments = append(arguments, iArgument.(*ast.Argument))
-			}
-		}
+	if peek(parser, lexer.PAREN_L) {
+		return list[*ast.Argument](parser, lexer.PAREN_L, parseArgument, lexer.PAREN_R)
 	}
-	return arguments, nil
+	return []*ast.Argument{}, nil
 }
 
 /**
@@ -312,13 +560,13 @@ func parseArgument(parser *Parser) (ast.Node, error) {
 		value ast.Value
 	)
 	start := parser.Token.Start
-	if !peek(parser, lexer.TokenKind[lexer.NAME]) {
+	if !peek(parser, lexer.NAME) {
 		name = ast.NewName(nil, "value")
 	} else {
 		if name, err = parseName(parser); err != nil {
 			return nil, err
 		}
-		if _, err = expect(parser, lexer.TokenKind[lexer.COLON]); err != nil {
+		if _, err = expect(parser, lexer.COLON); err != nil {
 			return nil, err
 		}
 	}
@@ -350,15 +598,19 @@ func parseArgument(parser *Parser) (ast.Node, error) {
  * EnumValue : Name but not `true`, `false` or `null`
  */
 func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
+	if err := enter(parser); err != nil {
+		return nil, err
+	}
+	defer leave(parser)
 	token := parser.Token
 	switch token.Kind {
-	// case lexer.TokenKind[lexer.BRACE_L]:
+	// case lexer.BRACE_L:
 	// 	return parseMap(parser, isConst)
-	case lexer.TokenKind[lexer.BRACKET_L]:
+	case lexer.BRACKET_L:
 		return parseList(parser, isConst)
-	case lexer.TokenKind[lexer.BRACE_L]:
+	case lexer.BRACE_L:
 		return parseObject(parser, isConst)
-	case lexer.TokenKind[lexer.INT]:
+	case lexer.INT:
 		if err := advance(parser); err != nil {
 			return nil, err
 		}
@@ -370,7 +622,7 @@ func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 			loc(parser, token.Start),
 			intVal,
 		), nil
-	case lexer.TokenKind[lexer.FLOAT]:
+	case lexer.FLOAT:
 		if err := advance(parser); err != nil {
 			return nil, err
 		}
@@ -382,9 +634,9 @@ func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 			loc(parser, token.Start),
 			floatVal,
 		), nil
-	case lexer.TokenKind[lexer.BLOCK_STRING], lexer.TokenKind[lexer.STRING]:
+	case lexer.BLOCK_STRING, lexer.STRING:
 		return parseStringLiteral(parser)
-	case lexer.TokenKind[lexer.NAME]:
+	case lexer.NAME:
 		if token.Value == "true" || token.Value == "false" {
 			if err := advance(parser); err != nil {
 				return nil, err
@@ -434,16 +686,9 @@ func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
 	if isConst {
 		item = parseConstValue
 	}
-	values := []ast.Value{}
-	if iValues, err := reverse(parser,
-		lexer.TokenKind[lexer.BRACKET_L], item, lexer.TokenKind[lexer.BRACKET_R],
-		false,
-	); err != nil {
+	values, err := list[ast.Value](parser, lexer.BRACKET_L, item, lexer.BRACKET_R)
+	if err != nil {
 		return nil, err
-	} else {
-		for _, iValue := range iValues {
-			values = append(values, iValue.(ast.Value))
-		}
 	}
 	return ast.NewListValue(
 		loc(parser, start),
@@ -458,12 +703,12 @@ func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
  */
 func parseObject(parser *Parser, isConst bool) (*ast.ObjectValue, error) {
 	start := parser.Token.Start
-	if _, err := expect(parser, lexer.TokenKind[lexer.BRACE_L]); err != nil {
+	if _, err := expect(parser, lexer.BRACE_L); err != nil {
 		return nil, err
 	}
 	fields := []*ast.ObjectField{}
 	for {
-		if skp, err := skip(parser, lexer.TokenKind[lexer.BRACE_R]); err != nil {
+		if skp, err := skip(parser, lexer.BRACE_R); err != nil {
 			return nil, err
 		} else if skp {
 			break
@@ -490,18 +735,15 @@ func parseObjectField(parser *Parser, isConst bool) (*ast.ObjectField, error) {
 		err   error
 	)
 	start := parser.Token.Start
-	if parser.Token.Kind == lexer.TokenKind[lexer.NS] ||
-		parser.Token.Kind == lexer.TokenKind[lexer.NAME] ||
-		parser.Token.Kind == lexer.TokenKind[lexer.STRING] {
-		name = ast.NewName(
-			loc(parser, parser.Token.Start),
-			parser.Token.Value,
-		)
+	if parser.Token.Kind == lexer.NS ||
+		parser.Token.Kind == lexer.NAME ||
+		parser.Token.Kind == lexer.STRING {
+		name = parser.alloc.name(loc(parser, parser.Token.Start), parser.Token.Value)
 		advance(parser)
 	} else {
 		return nil, unexpected(parser, parser.Token)
 	}
-	if _, err = expect(parser, lexer.TokenKind[lexer.COLON]); err != nil {
+	if _, err = expect(parser, lexer.COLON); err != nil {
 		return nil, err
 	}
 	if value, err = parseValueLiteral(parser, isConst); err != nil {
@@ -521,7 +763,7 @@ func parseObjectField(parser *Parser, isConst bool) (*ast.ObjectField, error) {
  */
 func parseAnnotations(parser *Parser) ([]*ast.Annotation, error) {
 	annotations := []*ast.Annotation{}
-	for peek(parser, lexer.TokenKind[lexer.AT]) {
+	for peek(parser, lexer.AT) {
 		if annotation, err := parseAnnotation(parser); err != nil {
 			return annotations, err
 		} else {
@@ -541,7 +783,7 @@ func parseAnnotation(parser *Parser) (*ast.Annotation, error) {
 		args []*ast.Argument
 	)
 	start := parser.Token.Start
-	if _, err = expect(parser, lexer.TokenKind[lexer.AT]); err != nil {
+	if _, err = expect(parser, lexer.AT); err != nil {
 		return nil, err
 	}
 	if name, err = parseName(parser); err != nil {
@@ -566,11 +808,15 @@ func parseAnnotation(parser *Parser) (*ast.Annotation, error) {
  *   - NonNullType
  */
 func parseType(parser *Parser) (ttype ast.Type, err error) {
+	if err = enter(parser); err != nil {
+		return nil, err
+	}
+	defer leave(parser)
 	token := parser.Token
 	var keyType, valueType ast.Type
 	// [ String! ]!
 	switch token.Kind {
-	case lexer.TokenKind[lexer.BRACKET_L]:
+	case lexer.BRACKET_L:
 		if err = advance(parser); err != nil {
 			return nil, err
 		}
@@ -578,7 +824,7 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 			return nil, err
 		}
 		fallthrough
-	case lexer.TokenKind[lexer.BRACKET_R]:
+	case lexer.BRACKET_R:
 		if err = advance(parser); err != nil {
 			return nil, err
 		}
@@ -586,21 +832,21 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 			loc(parser, token.Start),
 			ttype,
 		)
-	case lexer.TokenKind[lexer.BRACE_L]:
+	case lexer.BRACE_L:
 		if err = advance(parser); err != nil {
 			return nil, err
 		}
 		if keyType, err = parseType(parser); err != nil {
 			return nil, err
 		}
-		if _, err = expect(parser, lexer.TokenKind[lexer.COLON]); err != nil {
+		if _, err = expect(parser, lexer.COLON); err != nil {
 			return nil, err
 		}
 		if valueType, err = parseType(parser); err != nil {
 			return nil, err
 		}
 		fallthrough
-	case lexer.TokenKind[lexer.BRACE_R]:
+	case lexer.BRACE_R:
 		if err = advance(parser); err != nil {
 			return nil, err
 		}
@@ -609,14 +855,14 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 			keyType,
 			valueType,
 		)
-	case lexer.TokenKind[lexer.NAME]:
+	case lexer.NAME, lexer.NS:
 		if ttype, err = parseNamed(parser); err != nil {
 			return nil, err
 		}
 	}
 
 	// QUESTION must be executed
-	if skp, err := skip(parser, lexer.TokenKind[lexer.QUESTION]); err != nil {
+	if skp, err := skip(parser, lexer.QUESTION); err != nil {
 		return nil, err
 	} else if skp {
 		ttype = ast.NewOptional(
@@ -628,18 +874,33 @@ func parseType(parser *Parser) (ttype ast.Type, err error) {
 }
 
 /**
- * NamedType : Name
+ * NamedType : Name | Name . Name
  */
 func parseNamed(parser *Parser) (*ast.Named, error) {
 	start := parser.Token.Start
+	if token := parser.Token; token.Kind == lexer.NS {
+		// A qualified name is lexed as one token.
+		dot := strings.LastIndexByte(token.Value, '.')
+		qualifier, value := token.Value[:dot], token.Value[dot+1:]
+		if qualifier == "" || value == "" || strings.ContainsRune(qualifier, '.') {
+			return nil, errors.NewSyntaxError(parser.Source, token.Start,
+				"Invalid qualified name "+strconv.Quote(token.Value)+", expected Namespace.Name.")
+		}
+		if err := advance(parser); err != nil {
+			return nil, err
+		}
+		middle := token.Start + uint(dot)
+		return parser.alloc.namedType(
+			loc(parser, start),
+			parser.alloc.name(span(parser, start, middle), qualifier),
+			parser.alloc.name(span(parser, middle+1, token.End), value),
+		), nil
+	}
 	name, err := parseName(parser)
 	if err != nil {
 		return nil, err
 	}
-	return ast.NewNamed(
-		loc(parser, start),
-		name,
-	), nil
+	return parser.alloc.namedType(loc(parser, start), nil, name), nil
 }
 
 /* Implements the parsing rules in the Type Definition section. */
@@ -675,8 +936,7 @@ func parseTypeSystemDefinition(parser *Parser) (ast.Node, error) {
 	if keywordToken.Kind != lexer.NAME {
 		return nil, unexpected(parser, keywordToken)
 	}
-	var ok bool
-	if item, ok = tokenDefinitionFn[keywordToken.Value]; !ok {
+	if item = definitionFn(keywordToken.Value); item == nil {
 		return nil, unexpected(parser, keywordToken)
 	}
 	return item(parser)
@@ -701,10 +961,7 @@ func parseNamespaceDefinition(parser *Parser) (ast.Node, error) {
 	} else {
 		return nil, unexpected(parser, ns)
 	}
-	name := ast.NewName(
-		loc(parser, ns.Start),
-		ns.Value,
-	)
+	name := parser.alloc.name(loc(parser, ns.Start), ns.Value)
 	annotations, err := parseAnnotations(parser)
 	if err != nil {
 		return nil, err
@@ -718,7 +975,7 @@ func parseNamespaceDefinition(parser *Parser) (ast.Node, error) {
 }
 
 /**
- * ImportDefinition : Description? import Name from StringValue
+ * ImportDefinition : Description? import ( * ( as Name )? | { ImportName+ } ) from StringValue
  */
 func parseImportDefinition(parser *Parser) (ast.Node, error) {
 	start := parser.Token.Start
@@ -732,25 +989,22 @@ func parseImportDefinition(parser *Parser) (ast.Node, error) {
 		return nil, err
 	}
 
-	if peek(parser, lexer.TokenKind[lexer.STAR]) {
+	var alias *ast.Name
+	if peek(parser, lexer.STAR) {
 		all = true
 		advance(parser)
-	} else if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
-		// Parameters operation
-		iImportNames, err := reverse(parser,
-			lexer.TokenKind[lexer.BRACE_L], parseImportName, lexer.TokenKind[lexer.BRACE_R],
-			true,
-		)
-		if err != nil {
+		if _, ok, err := optionalKeyWord(parser, "as"); err != nil {
 			return nil, err
-		}
-
-		importNames = make([]*ast.ImportName, 0, len(iImportNames))
-		for _, iImportName := range iImportNames {
-			if iImportName != nil {
-				importNames = append(importNames, iImportName.(*ast.ImportName))
+		} else if ok {
+			if alias, err = parseName(parser); err != nil {
+				return nil, err
 			}
 		}
+	} else if peek(parser, lexer.BRACE_L) {
+		// Parameters operation
+		if importNames, err = list[*ast.ImportName](parser, lexer.BRACE_L, parseImportName, lexer.BRACE_R); err != nil {
+			return nil, err
+		}
 	} else {
 		return nil, unexpected(parser, parser.Token)
 	}
@@ -767,6 +1021,15 @@ func parseImportDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
+	if alias != nil {
+		return ast.NewAliasedImportDefinition(
+			loc(parser, start),
+			description,
+			alias,
+			from,
+			annotations,
+		), nil
+	}
 	return ast.NewImportDefinition(
 		loc(parser, start),
 		description,
@@ -815,7 +1078,7 @@ func parseAliasDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = expect(parser, lexer.TokenKind[lexer.EQUALS])
+	_, err = expect(parser, lexer.EQUALS)
 	if err != nil {
 		return nil, err
 	}
@@ -863,19 +1126,10 @@ func parseTypeDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	iFields, err := reverse(parser,
-		lexer.TokenKind[lexer.BRACE_L], parseFieldDefinition, lexer.TokenKind[lexer.BRACE_R],
-		false,
-	)
+	fields, err := list[*ast.FieldDefinition](parser, lexer.BRACE_L, parseFieldDefinition, lexer.BRACE_R)
 	if err != nil {
 		return nil, err
 	}
-	fields := []*ast.FieldDefinition{}
-	for _, iField := range iFields {
-		if iField != nil {
-			fields = append(fields, iField.(*ast.FieldDefinition))
-		}
-	}
 	return ast.NewTypeDefinition(
 		loc(parser, start),
 		name,
@@ -898,14 +1152,14 @@ func parseImplementsInterfaces(parser *Parser) ([]*ast.Named, error) {
 			return nil, err
 		}
 		// optional leading ampersand
-		skip(parser, lexer.TokenKind[lexer.AMP])
+		skip(parser, lexer.AMP)
 		for {
 			ttype, err := parseNamed(parser)
 			if err != nil {
 				return types, err
 			}
 			types = append(types, ttype)
-			if skipped, err := skip(parser, lexer.TokenKind[lexer.AMP]); !skipped {
+			if skipped, err := skip(parser, lexer.AMP); !skipped {
 				break
 			} else if err != nil {
 				return types, err
@@ -949,12 +1203,14 @@ func parseOperationDefinitionBody(parser *Parser, start uint, description *ast.S
 	if err != nil {
 		return nil, err
 	}
-	_, colon, err := optional(parser, lexer.TokenKind[lexer.COLON])
+	_, colon, err := optional(parser, lexer.COLON)
 	if err != nil {
 		return nil, err
 	}
-	var ttype ast.Type = ast.NewNamed(nil, ast.NewName(nil, "void"))
-	if colon {
+	var ttype ast.Type
+	if !colon {
+		ttype = parser.alloc.namedType(nil, nil, parser.alloc.name(nil, "void"))
+	} else {
 		streamToken, streamOK, err := optionalKeyWord(parser, "stream")
 		if err != nil {
 			return nil, err
@@ -998,7 +1254,7 @@ func parseFieldDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = expect(parser, lexer.TokenKind[lexer.COLON])
+	_, err = expect(parser, lexer.COLON)
 	if err != nil {
 		return nil, err
 	}
@@ -1007,7 +1263,7 @@ func parseFieldDefinition(parser *Parser) (ast.Node, error) {
 		return nil, err
 	}
 	var defaultValue ast.Value
-	if skp, err := skip(parser, lexer.TokenKind[lexer.EQUALS]); err != nil {
+	if skp, err := skip(parser, lexer.EQUALS); err != nil {
 		return nil, err
 	} else if skp {
 		if defaultValue, err = parseValueLiteral(parser, true); err != nil {
@@ -1032,25 +1288,15 @@ func parseFieldDefinition(parser *Parser) (ast.Node, error) {
  * ParametersDefinition : ( ParameterDefinition+ )
  */
 func parseParameterDefs(parser *Parser, unary bool) ([]*ast.ParameterDefinition, bool, error) {
-	if peek(parser, lexer.TokenKind[lexer.PAREN_L]) {
+	if peek(parser, lexer.PAREN_L) {
 		// Parameters operation
-		iParameterDefinitions, err := reverse(parser,
-			lexer.TokenKind[lexer.PAREN_L], parseParameterDef, lexer.TokenKind[lexer.PAREN_R],
-			true,
-		)
+		parameterDefinitions, err := list[*ast.ParameterDefinition](parser, lexer.PAREN_L, parseParameterDef, lexer.PAREN_R)
 		if err != nil {
 			return nil, false, err
 		}
 
-		parameterDefinitions := make([]*ast.ParameterDefinition, 0, len(iParameterDefinitions))
-		for _, iParameterDefinition := range iParameterDefinitions {
-			if iParameterDefinition != nil {
-				parameterDefinitions = append(parameterDefinitions, iParameterDefinition.(*ast.ParameterDefinition))
-			}
-		}
-
 		return parameterDefinitions, false, nil
-	} else if unary && peek(parser, lexer.TokenKind[lexer.BRACKET_L]) {
+	} else if unary && peek(parser, lexer.BRACKET_L) {
 		// Unary operation
 		if err := advance(parser); err != nil {
 			return nil, true, err
@@ -1060,7 +1306,7 @@ func parseParameterDefs(parser *Parser, unary bool) ([]*ast.ParameterDefinition,
 			return nil, true, err
 		}
 
-		if _, err := expect(parser, lexer.TokenKind[lexer.BRACKET_R]); err != nil {
+		if _, err := expect(parser, lexer.BRACKET_R); err != nil {
 			return nil, true, err
 		}
 
@@ -1088,7 +1334,7 @@ func parseParameterDef(parser *Parser) (ast.Node, error) {
 	if name, err = parseName(parser); err != nil {
 		return nil, err
 	}
-	if _, err = expect(parser, lexer.TokenKind[lexer.COLON]); err != nil {
+	if _, err = expect(parser, lexer.COLON); err != nil {
 		return nil, err
 	}
 
@@ -1107,7 +1353,7 @@ func parseParameterDef(parser *Parser) (ast.Node, error) {
 	}
 
 	var defaultValue ast.Value
-	if skp, err := skip(parser, lexer.TokenKind[lexer.EQUALS]); err != nil {
+	if skp, err := skip(parser, lexer.EQUALS); err != nil {
 		return nil, err
 	} else if skp {
 		val, err := parseConstValue(parser)
@@ -1154,19 +1400,10 @@ func parseInterfaceDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	iOperations, err := reverse(parser,
-		lexer.TokenKind[lexer.BRACE_L], parseOperationDefinition, lexer.TokenKind[lexer.BRACE_R],
-		false,
-	)
+	operations, err := list[*ast.OperationDefinition](parser, lexer.BRACE_L, parseOperationDefinition, lexer.BRACE_R)
 	if err != nil {
 		return nil, err
 	}
-	operations := []*ast.OperationDefinition{}
-	for _, iOperation := range iOperations {
-		if iOperation != nil {
-			operations = append(operations, iOperation.(*ast.OperationDefinition))
-		}
-	}
 	return ast.NewInterfaceDefinition(
 		loc(parser, start),
 		name,
@@ -1197,7 +1434,7 @@ func parseUnionDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = expect(parser, lexer.TokenKind[lexer.EQUALS])
+	_, err = expect(parser, lexer.EQUALS)
 	if err != nil {
 		return nil, err
 	}
@@ -1241,7 +1478,7 @@ func parseUnionMembers(parser *Parser) ([]*ast.UnionMemberDefinition, error) {
 			t,
 			annotations,
 		))
-		if skp, err := skip(parser, lexer.TokenKind[lexer.PIPE]); err != nil {
+		if skp, err := skip(parser, lexer.PIPE); err != nil {
 			return nil, err
 		} else if !skp {
 			break
@@ -1271,19 +1508,10 @@ func parseEnumDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	iEnumValueDefs, err := reverse(parser,
-		lexer.TokenKind[lexer.BRACE_L], parseEnumValueDefinition, lexer.TokenKind[lexer.BRACE_R],
-		false,
-	)
+	values, err := list[*ast.EnumValueDefinition](parser, lexer.BRACE_L, parseEnumValueDefinition, lexer.BRACE_R)
 	if err != nil {
 		return nil, err
 	}
-	values := []*ast.EnumValueDefinition{}
-	for _, iEnumValueDef := range iEnumValueDefs {
-		if iEnumValueDef != nil {
-			values = append(values, iEnumValueDef.(*ast.EnumValueDefinition))
-		}
-	}
 	return ast.NewEnumDefinition(
 		loc(parser, start),
 		name,
@@ -1308,11 +1536,11 @@ func parseEnumValueDefinition(parser *Parser) (ast.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	_, err = expect(parser, lexer.TokenKind[lexer.EQUALS])
+	_, err = expect(parser, lexer.EQUALS)
 	if err != nil {
 		return nil, err
 	}
-	token, err := expect(parser, lexer.TokenKind[lexer.INT])
+	token, err := expect(parser, lexer.INT)
 	if err != nil {
 		return nil, err
 	}
@@ -1363,7 +1591,7 @@ func parseDirectiveDefinition(parser *Parser) (ast.Node, error) {
 	if _, err = expectKeyWord(parser, lexer.DIRECTIVE); err != nil {
 		return nil, err
 	}
-	if _, err = expect(parser, lexer.TokenKind[lexer.AT]); err != nil {
+	if _, err = expect(parser, lexer.AT); err != nil {
 		return nil, err
 	}
 	if name, err = parseName(parser); err != nil {
@@ -1409,7 +1637,7 @@ func parseDirectiveLocations(parser *Parser) ([]*ast.Name, error) {
 			locations = append(locations, name)
 		}
 
-		if hasPipe, err := skip(parser, lexer.TokenKind[lexer.PIPE]); err != nil {
+		if hasPipe, err := skip(parser, lexer.PIPE); err != nil {
 			return locations, err
 		} else if !hasPipe {
 			break
@@ -1421,7 +1649,7 @@ func parseDirectiveLocations(parser *Parser) ([]*ast.Name, error) {
 func parseDirectiveRequires(parser *Parser) ([]*ast.DirectiveRequire, error) {
 	requires := []*ast.DirectiveRequire{}
 	for {
-		token, err := expect(parser, lexer.TokenKind[lexer.AT])
+		token, err := expect(parser, lexer.AT)
 		if err != nil {
 			return requires, err
 		}
@@ -1441,7 +1669,7 @@ func parseDirectiveRequires(parser *Parser) ([]*ast.DirectiveRequire, error) {
 			locations,
 		))
 
-		if hasPipe, err := skip(parser, lexer.TokenKind[lexer.PIPE]); err != nil {
+		if hasPipe, err := skip(parser, lexer.PIPE); err != nil {
 			return requires, err
 		} else if !hasPipe {
 			break
@@ -1480,22 +1708,31 @@ func loc(parser *Parser, start uint) *ast.Location {
 		return nil
 	}
 	if parser.Options.NoSource {
-		return ast.NewLocation(
-			start,
-			parser.PrevEnd,
-			nil,
-		)
+		return parser.alloc.location(start, parser.PrevEnd, nil)
 	}
-	return ast.NewLocation(
-		start,
-		parser.PrevEnd,
-		parser.Source,
-	)
+	return parser.alloc.location(start, parser.PrevEnd, parser.Source)
+}
+
+// span returns the location from start to end, such as that of a part of
+// a token.
+func span(parser *Parser, start, end uint) *ast.Location {
+	if parser.Options.NoLocation {
+		return nil
+	}
+	if parser.Options.NoSource {
+		return parser.alloc.location(start, end, nil)
+	}
+	return parser.alloc.location(start, end, parser.Source)
 }
 
 // Moves the internal parser object to the next lexed token.
 func advance(parser *Parser) error {
 	parser.PrevEnd = parser.Token.End
+	if parser.hasAhead {
+		parser.Token = parser.ahead
+		parser.hasAhead = false
+		return nil
+	}
 	token, err := parser.LexToken(parser.PrevEnd)
 	if err != nil {
 		return err
@@ -1506,7 +1743,15 @@ func advance(parser *Parser) error {
 
 // lookahead retrieves the next token
 func lookahead(parser *Parser) (lexer.Token, error) {
-	return parser.LexToken(parser.Token.End)
+	if !parser.hasAhead {
+		token, err := parser.LexToken(parser.Token.End)
+		if err != nil {
+			return token, err
+		}
+		parser.ahead = token
+		parser.hasAhead = true
+	}
+	return parser.ahead, nil
 }
 
 // Determines if the next token is of a given kind
@@ -1535,7 +1780,7 @@ func expect(parser *Parser, kind int) (lexer.Token, error) {
 	if token.Kind == kind {
 		return token, advance(parser)
 	}
-	descp := fmt.Sprintf("Expected %s, found %s", lexer.GetTokenKindDesc(kind), lexer.GetTokenDesc(token))
+	descp := "Expected " + lexer.GetTokenKindDesc(kind) + ", found " + lexer.GetTokenDesc(token)
 	return token, errors.NewSyntaxError(parser.Source, token.Start, descp)
 }
 
@@ -1551,16 +1796,16 @@ func optional(parser *Parser, kind int) (lexer.Token, bool, error) {
 // advancing the parser. Otherwise, do not change the parser state and return false.
 func expectKeyWord(parser *Parser, value string) (lexer.Token, error) {
 	token := parser.Token
-	if token.Kind == lexer.TokenKind[lexer.NAME] && token.Value == value {
+	if token.Kind == lexer.NAME && token.Value == value {
 		return token, advance(parser)
 	}
-	descp := fmt.Sprintf("Expected \"%s\", found %s", value, lexer.GetTokenDesc(token))
+	descp := `Expected "` + value + `", found ` + lexer.GetTokenDesc(token)
 	return token, errors.NewSyntaxError(parser.Source, token.Start, descp)
 }
 
 func optionalKeyWord(parser *Parser, value string) (lexer.Token, bool, error) {
 	token := parser.Token
-	if token.Kind == lexer.TokenKind[lexer.NAME] && token.Value == value {
+	if token.Kind == lexer.NAME && token.Value == value {
 		return token, true, advance(parser)
 	}
 	return token, false, nil
@@ -1573,7 +1818,7 @@ func unexpected(parser *Parser, atToken lexer.Token) error {
 	if (atToken == lexer.Token{}) {
 		token = parser.Token
 	}
-	description := fmt.Sprintf("Unexpected %v", lexer.GetTokenDesc(token))
+	description := "Unexpected " + lexer.GetTokenDesc(token)
 	return errors.NewSyntaxError(parser.Source, token.Start, description)
 }
 
@@ -1581,13 +1826,17 @@ func unexpected(parser *Parser, atToken lexer.Token) error {
 // the parseFn. This list begins with a lex token of openKind
 // and ends with a lex token of closeKind. Advances the parser
 // to the next lex token after the closing token.
-// if zinteger is true, len(nodes) > 0
-func reverse(parser *Parser, openKind int, parseFn parseFn, closeKind int, zinteger bool) ([]interface{}, error) {
+func list[T ast.Node](parser *Parser, openKind int, parseFn parseFn, closeKind int) ([]T, error) {
 	_, err := expect(parser, openKind)
 	if err != nil {
 		return nil, err
 	}
-	var nodes []interface{}
+	// Nodes are collected on a stack shared by nested lists so that each
+	// list is allocated once at its final size.
+	start := len(parser.listStack)
+	defer func() {
+		parser.listStack = parser.listStack[:start]
+	}()
 	for {
 		if skp, err := skip(parser, closeKind); err != nil {
 			return nil, err
@@ -1596,12 +1845,19 @@ func reverse(parser *Parser, openKind int, parseFn parseFn, closeKind int, zinte
 		}
 		node, err := parseFn(parser)
 		if err != nil {
-			return nodes, err
+			return collect[T](parser.listStack[start:]), err
+		}
+		if node != nil {
+			parser.listStack = append(parser.listStack, node)
 		}
-		nodes = append(nodes, node)
 	}
-	// if zinteger && len(nodes) == 0 {
-	// 	return nodes, unexpectedEmpty(parser, token.Start, openKind, closeKind)
-	// }
-	return nodes, nil
+	return collect[T](parser.listStack[start:]), nil
+}
+
+func collect[T ast.Node](nodes []ast.Node) []T {
+	collected := make([]T, len(nodes))
+	for i, node := range nodes {
+		collected[i] = node.(T)
+	}
+	return collected
 }
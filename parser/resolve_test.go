@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+)
+
+const siblingsSpec = `namespace "test"
+
+import * from "a"
+import * from "b"
+import * from "c"
+`
+
+// typeOf returns a source defining a type named after location.
+func typeOf(location string) string {
+	return fmt.Sprintf("type %s { id: string }\n", strings.ToUpper(location))
+}
+
+// typeNames returns the names of the types of doc in order.
+func typeNames(doc *ast.Document) string {
+	var names []string
+	for _, def := range doc.Definitions {
+		if t, ok := def.(*ast.TypeDefinition); ok {
+			names = append(names, t.Name.Value)
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// contextResolver adapts a function to ContextResolver.
+type contextResolver func(ctx context.Context, location, from string) (string, error)
+
+func (r contextResolver) Resolve(ctx context.Context, location, from string) (string, error) {
+	return r(ctx, location, from)
+}
+
+func TestResolverOneAtATime(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	active, most := 0, 0
+	resolver := func(location, from string) (string, error) {
+		mu.Lock()
+		calls = append(calls, location)
+		active++
+		if active > most {
+			most = active
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		active--
+		mu.Unlock()
+		return typeOf(location), nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for _, options := range []parser.ParseOptions{
+		{Resolver: resolver},
+		{ContextResolver: parser.Resolver(resolver)},
+	} {
+		calls, most = nil, 0
+		doc, err := parser.ParseContext(ctx, parser.ParseParams{
+			Source:  siblingsSpec,
+			Options: options,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(calls, " "); got != "a b c" {
+			t.Errorf("calls = %q, want in source order", got)
+		}
+		if most != 1 {
+			t.Errorf("%d concurrent calls, want 1", most)
+		}
+		if got := typeNames(doc); got != "A B C" {
+			t.Errorf("types = %q, want A B C", got)
+		}
+	}
+}
+
+func TestResolverCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, err := parser.ParseContext(ctx, parser.ParseParams{
+		Source: siblingsSpec,
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				calls++
+				// The imports after the first are not resolved.
+				cancel()
+				return typeOf(location), nil
+			},
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want %v", err, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("%d calls, want 1", calls)
+	}
+}
+
+func TestContextResolverConcurrent(t *testing.T) {
+	// Each resolution waits for all three to start, so the imports must be
+	// resolved concurrently.
+	var started sync.WaitGroup
+	started.Add(3)
+	all := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(all)
+	}()
+	resolver := contextResolver(func(ctx context.Context, location, from string) (string, error) {
+		started.Done()
+		select {
+		case <-all:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		// Finish in reverse source order.
+		time.Sleep(time.Duration('c'-location[0]) * time.Millisecond)
+		return typeOf(location), nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	doc, err := parser.ParseContext(ctx, parser.ParseParams{
+		Source:  siblingsSpec,
+		Options: parser.ParseOptions{ContextResolver: resolver},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := typeNames(doc); got != "A B C" {
+		t.Errorf("types = %q, want A B C", got)
+	}
+}
+
+func TestContextResolverErrors(t *testing.T) {
+	errA := errors.New("a failed")
+	resolver := contextResolver(func(ctx context.Context, location, from string) (string, error) {
+		switch location {
+		case "a":
+			// Fails after b, which does not cancel a.
+			time.Sleep(10 * time.Millisecond)
+			return "", errA
+		case "b":
+			return "", errors.New("b failed")
+		}
+		// c is canceled by b.
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	_, err := parser.ParseContext(context.Background(), parser.ParseParams{
+		Source:  siblingsSpec,
+		Options: parser.ParseOptions{ContextResolver: resolver},
+	})
+	if err != errA {
+		t.Errorf("error = %v, want the error of the first import", err)
+	}
+}
+
+func TestContextResolverDeadline(t *testing.T) {
+	resolver := contextResolver(func(ctx context.Context, location, from string) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := parser.ParseContext(ctx, parser.ParseParams{
+		Source:  siblingsSpec,
+		Options: parser.ParseOptions{ContextResolver: resolver},
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
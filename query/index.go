@@ -0,0 +1,278 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package query
+
+import (
+	"sort"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+// Reference is a use of a definition's name.
+type Reference struct {
+	// Node is the *ast.Named, *ast.Annotation or *ast.DirectiveRequire
+	// that refers to the definition.
+	Node ast.Node
+	// Name is the referring name within Node.
+	Name *ast.Name
+}
+
+// Index resolves names in a document. Documents parsed with a resolver
+// contain the definitions of their imports, so definitions and references
+// span the imported sources as well.
+type Index struct {
+	doc        *ast.Document
+	named      map[string]ast.Node
+	directives map[string]*ast.DirectiveDefinition
+	imports    map[*ast.ImportName]ast.Node
+	importedBy map[ast.Node]*ast.ImportName
+	importDefs map[*ast.ImportName]*ast.ImportDefinition
+	qualified  map[string]map[string]ast.Node
+	references map[ast.Node][]Reference
+}
+
+// NewIndex indexes the definitions and references in doc.
+func NewIndex(doc *ast.Document) *Index {
+	idx := Index{
+		doc:        doc,
+		named:      make(map[string]ast.Node),
+		directives: make(map[string]*ast.DirectiveDefinition),
+		imports:    make(map[*ast.ImportName]ast.Node),
+		importedBy: make(map[ast.Node]*ast.ImportName),
+		importDefs: make(map[*ast.ImportName]*ast.ImportDefinition),
+		qualified:  make(map[string]map[string]ast.Node),
+		references: make(map[ast.Node][]Reference),
+	}
+
+	names := make(map[*ast.Name]ast.Node)
+	for _, def := range doc.Definitions {
+		var name *ast.Name
+		switch v := def.(type) {
+		case *ast.TypeDefinition:
+			name = v.Name
+		case *ast.InterfaceDefinition:
+			name = v.Name
+		case *ast.AliasDefinition:
+			name = v.Name
+		case *ast.UnionDefinition:
+			name = v.Name
+		case *ast.EnumDefinition:
+			name = v.Name
+		case *ast.DirectiveDefinition:
+			if _, exists := idx.directives[v.Name.Value]; !exists {
+				idx.directives[v.Name.Value] = v
+			}
+			names[v.Name] = v
+			continue
+		default:
+			continue
+		}
+		if _, exists := idx.named[name.Value]; !exists {
+			idx.named[name.Value] = def
+		}
+		names[name] = def
+	}
+
+	// Selective imports define their names with the import's name nodes.
+	// Namespace imports define the names of the imported document under
+	// their alias.
+	for _, def := range doc.Definitions {
+		if imp, ok := def.(*ast.ImportDefinition); ok {
+			if imp.Alias != nil && imp.Document != nil {
+				if _, exists := idx.qualified[imp.Alias.Value]; !exists {
+					qualified := make(map[string]ast.Node)
+					for name, d := range ast.NamedDefinitions(imp.Document) {
+						qualified[name] = d
+					}
+					idx.qualified[imp.Alias.Value] = qualified
+				}
+			}
+			for _, n := range imp.Names {
+				name := n.Alias
+				if name == nil {
+					name = n.Name
+				}
+				if d, ok := names[name]; ok {
+					idx.imports[n] = d
+					idx.importedBy[d] = n
+					idx.importDefs[n] = imp
+				}
+			}
+		}
+	}
+
+	Inspect(doc, func(path Path) bool {
+		var ref Reference
+		switch n := path.Node().(type) {
+		case *ast.Named:
+			ref = Reference{Node: n, Name: n.Name}
+		case *ast.Annotation:
+			ref = Reference{Node: n, Name: n.Name}
+		case *ast.DirectiveRequire:
+			ref = Reference{Node: n, Name: n.Directive}
+		default:
+			return true
+		}
+		if def := idx.DefinitionOf(ref.Node); def != nil {
+			idx.references[def] = append(idx.references[def], ref)
+		}
+		return true
+	})
+
+	return &idx
+}
+
+// Document returns the indexed document.
+func (idx *Index) Document() *ast.Document {
+	return idx.doc
+}
+
+// Lookup returns the type, interface, alias, union or enum named name.
+func (idx *Index) Lookup(name string) ast.Node {
+	return idx.named[name]
+}
+
+// LookupDirective returns the directive named name.
+func (idx *Index) LookupDirective(name string) *ast.DirectiveDefinition {
+	return idx.directives[name]
+}
+
+// Named returns the names of the types, interfaces, aliases, unions and
+// enums in sorted order.
+func (idx *Index) Named() []string {
+	names := make([]string, 0, len(idx.named))
+	for name := range idx.named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Directives returns the names of the directives in sorted order.
+func (idx *Index) Directives() []string {
+	names := make([]string, 0, len(idx.directives))
+	for name := range idx.directives {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefinitionOf returns the definition that node refers to. node may be an
+// *ast.Named, *ast.Annotation, *ast.DirectiveRequire or *ast.ImportName.
+// Definitions are returned as is. The result is nil when the name is
+// unknown or is a built-in type.
+func (idx *Index) DefinitionOf(node ast.Node) ast.Node {
+	switch n := node.(type) {
+	case *ast.Named:
+		if n.Namespace != nil {
+			return idx.qualified[n.Namespace.Value][n.Name.Value]
+		}
+		return idx.named[n.Name.Value]
+	case *ast.Annotation:
+		if d, ok := idx.directives[n.Name.Value]; ok {
+			return d
+		}
+	case *ast.DirectiveRequire:
+		if d, ok := idx.directives[n.Directive.Value]; ok {
+			return d
+		}
+	case *ast.ImportName:
+		return idx.imports[n]
+	case *ast.TypeDefinition, *ast.InterfaceDefinition, *ast.AliasDefinition,
+		*ast.UnionDefinition, *ast.EnumDefinition, *ast.DirectiveDefinition:
+		return n
+	}
+	return nil
+}
+
+// ImportOf returns the selective import that defines def, or nil when def
+// is declared by a definition of its own.
+func (idx *Index) ImportOf(def ast.Node) (*ast.ImportDefinition, *ast.ImportName) {
+	n, ok := idx.importedBy[def]
+	if !ok {
+		return nil, nil
+	}
+	return idx.importDefs[n], n
+}
+
+// DefinitionAt returns the definition whose name is at offset, either
+// where it is declared or where it is referenced, along with the path to
+// the declaring or referring node.
+func (idx *Index) DefinitionAt(offset uint) (ast.Node, Path) {
+	path := NodeAt(idx.doc, offset)
+	name, ok := path.Node().(*ast.Name)
+	if !ok {
+		return nil, path
+	}
+	parent := path[:len(path)-1]
+	switch n := parent.Node().(type) {
+	case *ast.DirectiveRequire:
+		if n.Directive != name {
+			return nil, path
+		}
+	case *ast.Named:
+		if n.Name != name {
+			return nil, path
+		}
+	case *ast.Annotation, *ast.ImportName:
+	default:
+		if NameOf(n) != name {
+			return nil, path
+		}
+	}
+	if def := idx.DefinitionOf(parent.Node()); def != nil {
+		return def, parent
+	}
+	return nil, path
+}
+
+// ReferencesTo returns the references to def in source order of the
+// document, including references from imported definitions.
+func (idx *Index) ReferencesTo(def ast.Node) []Reference {
+	return idx.references[def]
+}
+
+// NameOf returns the name of a definition or member, or nil for nodes
+// without a name.
+func NameOf(node ast.Node) *ast.Name {
+	switch n := node.(type) {
+	case *ast.NamespaceDefinition:
+		return n.Name
+	case *ast.DirectiveDefinition:
+		return n.Name
+	case *ast.AliasDefinition:
+		return n.Name
+	case *ast.InterfaceDefinition:
+		return n.Name
+	case *ast.OperationDefinition:
+		return n.Name
+	case *ast.ParameterDefinition:
+		return n.Name
+	case *ast.TypeDefinition:
+		return n.Name
+	case *ast.FieldDefinition:
+		return n.Name
+	case *ast.UnionDefinition:
+		return n.Name
+	case *ast.EnumDefinition:
+		return n.Name
+	case *ast.EnumValueDefinition:
+		return n.Name
+	}
+	return nil
+}
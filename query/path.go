@@ -0,0 +1,284 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package query answers position-based and semantic questions about a
+// parsed Apex document, such as which node is at an offset and where a
+// type is defined or referenced.
+package query
+
+import (
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/kinds"
+)
+
+// Path is a node and its ancestors, outermost first. The first element of
+// a path returned by NodeAt is the document.
+type Path []ast.Node
+
+// Node returns the innermost node of p.
+func (p Path) Node() ast.Node {
+	if len(p) == 0 {
+		return nil
+	}
+	return p[len(p)-1]
+}
+
+// Parent returns the parent of the innermost node of p.
+func (p Path) Parent() ast.Node {
+	if len(p) < 2 {
+		return nil
+	}
+	return p[len(p)-2]
+}
+
+// Enclosing returns the innermost node of p, including p.Node(), that has
+// one of kinds.
+func (p Path) Enclosing(kinds ...kinds.Kind) ast.Node {
+	for i := len(p) - 1; i >= 0; i-- {
+		for _, kind := range kinds {
+			if p[i].IsKind(kind) {
+				return p[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Definition returns the top level definition that contains p.Node().
+func (p Path) Definition() ast.Node {
+	for i, n := range p {
+		if _, ok := n.(*ast.Document); ok {
+			if i+1 < len(p) {
+				return p[i+1]
+			}
+			return nil
+		}
+	}
+	if len(p) > 0 {
+		return p[0]
+	}
+	return nil
+}
+
+// Children returns the child nodes of node in source order.
+func Children(node ast.Node) []ast.Node {
+	return appendChildren(nil, node)
+}
+
+// appendChildren appends the child nodes of node to dst.
+func appendChildren(dst []ast.Node, node ast.Node) []ast.Node {
+	c := children(dst)
+	switch n := node.(type) {
+	case *ast.Document:
+		for _, def := range n.Definitions {
+			c.add(def)
+		}
+
+	case *ast.NamespaceDefinition:
+		c.description(n.Description)
+		c.name(n.Name)
+		c.annotations(n.Annotations)
+	case *ast.ImportDefinition:
+		c.description(n.Description)
+		for _, name := range n.Names {
+			c.add(name)
+		}
+		c.name(n.Alias)
+		c.description(n.From)
+		c.annotations(n.Annotations)
+	case *ast.ImportName:
+		c.name(n.Name)
+		c.name(n.Alias)
+	case *ast.DirectiveDefinition:
+		c.description(n.Description)
+		c.name(n.Name)
+		c.parameters(n.Parameters)
+		for _, l := range n.Locations {
+			c.name(l)
+		}
+		for _, r := range n.Requires {
+			c.add(r)
+		}
+	case *ast.DirectiveRequire:
+		c.name(n.Directive)
+		for _, l := range n.Locations {
+			c.name(l)
+		}
+	case *ast.AliasDefinition:
+		c.description(n.Description)
+		c.name(n.Name)
+		c.add(n.Type)
+		c.annotations(n.Annotations)
+	case *ast.InterfaceDefinition:
+		c.description(n.Description)
+		c.name(n.Name)
+		c.annotations(n.Annotations)
+		for _, oper := range n.Operations {
+			c.add(oper)
+		}
+	case *ast.OperationDefinition:
+		c.description(n.Description)
+		c.name(n.Name)
+		c.parameters(n.Parameters)
+		c.add(n.Type)
+		c.annotations(n.Annotations)
+	case *ast.ParameterDefinition:
+		c.description(n.Description)
+		c.name(n.Name)
+		c.add(n.Type)
+		c.add(n.Default)
+		c.annotations(n.Annotations)
+	case *ast.TypeDefinition:
+		c.description(n.Description)
+		c.name(n.Name)
+		for _, iface := range n.Interfaces {
+			c.add(iface)
+		}
+		c.annotations(n.Annotations)
+		for _, field := range n.Fields {
+			c.add(field)
+		}
+	case *ast.FieldDefinition:
+		c.description(n.Description)
+		c.name(n.Name)
+		c.add(n.Type)
+		c.add(n.Default)
+		c.annotations(n.Annotations)
+	case *ast.UnionDefinition:
+		c.description(n.Description)
+		c.name(n.Name)
+		c.annotations(n.Annotations)
+		for _, m := range n.Members {
+			c.add(m)
+		}
+	case *ast.UnionMemberDefinition:
+		c.description(n.Description)
+		c.add(n.Type)
+		c.annotations(n.Annotations)
+	case *ast.EnumDefinition:
+		c.description(n.Description)
+		c.name(n.Name)
+		c.annotations(n.Annotations)
+		for _, v := range n.Values {
+			c.add(v)
+		}
+	case *ast.EnumValueDefinition:
+		c.description(n.Description)
+		c.name(n.Name)
+		if n.Index != nil {
+			c.add(n.Index)
+		}
+		c.annotations(n.Annotations)
+		c.description(n.Display)
+
+	case *ast.Annotation:
+		c.name(n.Name)
+		for _, arg := range n.Arguments {
+			c.add(arg)
+		}
+	case *ast.Argument:
+		c.name(n.Name)
+		c.add(n.Value)
+
+	case *ast.Named:
+		c.name(n.Namespace)
+		c.name(n.Name)
+	case *ast.ListType:
+		c.add(n.Type)
+	case *ast.Optional:
+		c.add(n.Type)
+	case *ast.Stream:
+		c.add(n.Type)
+	case *ast.MapType:
+		c.add(n.KeyType)
+		c.add(n.ValueType)
+
+	case *ast.ListValue:
+		for _, v := range n.Values {
+			c.add(v)
+		}
+	case *ast.ObjectValue:
+		for _, f := range n.Fields {
+			c.add(f)
+		}
+	case *ast.ObjectField:
+		c.name(n.Name)
+		c.add(n.Value)
+	}
+	return c
+}
+
+type children []ast.Node
+
+// add appends n unless it is a nil interface. Callers check optional
+// pointer fields themselves.
+func (c *children) add(n ast.Node) {
+	if n != nil {
+		*c = append(*c, n)
+	}
+}
+
+func (c *children) name(n *ast.Name) {
+	if n != nil {
+		*c = append(*c, n)
+	}
+}
+
+func (c *children) description(n *ast.StringValue) {
+	if n != nil {
+		*c = append(*c, n)
+	}
+}
+
+func (c *children) annotations(annotations []*ast.Annotation) {
+	for _, a := range annotations {
+		*c = append(*c, a)
+	}
+}
+
+func (c *children) parameters(params []*ast.ParameterDefinition) {
+	for _, p := range params {
+		*c = append(*c, p)
+	}
+}
+
+// Inspect traverses node and its descendants depth first, calling f with
+// the path to each node. Children are skipped when f returns false. The
+// path is reused between calls and must be copied to be retained.
+func Inspect(node ast.Node, f func(path Path) bool) {
+	in := inspector{f: f}
+	in.inspect(Path{node})
+}
+
+// inspector keeps the children of the nodes being traversed on a shared
+// stack so that traversals do not allocate for every node.
+type inspector struct {
+	f     func(path Path) bool
+	stack []ast.Node
+}
+
+func (in *inspector) inspect(path Path) {
+	if !in.f(path) {
+		return
+	}
+	start := len(in.stack)
+	in.stack = appendChildren(in.stack, path.Node())
+	end := len(in.stack)
+	for i := start; i < end; i++ {
+		in.inspect(append(path, in.stack[i]))
+	}
+	in.stack = in.stack[:start]
+}
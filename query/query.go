@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package query
+
+import (
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/source"
+)
+
+// NodeAt returns the path to the innermost node of doc's own source that
+// contains the byte offset. Definitions inlined from imported sources are
+// not considered. A node also contains the offset just past its end so
+// that a cursor placed after a name finds it. The path is empty when doc
+// has no locations.
+func NodeAt(doc *ast.Document, offset uint) Path {
+	loc := doc.GetLoc()
+	if loc == nil {
+		return nil
+	}
+	return nodeAt(Path{doc}, loc.Source, offset)
+}
+
+func nodeAt(path Path, src *source.Source, offset uint) Path {
+	children := Children(path.Node())
+	if _, ok := path.Node().(*ast.Document); ok {
+		// Selectively imported definitions are located at their names in
+		// the import statement. Prefer the import itself.
+		imports := make([]ast.Node, 0, len(children))
+		for _, child := range children {
+			if _, ok := child.(*ast.ImportDefinition); ok {
+				imports = append(imports, child)
+			}
+		}
+		children = append(imports, children...)
+	}
+
+	var touching ast.Node
+	for _, child := range children {
+		loc := child.GetLoc()
+		if loc == nil || loc.Source != src {
+			continue
+		}
+		if loc.Start <= offset && offset < loc.End {
+			return nodeAt(append(path, child), src, offset)
+		}
+		if offset == loc.End && touching == nil {
+			touching = child
+		}
+	}
+	if touching != nil {
+		return nodeAt(append(path, touching), src, offset)
+	}
+	return path
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package query_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/kinds"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/query"
+)
+
+const spec = `namespace "test"
+
+import { Person as Human } from "people"
+import * as p from "people"
+
+directive @auth(role: string) on OPERATION
+
+type User {
+  id: string
+  friend: User?
+  owner: p.Person
+  human: Human
+}
+
+interface Users {
+  get(id: string): User @auth(role: "admin")
+}
+`
+
+const people = `namespace "people"
+
+type Person { user: Person }
+`
+
+func parse(t *testing.T, src string) *ast.Document {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: src,
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				return people, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+// offset returns the byte offset of the n'th occurrence of substr in src.
+func offset(t *testing.T, src, substr string, n int) uint {
+	t.Helper()
+	off := 0
+	for i := 0; i <= n; i++ {
+		idx := strings.Index(src[off:], substr)
+		if idx < 0 {
+			t.Fatalf("%q not found", substr)
+		}
+		if i < n {
+			off += idx + len(substr)
+		} else {
+			off += idx
+		}
+	}
+	return uint(off)
+}
+
+func pathKinds(path query.Path) string {
+	parts := make([]string, len(path))
+	for i, n := range path {
+		parts[i] = string(n.GetKind())
+	}
+	return strings.Join(parts, " > ")
+}
+
+func TestNodeAt(t *testing.T) {
+	doc := parse(t, spec)
+	tests := []struct {
+		name   string
+		offset uint
+		want   string
+	}{
+		{
+			name:   "field type",
+			offset: offset(t, spec, "User?", 0) + 1,
+			want:   "Document > TypeDefinition > FieldDefinition > Optional > Named > Name",
+		},
+		{
+			name:   "end of name",
+			offset: offset(t, spec, "friend", 0) + uint(len("friend")),
+			want:   "Document > TypeDefinition > FieldDefinition > Name",
+		},
+		{
+			name:   "parameter",
+			offset: offset(t, spec, "id: string)", 0),
+			want:   "Document > InterfaceDefinition > OperationDefinition > ParameterDefinition > Name",
+		},
+		{
+			name:   "annotation argument",
+			offset: offset(t, spec, `"admin"`, 0),
+			want:   "Document > InterfaceDefinition > OperationDefinition > Annotation > Argument > StringValue",
+		},
+		{
+			name:   "selective import",
+			offset: offset(t, spec, "Human", 0),
+			want:   "Document > ImportDefinition > ImportName > Name",
+		},
+		{
+			name:   "between definitions",
+			offset: offset(t, spec, "\n\ninterface", 0) + 1,
+			want:   "Document",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pathKinds(query.NodeAt(doc, tt.offset)); got != tt.want {
+				t.Errorf("path = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	doc := parse(t, spec)
+	path := query.NodeAt(doc, offset(t, spec, "id: string)", 0))
+	if _, ok := path.Node().(*ast.Name); !ok {
+		t.Fatalf("node = %T, want *ast.Name", path.Node())
+	}
+	if _, ok := path.Parent().(*ast.ParameterDefinition); !ok {
+		t.Errorf("parent = %T, want *ast.ParameterDefinition", path.Parent())
+	}
+	op, ok := path.Enclosing(kinds.OperationDefinition, kinds.TypeDefinition).(*ast.OperationDefinition)
+	if !ok || op.Name.Value != "get" {
+		t.Errorf("enclosing = %v, want operation get", path.Enclosing(kinds.OperationDefinition))
+	}
+	if path.Enclosing(kinds.TypeDefinition) != nil {
+		t.Error("found an enclosing type definition")
+	}
+	iface, ok := path.Definition().(*ast.InterfaceDefinition)
+	if !ok || iface.Name.Value != "Users" {
+		t.Errorf("definition = %v, want interface Users", path.Definition())
+	}
+	if got := (query.Path{}).Node(); got != nil {
+		t.Errorf("empty path node = %v", got)
+	}
+}
+
+func TestIndexDefinitionAt(t *testing.T) {
+	doc := parse(t, spec)
+	idx := query.NewIndex(doc)
+	tests := []struct {
+		name   string
+		offset uint
+		want   string
+	}{
+		{"declaration", offset(t, spec, "User", 0), "User"},
+		{"reference", offset(t, spec, "User?", 0), "User"},
+		{"qualified", offset(t, spec, "Person", 1), "Person"},
+		{"import alias", offset(t, spec, "Human", 0), "Human"},
+		{"imported reference", offset(t, spec, "Human", 1), "Human"},
+		{"annotation", offset(t, spec, "auth", 1), "auth"},
+		{"built-in type", offset(t, spec, "string", 1), ""},
+		{"field name", offset(t, spec, "friend", 0), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, _ := idx.DefinitionAt(tt.offset)
+			var got string
+			if def != nil {
+				got = query.NameOf(def).Value
+			}
+			if got != tt.want {
+				t.Errorf("definition = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexReferencesTo(t *testing.T) {
+	doc := parse(t, spec)
+	idx := query.NewIndex(doc)
+
+	user := idx.Lookup("User")
+	if user == nil {
+		t.Fatal("User not found")
+	}
+	if got := len(idx.ReferencesTo(user)); got != 2 {
+		t.Errorf("references to User = %d, want 2", got)
+	}
+
+	// The selectively imported Person is defined under its alias.
+	human := idx.Lookup("Human")
+	if human == nil {
+		t.Fatal("Human not found")
+	}
+	if got := len(idx.ReferencesTo(human)); got != 1 {
+		t.Errorf("references to Human = %d, want 1", got)
+	}
+
+	// The namespace imported Person is referenced through its qualifier.
+	owner := query.NodeAt(doc, offset(t, spec, "Person", 1)).Parent()
+	person := idx.DefinitionOf(owner)
+	if person == nil {
+		t.Fatal("p.Person not found")
+	}
+	if refs := idx.ReferencesTo(person); len(refs) != 1 || refs[0].Node != owner {
+		t.Errorf("references to p.Person = %v, want the owner field type", refs)
+	}
+	imp, name := idx.ImportOf(human)
+	if imp == nil || name.Name.Value != "Person" || name.Alias.Value != "Human" {
+		t.Errorf("import of Human = %v, %v", imp, name)
+	}
+	if imp, _ := idx.ImportOf(user); imp != nil {
+		t.Errorf("import of User = %v", imp)
+	}
+
+	auth := idx.LookupDirective("auth")
+	if got := len(idx.ReferencesTo(auth)); got != 1 {
+		t.Errorf("references to auth = %d, want 1", got)
+	}
+}
+
+func TestIndexNames(t *testing.T) {
+	idx := query.NewIndex(parse(t, spec))
+	if got, want := strings.Join(idx.Named(), " "), "Human User Users"; got != want {
+		t.Errorf("named = %q, want %q", got, want)
+	}
+	if got, want := strings.Join(idx.Directives(), " "), "auth"; got != want {
+		t.Errorf("directives = %q, want %q", got, want)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	doc := parse(t, `namespace "test"
+type A { a: [B] }
+`)
+	var got []string
+	query.Inspect(doc, func(path query.Path) bool {
+		// Skip the children of the namespace.
+		got = append(got, string(path.Node().GetKind()))
+		return !path.Node().IsKind(kinds.NamespaceDefinition)
+	})
+	want := "Document NamespaceDefinition TypeDefinition Name FieldDefinition Name ListType Named Name"
+	if strings.Join(got, " ") != want {
+		t.Errorf("inspected %q, want %q", strings.Join(got, " "), want)
+	}
+}
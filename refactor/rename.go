@@ -0,0 +1,478 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package refactor computes source edits for refactorings of Apex
+// specifications.
+package refactor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/query"
+	"github.com/apexlang/apex-go/source"
+)
+
+// FileEdits are the edits to apply to one source.
+type FileEdits struct {
+	Source *source.Source
+	Edits  []source.Edit
+}
+
+// Apply returns the new body of each source, keyed by source name.
+func Apply(files []FileEdits) (map[string][]byte, error) {
+	bodies := make(map[string][]byte, len(files))
+	for _, f := range files {
+		body, err := source.ApplyEdits(f.Source.Body, f.Edits)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Source.Name, err)
+		}
+		bodies[f.Source.Name] = body
+	}
+	return bodies, nil
+}
+
+type targetKind int
+
+const (
+	targetNamed targetKind = iota
+	targetDirective
+	targetAlias
+	targetEnumValue
+	targetMember
+)
+
+// origin identifies a definition across documents by the source that
+// declares it and its declared name.
+type origin struct {
+	source string
+	name   string
+}
+
+type target struct {
+	kind    targetKind
+	origin  origin
+	oldName string
+
+	// name is the declaration of a member, or the alias of a selective
+	// import, being renamed.
+	name *ast.Name
+	// doc is the document of an aliased import.
+	doc *ast.Document
+	// siblings are the names that a member must not collide with.
+	siblings []*ast.Name
+}
+
+// Rename returns the edits that rename the type, interface, alias, union,
+// enum, directive, field, enum value or operation whose name is at offset
+// in the source named sourceName.
+//
+// docs are the parsed documents of a workspace, one per source, parsed with
+// a resolver and locator so that imported sources are named consistently.
+// References in every document are updated, including selective import
+// lists and enum values used in defaults and annotation arguments.
+// Renaming the alias of an `import { X as Y }` only affects the importing
+// document.
+func Rename(docs []*ast.Document, sourceName string, offset uint, newName string) ([]FileEdits, error) {
+	if !validName(newName) {
+		return nil, fmt.Errorf("%q is not a valid name", newName)
+	}
+	r := newRenamer(docs, newName)
+	doc, ok := r.docs[sourceName]
+	if !ok {
+		return nil, fmt.Errorf("%s is not in the workspace", sourceName)
+	}
+
+	t, err := r.target(doc, offset)
+	if err != nil {
+		return nil, err
+	}
+	if t.oldName == newName {
+		return nil, nil
+	}
+
+	switch t.kind {
+	case targetNamed, targetDirective:
+		for _, doc := range docs {
+			if err := r.renameDefinition(doc, t); err != nil {
+				return nil, err
+			}
+		}
+	case targetAlias:
+		idx := r.index(t.doc)
+		if idx.Lookup(newName) != nil {
+			return nil, fmt.Errorf("%q is already defined", newName)
+		}
+		def := idx.Lookup(t.oldName)
+		r.edit(t.name)
+		for _, ref := range idx.ReferencesTo(def) {
+			r.edit(ref.Name)
+		}
+	case targetEnumValue, targetMember:
+		for _, sibling := range t.siblings {
+			if sibling.Value == newName {
+				return nil, fmt.Errorf("%q is already defined", newName)
+			}
+		}
+		r.edit(t.name)
+		if t.kind == targetEnumValue {
+			for _, doc := range docs {
+				r.renameEnumValues(doc, t)
+			}
+		}
+	}
+
+	return r.result(), nil
+}
+
+// Prepare returns the name at offset in doc if Rename can rename it.
+func Prepare(doc *ast.Document, offset uint) (*ast.Name, error) {
+	r := newRenamer([]*ast.Document{doc}, "")
+	if _, err := r.target(doc, offset); err != nil {
+		return nil, err
+	}
+	return query.NodeAt(doc, offset).Node().(*ast.Name), nil
+}
+
+type renamer struct {
+	docs    map[string]*ast.Document
+	indexes map[*ast.Document]*query.Index
+	edits   map[string]map[uint]source.Edit
+	sources map[string]*source.Source
+	newName string
+}
+
+func newRenamer(docs []*ast.Document, newName string) *renamer {
+	r := renamer{
+		docs:    make(map[string]*ast.Document, len(docs)),
+		indexes: make(map[*ast.Document]*query.Index, len(docs)),
+		edits:   make(map[string]map[uint]source.Edit),
+		sources: make(map[string]*source.Source),
+		newName: newName,
+	}
+	for _, doc := range docs {
+		if loc := doc.GetLoc(); loc != nil && loc.Source != nil {
+			r.docs[loc.Source.Name] = doc
+		}
+	}
+	return &r
+}
+
+func (r *renamer) index(doc *ast.Document) *query.Index {
+	idx, ok := r.indexes[doc]
+	if !ok {
+		idx = query.NewIndex(doc)
+		r.indexes[doc] = idx
+	}
+	return idx
+}
+
+// target finds what to rename at offset in doc.
+func (r *renamer) target(doc *ast.Document, offset uint) (*target, error) {
+	idx := r.index(doc)
+	path := query.NodeAt(doc, offset)
+	name, ok := path.Node().(*ast.Name)
+	if !ok {
+		return nil, fmt.Errorf("no name at offset %d", offset)
+	}
+
+	if def, defPath := idx.DefinitionAt(offset); def != nil {
+		if n, ok := defPath.Node().(*ast.ImportName); ok && n.Alias == name {
+			return &target{kind: targetAlias, oldName: name.Value, name: name, doc: doc}, nil
+		}
+		kind := targetNamed
+		if _, ok := def.(*ast.DirectiveDefinition); ok {
+			kind = targetDirective
+		}
+		o := r.origin(doc, def)
+		return &target{kind: kind, origin: o, oldName: o.name}, nil
+	}
+
+	parent := path.Parent()
+	if query.NameOf(parent) != name {
+		return nil, fmt.Errorf("%q cannot be renamed", name.Value)
+	}
+	switch parent.(type) {
+	case *ast.EnumValueDefinition:
+		enum, ok := path[len(path)-3].(*ast.EnumDefinition)
+		if !ok {
+			break
+		}
+		t := target{kind: targetEnumValue, origin: r.origin(doc, enum), oldName: name.Value, name: name}
+		for _, v := range enum.Values {
+			t.siblings = append(t.siblings, v.Name)
+		}
+		return &t, nil
+	case *ast.FieldDefinition:
+		t := target{kind: targetMember, oldName: name.Value, name: name}
+		if def, ok := path[len(path)-3].(*ast.TypeDefinition); ok {
+			for _, f := range def.Fields {
+				t.siblings = append(t.siblings, f.Name)
+			}
+		}
+		return &t, nil
+	case *ast.OperationDefinition:
+		t := target{kind: targetMember, oldName: name.Value, name: name}
+		if iface, ok := path[len(path)-3].(*ast.InterfaceDefinition); ok {
+			for _, oper := range iface.Operations {
+				t.siblings = append(t.siblings, oper.Name)
+			}
+		} else {
+			for _, def := range doc.Definitions {
+				if oper, ok := def.(*ast.OperationDefinition); ok {
+					t.siblings = append(t.siblings, oper.Name)
+				}
+			}
+		}
+		return &t, nil
+	}
+	return nil, fmt.Errorf("%q cannot be renamed", name.Value)
+}
+
+// origin follows selective imports back to the source that declares def.
+func (r *renamer) origin(doc *ast.Document, def ast.Node) origin {
+	for depth := 0; depth < len(r.docs)+1; depth++ {
+		idx := r.index(doc)
+		imp, n := idx.ImportOf(def)
+		if imp == nil || imp.Source == nil {
+			break
+		}
+		o := origin{source: imp.Source.Name, name: n.Name.Value}
+		imported, ok := r.docs[o.source]
+		if !ok {
+			return o
+		}
+		next := lookup(r.index(imported), def, o.name)
+		if next == nil {
+			return o
+		}
+		doc, def = imported, next
+	}
+	name := query.NameOf(def)
+	o := origin{name: name.Value}
+	if loc := name.GetLoc(); loc != nil && loc.Source != nil {
+		o.source = loc.Source.Name
+	}
+	return o
+}
+
+// lookup finds the definition named name of the same category as def.
+func lookup(idx *query.Index, def ast.Node, name string) ast.Node {
+	if _, ok := def.(*ast.DirectiveDefinition); ok {
+		if d := idx.LookupDirective(name); d != nil {
+			return d
+		}
+		return nil
+	}
+	return idx.Lookup(name)
+}
+
+func (r *renamer) renameDefinition(doc *ast.Document, t *target) error {
+	idx := r.index(doc)
+	for _, def := range doc.Definitions {
+		switch def.(type) {
+		case *ast.DirectiveDefinition:
+			if t.kind != targetDirective {
+				continue
+			}
+		case *ast.TypeDefinition, *ast.InterfaceDefinition, *ast.AliasDefinition,
+			*ast.UnionDefinition, *ast.EnumDefinition:
+			if t.kind != targetNamed {
+				continue
+			}
+		default:
+			continue
+		}
+		if r.origin(doc, def) != t.origin {
+			continue
+		}
+
+		visible := true
+		if imp, n := idx.ImportOf(def); imp != nil {
+			r.edit(n.Name)
+			visible = n.Alias == nil
+		} else {
+			r.edit(query.NameOf(def))
+		}
+		if !visible {
+			continue
+		}
+		if conflict := lookup(idx, def, r.newName); conflict != nil && conflict != def {
+			return fmt.Errorf("%q is already defined in %s", r.newName, doc.GetLoc().Source.Name)
+		}
+		for _, ref := range idx.ReferencesTo(def) {
+			r.edit(ref.Name)
+		}
+	}
+
+	// Qualified references name definitions of namespaces imported with an
+	// alias. The definitions themselves are renamed in their own source.
+	if t.kind != targetNamed {
+		return nil
+	}
+	for _, def := range doc.Definitions {
+		imp, ok := def.(*ast.ImportDefinition)
+		if !ok || imp.Alias == nil || imp.Document == nil {
+			continue
+		}
+		for _, def := range ast.NamedDefinitions(imp.Document) {
+			if r.origin(imp.Document, def) != t.origin {
+				continue
+			}
+			for _, ref := range idx.ReferencesTo(def) {
+				r.edit(ref.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// renameEnumValues renames uses of the enum value t in field and parameter
+// defaults and annotation arguments.
+func (r *renamer) renameEnumValues(doc *ast.Document, t *target) {
+	idx := r.index(doc)
+	query.Inspect(doc, func(path query.Path) bool {
+		switch n := path.Node().(type) {
+		case *ast.FieldDefinition:
+			r.enumValue(idx, t, n.Default, n.Type)
+		case *ast.ParameterDefinition:
+			r.enumValue(idx, t, n.Default, n.Type)
+		case *ast.Annotation:
+			directive := idx.LookupDirective(n.Name.Value)
+			if directive == nil {
+				break
+			}
+			for _, arg := range n.Arguments {
+				if param := parameter(directive, arg.Name.Value); param != nil {
+					r.enumValue(idx, t, arg.Value, param.Type)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// parameter returns the directive parameter an argument is for. Unnamed
+// arguments are named "value" and apply to a sole parameter.
+func parameter(directive *ast.DirectiveDefinition, name string) *ast.ParameterDefinition {
+	for _, p := range directive.Parameters {
+		if p.Name.Value == name {
+			return p
+		}
+	}
+	if name == "value" && len(directive.Parameters) == 1 {
+		return directive.Parameters[0]
+	}
+	return nil
+}
+
+func (r *renamer) enumValue(idx *query.Index, t *target, value ast.Value, typ ast.Type) {
+	if value == nil {
+		return
+	}
+	for depth := 0; typ != nil && depth < 32; depth++ {
+		switch v := typ.(type) {
+		case *ast.Optional:
+			typ = v.Type
+			continue
+		case *ast.ListType:
+			if list, ok := value.(*ast.ListValue); ok {
+				for _, item := range list.Values {
+					r.enumValue(idx, t, item, v.Type)
+				}
+			}
+			return
+		case *ast.MapType:
+			if obj, ok := value.(*ast.ObjectValue); ok {
+				for _, f := range obj.Fields {
+					r.enumValue(idx, t, f.Value, v.ValueType)
+				}
+			}
+			return
+		case *ast.Named:
+			switch def := idx.DefinitionOf(v).(type) {
+			case *ast.AliasDefinition:
+				typ = def.Type
+				continue
+			case *ast.EnumDefinition:
+				ev, ok := value.(*ast.EnumValue)
+				if ok && ev.Value == t.oldName && r.origin(idx.Document(), def) == t.origin {
+					r.editValue(ev)
+				}
+			}
+		}
+		return
+	}
+}
+
+func (r *renamer) edit(name *ast.Name) {
+	r.add(name.GetLoc())
+}
+
+func (r *renamer) editValue(v *ast.EnumValue) {
+	r.add(v.GetLoc())
+}
+
+func (r *renamer) add(loc *ast.Location) {
+	if loc == nil || loc.Source == nil {
+		return
+	}
+	name := loc.Source.Name
+	edits, ok := r.edits[name]
+	if !ok {
+		edits = make(map[uint]source.Edit)
+		r.edits[name] = edits
+		r.sources[name] = loc.Source
+	}
+	edits[loc.Start] = source.Edit{Start: loc.Start, End: loc.End, NewText: r.newName}
+}
+
+func (r *renamer) result() []FileEdits {
+	names := make([]string, 0, len(r.edits))
+	for name := range r.edits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make([]FileEdits, 0, len(names))
+	for _, name := range names {
+		f := FileEdits{Source: r.sources[name]}
+		for _, e := range r.edits[name] {
+			f.Edits = append(f.Edits, e)
+		}
+		sort.Slice(f.Edits, func(i, j int) bool {
+			return f.Edits[i].Start < f.Edits[j].Start
+		})
+		files = append(files, f)
+	}
+	return files
+}
+
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refactor_test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/refactor"
+	"github.com/apexlang/apex-go/source"
+)
+
+var workspace = map[string]string{
+	"types.apex": `namespace "types"
+
+directive @level(value: Level) on FIELD
+
+enum Level {
+  low = 0
+  high = 1
+}
+
+type Address {
+  line: string
+}
+`,
+	"main.apex": `namespace "main"
+
+import { Address, Level, level } from "./types.apex"
+import { Address as Home } from "./types.apex"
+
+type User {
+  work: Address @level(value: low)
+  home: Home
+  level: Level = high
+}
+
+interface Users {
+  get(id: string): User
+}
+
+func lookup(id: string): User
+`,
+}
+
+// parseWorkspace parses every file of files, resolving imports from
+// files.
+func parseWorkspace(t *testing.T, files map[string]string) []*ast.Document {
+	t.Helper()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	docs := make([]*ast.Document, 0, len(names))
+	for _, name := range names {
+		doc, err := parser.Parse(parser.ParseParams{
+			Source: source.NewSource(name, []byte(files[name])),
+			Options: parser.ParseOptions{
+				Resolver: func(location, from string) (string, error) {
+					body, ok := files[parser.ImportSourceName(location, from)]
+					if !ok {
+						return "", fmt.Errorf("could not resolve %q", location)
+					}
+					return body, nil
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// rename renames the name at the n'th occurrence of at in the file named
+// name and returns the edited files.
+func rename(t *testing.T, name, at string, n int, newName string) (map[string]string, error) {
+	t.Helper()
+	docs := parseWorkspace(t, workspace)
+	body := workspace[name]
+	offset := -1
+	for i := 0; i <= n; i++ {
+		next := strings.Index(body[offset+1:], at)
+		if next < 0 {
+			t.Fatalf("%q not found in %s", at, name)
+		}
+		offset += next + 1
+	}
+	files, err := refactor.Rename(docs, name, uint(offset), newName)
+	if err != nil {
+		return nil, err
+	}
+	bodies, err := refactor.Apply(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := make(map[string]string, len(bodies))
+	for name, body := range bodies {
+		edited[name] = string(body)
+	}
+	return edited, nil
+}
+
+func TestRename(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		at      string
+		n       int
+		newName string
+		want    map[string][]string
+	}{
+		{
+			name: "type from its declaration",
+			file: "types.apex", at: "Address", newName: "Location",
+			want: map[string][]string{
+				"types.apex": {"type Location {"},
+				"main.apex": {
+					"import { Location, Level, level }",
+					"import { Location as Home }",
+					"work: Location @level",
+					"home: Home",
+				},
+			},
+		},
+		{
+			name: "type from a reference",
+			file: "main.apex", at: "User", n: 2, newName: "Person",
+			want: map[string][]string{
+				"main.apex": {"type Person {", "get(id: string): Person", "lookup(id: string): Person"},
+			},
+		},
+		{
+			name: "import alias",
+			file: "main.apex", at: "Home", newName: "House",
+			want: map[string][]string{
+				"main.apex": {"import { Address as House }", "home: House", "work: Address"},
+			},
+		},
+		{
+			name: "directive",
+			file: "main.apex", at: "level", n: 1, newName: "tier",
+			want: map[string][]string{
+				"types.apex": {"directive @tier(value: Level)"},
+				"main.apex":  {"import { Address, Level, tier }", "@tier(value: low)", "level: Level = high"},
+			},
+		},
+		{
+			name: "enum value",
+			file: "types.apex", at: "low", newName: "minimal",
+			want: map[string][]string{
+				"types.apex": {"minimal = 0"},
+				"main.apex":  {"@level(value: minimal)", "level: Level = high"},
+			},
+		},
+		{
+			name: "enum value used as a default",
+			file: "types.apex", at: "high", newName: "maximal",
+			want: map[string][]string{
+				"types.apex": {"maximal = 1", "low = 0"},
+				"main.apex":  {"level: Level = maximal"},
+			},
+		},
+		{
+			name: "field",
+			file: "main.apex", at: "work", newName: "office",
+			want: map[string][]string{
+				"main.apex": {"office: Address"},
+			},
+		},
+		{
+			name: "operation",
+			file: "main.apex", at: "lookup", newName: "find",
+			want: map[string][]string{
+				"main.apex": {"func find(id: string)"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edited, err := rename(t, tt.file, tt.at, tt.n, tt.newName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(edited) != len(tt.want) {
+				t.Errorf("edited files = %v, want %d", edited, len(tt.want))
+			}
+			for file, wants := range tt.want {
+				for _, want := range wants {
+					if !strings.Contains(edited[file], want) {
+						t.Errorf("%s does not contain %q:\n%s", file, want, edited[file])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRenameErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		at      string
+		newName string
+		want    string
+	}{
+		{"invalid name", "main.apex", "User", "9lives", `"9lives" is not a valid name`},
+		{"not in the workspace", "other.apex", "", "X", "other.apex is not in the workspace"},
+		{"no name", "main.apex", "{", "X", "no name at offset"},
+		{"keyword", "main.apex", "type", "X", "no name at offset"},
+		{"built-in type", "main.apex", "string", "X", `"string" cannot be renamed`},
+		{"defined name", "main.apex", "Home", "User", `"User" is already defined`},
+		{"sibling field", "main.apex", "work", "home", `"home" is already defined`},
+		{"sibling enum value", "types.apex", "low", "high", `"high" is already defined`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs := parseWorkspace(t, workspace)
+			offset := strings.Index(workspace[tt.file], tt.at)
+			if offset < 0 {
+				offset = 0
+			}
+			_, err := refactor.Rename(docs, tt.file, uint(offset), tt.newName)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenameUnchanged(t *testing.T) {
+	docs := parseWorkspace(t, workspace)
+	files, err := refactor.Rename(docs, "main.apex", uint(strings.Index(workspace["main.apex"], "User")), "User")
+	if err != nil || files != nil {
+		t.Errorf("Rename = %v, %v, want no edits", files, err)
+	}
+}
+
+func TestPrepare(t *testing.T) {
+	docs := parseWorkspace(t, workspace)
+	var main *ast.Document
+	for _, doc := range docs {
+		if doc.Loc.Source.Name == "main.apex" {
+			main = doc
+		}
+	}
+	body := workspace["main.apex"]
+	name, err := refactor.Prepare(main, uint(strings.Index(body, "Home")+2))
+	if err != nil || name.Value != "Home" {
+		t.Errorf("Prepare = %v, %v, want Home", name, err)
+	}
+	if _, err := refactor.Prepare(main, uint(strings.Index(body, "string"))); err == nil {
+		t.Error("prepared renaming a built-in type")
+	}
+}
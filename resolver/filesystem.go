@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resolver provides import resolvers for the parser.
+package resolver
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileSystem resolves imports from files on disk. Relative locations
+// ("./x" or "../x") are resolved against the importing file, all others
+// against each of Roots in order, or the working directory when there are
+// no roots. For a location `x` the files `x`, `x.apex` and `x/index.apex`
+// are tried.
+type FileSystem struct {
+	Roots []string
+}
+
+// NewFileSystem returns a FileSystem resolving non-relative imports from roots.
+func NewFileSystem(roots ...string) *FileSystem {
+	return &FileSystem{Roots: roots}
+}
+
+// Resolve implements parser.Resolver.
+func (fs *FileSystem) Resolve(location, from string) (string, error) {
+	filename, err := fs.Locate(location, from)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Locate returns the path of the file that location refers to.
+func (fs *FileSystem) Locate(location, from string) (string, error) {
+	var bases []string
+	switch {
+	case filepath.IsAbs(location):
+		bases = []string{location}
+	case strings.HasPrefix(location, "./") || strings.HasPrefix(location, "../"):
+		dir := "."
+		if from != "" {
+			dir = filepath.Dir(from)
+		}
+		joined := filepath.Join(dir, location)
+		bases = []string{joined}
+		if !filepath.IsAbs(joined) {
+			// from may itself be named relative to one of the roots.
+			bases = append(bases, fs.rootPaths(joined)...)
+		}
+	default:
+		bases = fs.rootPaths(location)
+	}
+
+	for _, base := range bases {
+		for _, candidate := range []string{
+			base,
+			base + ".apex",
+			filepath.Join(base, "index.apex"),
+		} {
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("could not resolve %q", location)
+}
+
+func (fs *FileSystem) rootPaths(location string) []string {
+	roots := fs.Roots
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+	paths := make([]string, len(roots))
+	for i, root := range roots {
+		paths[i] = filepath.Join(root, location)
+	}
+	return paths
+}
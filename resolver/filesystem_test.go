@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apexlang/apex-go/resolver"
+)
+
+// tree writes files, keyed by slash separated paths, under a temporary
+// directory and returns it.
+func tree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, text := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestFileSystemLocate(t *testing.T) {
+	dir := tree(t, map[string]string{
+		"specs/main.apex":         "",
+		"specs/common.apex":       "",
+		"specs/shared/index.apex": "",
+		"lib/types.apex":          "",
+		"lib/util":                "",
+		"other/types.apex":        "",
+	})
+	fs := resolver.NewFileSystem(filepath.Join(dir, "lib"), filepath.Join(dir, "other"))
+	from := filepath.Join(dir, "specs", "main.apex")
+
+	tests := []struct {
+		location string
+		want     string
+	}{
+		{"./common.apex", "specs/common.apex"},
+		{"./common", "specs/common.apex"},
+		{"./shared", "specs/shared/index.apex"},
+		{"../lib/types", "lib/types.apex"},
+		{"types", "lib/types.apex"},
+		{"util", "lib/util"},
+		{filepath.Join(dir, "other", "types.apex"), "other/types.apex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			got, err := fs.Locate(tt.location, from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := filepath.Join(dir, filepath.FromSlash(tt.want)); got != want {
+				t.Errorf("Locate(%q) = %q, want %q", tt.location, got, want)
+			}
+		})
+	}
+
+	for _, location := range []string{"./missing", "missing", "./shared/none"} {
+		if got, err := fs.Locate(location, from); err == nil {
+			t.Errorf("Locate(%q) = %q, want an error", location, got)
+		}
+	}
+}
+
+// Relative imports of a file named relative to a root are found under
+// that root.
+func TestFileSystemLocateRelativeToRoot(t *testing.T) {
+	dir := tree(t, map[string]string{
+		"root/a/main.apex":  "",
+		"root/a/other.apex": "",
+	})
+	fs := resolver.NewFileSystem(filepath.Join(dir, "root"))
+	got, err := fs.Locate("./other", filepath.Join("a", "main.apex"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "root", "a", "other.apex"); got != want {
+		t.Errorf("Locate = %q, want %q", got, want)
+	}
+}
+
+func TestFileSystemResolve(t *testing.T) {
+	dir := tree(t, map[string]string{"types.apex": "type T { a: string }\n"})
+	fs := resolver.NewFileSystem(dir)
+	contents, err := fs.Resolve("types", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contents != "type T { a: string }\n" {
+		t.Errorf("contents = %q", contents)
+	}
+	if _, err := fs.Resolve("missing", ""); err == nil {
+		t.Error("resolving a missing file succeeded")
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"github.com/apexlang/apex-go/analysis"
+	"github.com/apexlang/apex-go/ast"
+)
+
+// DefaultExposurePolicies forbid operations on `@public` interfaces from
+// returning or accepting `@secret` data.
+var DefaultExposurePolicies = []analysis.ExposurePolicy{
+	{
+		Interface:  "public",
+		Forbidden:  []string{"secret"},
+		Parameters: true,
+	},
+}
+
+// ClassificationPolicy returns a rule that reports operations exposing
+// classified data forbidden by policies. DefaultExposurePolicies are used
+// when none are given.
+func ClassificationPolicy(policies ...analysis.ExposurePolicy) ValidationRule {
+	if len(policies) == 0 {
+		policies = DefaultExposurePolicies
+	}
+	classifications := append([]string{}, analysis.DefaultClassifications...)
+	seen := make(map[string]struct{})
+	for _, c := range classifications {
+		seen[c] = struct{}{}
+	}
+	for _, p := range policies {
+		for _, c := range p.Forbidden {
+			if _, ok := seen[c]; !ok {
+				seen[c] = struct{}{}
+				classifications = append(classifications, c)
+			}
+		}
+	}
+
+	return func() ast.Visitor {
+		return &classificationPolicy{
+			policies:        policies,
+			classifications: classifications,
+		}
+	}
+}
+
+type classificationPolicy struct {
+	ast.BaseVisitor
+	policies        []analysis.ExposurePolicy
+	classifications []string
+}
+
+func (r *classificationPolicy) VisitDocumentAfter(context ast.Context) {
+	report := analysis.Exposures(context.Document, r.classifications...)
+	for _, policy := range r.policies {
+		for _, v := range report.Check(policy) {
+			exp := v.Exposure
+			name := exp.Operation
+			if exp.Interface != "" {
+				name = exp.Interface + "." + name
+			}
+			if exp.Returns() {
+				context.ReportError(
+					ValidationError(
+						exp.Node,
+						"operation %q returns %s data through %s",
+						name, v.Classification, v.Path,
+					),
+				)
+			} else {
+				context.ReportError(
+					ValidationError(
+						exp.Node,
+						"parameter %q of operation %q accepts %s data through %s",
+						exp.Parameter, name, v.Classification, v.Path,
+					),
+				)
+			}
+		}
+	}
+}
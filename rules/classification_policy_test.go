@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestClassificationPolicy(t *testing.T) {
+	messages := validate(t, `namespace "test"
+type User { name: string @pii  password: Secret }
+type Secret @secret { v: string }
+interface Users @public {
+  get(id: string): User
+  set(token: string @secret): void
+}
+interface Internal {
+  get(): User
+}
+`, rules.ClassificationPolicy())
+	expectMessages(t, messages, []string{
+		`Validation Error: operation "Users.get" returns secret data through Users.get -> User.password -> Secret`,
+		`Validation Error: parameter "token" of operation "Users.set" accepts secret data through Users.set(token)`,
+	})
+}
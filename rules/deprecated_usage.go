@@ -0,0 +1,274 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+// DeprecatedUsage warns when a definition or enum value marked with
+// `@deprecated` is referenced. References made from an element that is
+// itself deprecated are not reported.
+func DeprecatedUsage() ast.Visitor { return &deprecatedUsage{} }
+
+type deprecatedUsage struct{ ast.BaseVisitor }
+
+type annotated interface {
+	Annotation(name string) *ast.Annotation
+}
+
+func (r *deprecatedUsage) VisitImport(context ast.Context) {
+	imp := context.Import
+	if imp.All {
+		return
+	}
+	for _, n := range imp.Names {
+		name := n.Name.Value
+		if n.Alias != nil {
+			name = n.Alias.Value
+		}
+		def := lookupNamed(context, name)
+		if def == nil {
+			continue
+		}
+		r.report(context, n.Name, "imported definition", n.Name.Value, def.Annotation("deprecated"))
+	}
+}
+
+func (r *deprecatedUsage) VisitAlias(context ast.Context) {
+	alias := context.Alias
+	if isDeprecated(alias) {
+		return
+	}
+	r.checkType(context, alias.Type)
+}
+
+func (r *deprecatedUsage) VisitFunction(context ast.Context) {
+	r.checkOperation(context, context.Function, nil)
+}
+
+func (r *deprecatedUsage) VisitOperation(context ast.Context) {
+	r.checkOperation(context, context.Operation, context.Interface)
+}
+
+func (r *deprecatedUsage) checkOperation(context ast.Context, oper *ast.OperationDefinition, iface *ast.InterfaceDefinition) {
+	if oper == nil || isDeprecated(oper) || (iface != nil && isDeprecated(iface)) {
+		return
+	}
+	if named, ok := oper.Type.(*ast.Named); ok && named.Namespace == nil && named.Name.Value == "void" {
+		return
+	}
+	r.checkType(context, oper.Type)
+}
+
+func (r *deprecatedUsage) VisitParameter(context ast.Context) {
+	param := context.Parameter
+	if isDeprecated(param) ||
+		(context.Operation != nil && isDeprecated(context.Operation)) ||
+		(context.Function != nil && isDeprecated(context.Function)) ||
+		(context.Interface != nil && isDeprecated(context.Interface)) {
+		return
+	}
+	r.checkType(context, param.Type)
+	r.checkValue(context, param.Type, param.Default)
+}
+
+func (r *deprecatedUsage) VisitType(context ast.Context) {
+	t := context.Type
+	if isDeprecated(t) {
+		return
+	}
+	for _, i := range t.Interfaces {
+		if iface := lookupInterface(context, i.Name.Value); iface != nil {
+			r.report(context, i, "interface", i.Name.Value, iface.Annotation("deprecated"))
+		}
+	}
+}
+
+func (r *deprecatedUsage) VisitTypeField(context ast.Context) {
+	field := context.Field
+	if isDeprecated(field) || isDeprecated(context.Type) {
+		return
+	}
+	r.checkType(context, field.Type)
+	r.checkValue(context, field.Type, field.Default)
+}
+
+func (r *deprecatedUsage) VisitUnion(context ast.Context) {
+	union := context.Union
+	if isDeprecated(union) {
+		return
+	}
+	for _, member := range union.Members {
+		if isDeprecated(member) {
+			continue
+		}
+		r.checkType(context, member.Type)
+	}
+}
+
+func (r *deprecatedUsage) VisitDirectiveParameter(context ast.Context) {
+	if isDeprecated(context.Parameter) {
+		return
+	}
+	r.checkType(context, context.Parameter.Type)
+}
+
+func (r *deprecatedUsage) VisitAnnotation(context ast.Context) {
+	a := context.Annotation
+	dir := lookupDirective(context, a.Name.Value)
+	if dir == nil {
+		return
+	}
+	for _, param := range dir.Parameters {
+		if arg := a.Argument(param.Name.Value); arg != nil {
+			r.checkValue(context, param.Type, arg.Value)
+		}
+	}
+}
+
+func (r *deprecatedUsage) checkType(context ast.Context, t ast.Type) {
+	switch v := t.(type) {
+	case *ast.Named:
+		if def := lookupType(context, v); def != nil {
+			r.report(context, v, "type", v.QualifiedName(), def.Annotation("deprecated"))
+		}
+	case *ast.Optional:
+		r.checkType(context, v.Type)
+	case *ast.ListType:
+		r.checkType(context, v.Type)
+	case *ast.MapType:
+		r.checkType(context, v.KeyType)
+		r.checkType(context, v.ValueType)
+	case *ast.Stream:
+		r.checkType(context, v.Type)
+	}
+}
+
+// checkValue reports enum value references in default values and
+// annotation arguments that resolve to deprecated enum values.
+func (r *deprecatedUsage) checkValue(context ast.Context, t ast.Type, value ast.Value) {
+	if value == nil {
+		return
+	}
+	switch v := t.(type) {
+	case *ast.Optional:
+		r.checkValue(context, v.Type, value)
+	case *ast.ListType:
+		if list, ok := value.(*ast.ListValue); ok {
+			for _, item := range list.Values {
+				r.checkValue(context, v.Type, item)
+			}
+		}
+	case *ast.Named:
+		ev, ok := value.(*ast.EnumValue)
+		if !ok {
+			return
+		}
+		enum, ok := lookupType(context, v).(*ast.EnumDefinition)
+		if !ok {
+			return
+		}
+		for _, val := range enum.Values {
+			if val.Name.Value == ev.Value {
+				r.report(context, ev, "enum value", enum.Name.Value+"."+ev.Value, val.Annotation("deprecated"))
+				return
+			}
+		}
+	}
+}
+
+func (r *deprecatedUsage) report(context ast.Context, node ast.Node, what, name string, deprecated *ast.Annotation) {
+	if deprecated == nil {
+		return
+	}
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "%s %q is deprecated", what, name)
+	reason := deprecated.Argument("reason")
+	if reason == nil {
+		reason = deprecated.Argument("value")
+	}
+	if reason != nil {
+		if s, ok := reason.Value.(*ast.StringValue); ok {
+			fmt.Fprintf(&msg, ": %s", s.Value)
+		}
+	}
+	if since := deprecated.Argument("since"); since != nil {
+		if s, ok := since.Value.(*ast.StringValue); ok {
+			fmt.Fprintf(&msg, " (since %s)", s.Value)
+		}
+	}
+	context.ReportError(ValidationWarning(node, "%s", msg.String()))
+}
+
+func isDeprecated(def annotated) bool {
+	return def.Annotation("deprecated") != nil
+}
+
+func lookupNamed(context ast.Context, name string) annotated {
+	if def := annotatedDefinition(context.Named[name]); def != nil {
+		return def
+	}
+	if iface := lookupInterface(context, name); iface != nil {
+		return iface
+	}
+	return nil
+}
+
+// lookupType returns the definition that a type refers to, including
+// definitions of namespaces imported with an alias.
+func lookupType(context ast.Context, named *ast.Named) annotated {
+	if named.Namespace != nil {
+		return annotatedDefinition(context.Lookup(named))
+	}
+	return lookupNamed(context, named.Name.Value)
+}
+
+func annotatedDefinition(def ast.Definition) annotated {
+	switch def := def.(type) {
+	case *ast.TypeDefinition:
+		return def
+	case *ast.EnumDefinition:
+		return def
+	case *ast.UnionDefinition:
+		return def
+	case *ast.AliasDefinition:
+		return def
+	}
+	return nil
+}
+
+func lookupInterface(context ast.Context, name string) *ast.InterfaceDefinition {
+	for _, iface := range context.Interfaces {
+		if iface.Name.Value == name {
+			return iface
+		}
+	}
+	return nil
+}
+
+func lookupDirective(context ast.Context, name string) *ast.DirectiveDefinition {
+	for _, dir := range context.Directives {
+		if dir.Name.Value == name {
+			return dir
+		}
+	}
+	return nil
+}
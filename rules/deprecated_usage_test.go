@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestDeprecatedUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "field and parameter types",
+			src: `namespace "test"
+type Old @deprecated(reason: "use New") { a: string }
+type New { old: Old }
+func get(id: Old): Old
+interface Svc { put(o: [Old]): void }
+`,
+			want: []string{
+				`Validation Warning: type "Old" is deprecated: use New`,
+				`Validation Warning: type "Old" is deprecated: use New`,
+				`Validation Warning: type "Old" is deprecated: use New`,
+				`Validation Warning: type "Old" is deprecated: use New`,
+			},
+		},
+		{
+			name: "enum value defaults",
+			src: `namespace "test"
+enum Level { LOW = 0 @deprecated HIGH = 1 }
+type T { low: Level = LOW high: Level = HIGH }
+`,
+			want: []string{
+				`Validation Warning: enum value "Level.LOW" is deprecated`,
+			},
+		},
+		{
+			name: "deprecated referrers",
+			src: `namespace "test"
+type Old @deprecated { a: string }
+type Older @deprecated { old: Old }
+type T { old: Old @deprecated }
+func get(id: Old): Old @deprecated
+`,
+		},
+		{
+			name: "no deprecations",
+			src: `namespace "test"
+type A { b: B }
+type B { a: A? }
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectMessages(t, validate(t, tt.src, rules.DeprecatedUsage), tt.want)
+		})
+	}
+}
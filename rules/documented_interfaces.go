@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+// DocumentedInterfaces requires a description on every public interface and
+// its operations. Interfaces annotated with `@internal` are not public.
+func DocumentedInterfaces() ast.Visitor { return &documentedInterfaces{} }
+
+type documentedInterfaces struct{ ast.BaseVisitor }
+
+func (r *documentedInterfaces) VisitInterface(context ast.Context) {
+	iface := context.Interface
+	if iface.Annotation("internal") != nil {
+		return
+	}
+	if !hasDescription(iface.Description) {
+		context.ReportError(
+			ValidationError(iface.Name, "interface %q requires a description", iface.Name.Value),
+		)
+	}
+}
+
+func (r *documentedInterfaces) VisitOperation(context ast.Context) {
+	iface := context.Interface
+	oper := context.Operation
+	if iface.Annotation("internal") != nil {
+		return
+	}
+	if !hasDescription(oper.Description) {
+		context.ReportError(
+			ValidationError(
+				oper.Name,
+				"operation %q in interface %q requires a description",
+				oper.Name.Value,
+				iface.Name.Value,
+			),
+		)
+	}
+}
+
+func hasDescription(description *ast.StringValue) bool {
+	return description != nil && strings.TrimSpace(description.Value) != ""
+}
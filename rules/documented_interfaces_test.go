@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestDocumentedInterfaces(t *testing.T) {
+	messages := validate(t, `namespace "test"
+
+interface Public {
+  "Documented."
+  a(): void
+  b(): void
+}
+
+"Documented."
+interface Partial {
+  "  "
+  c(): void
+}
+
+interface Hidden @internal {
+  d(): void
+}
+`, rules.DocumentedInterfaces)
+	expectMessages(t, messages, []string{
+		`Validation Error: interface "Public" requires a description`,
+		`Validation Error: operation "b" in interface "Public" requires a description`,
+		`Validation Error: operation "c" in interface "Partial" requires a description`,
+	})
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/query"
+)
+
+// importScope describes what the imports of a document bring into it.
+type importScope struct {
+	// imports are the resolved imports written in the document, leaving
+	// out those brought in with the definitions of another import.
+	imports []*ast.ImportDefinition
+	// brought are the definitions that each import brings in.
+	brought map[*ast.ImportDefinition][]imported
+	// local are the type, interface, union, enum, alias and directive
+	// definitions that no import brings in.
+	local []ast.Node
+}
+
+// imported is a definition brought in by an import.
+type imported struct {
+	def ast.Node
+	// name is the name of the definition in the importing document.
+	name *ast.Name
+	// origin identifies the declaration in its source.
+	origin string
+}
+
+func newImportScope(doc *ast.Document) *importScope {
+	s := importScope{
+		brought: make(map[*ast.ImportDefinition][]imported),
+	}
+	names := make(map[*ast.Name]ast.Node)
+	for _, def := range doc.Definitions {
+		if name := importableName(def); name != nil {
+			names[name] = def
+		}
+	}
+
+	sources := make(map[ast.Node]string)
+	inImport := make(map[ast.Node]bool)
+	for _, def := range doc.Definitions {
+		imp, ok := def.(*ast.ImportDefinition)
+		if !ok || imp.Document == nil || imp.Alias != nil {
+			continue
+		}
+		declaredIn(imp, sources)
+		if imp.All {
+			for _, d := range imp.Document.Definitions {
+				inImport[d] = true
+				if name := importableName(d); name != nil {
+					s.brought[imp] = append(s.brought[imp], imported{d, name, originOf(sources, d, name.Value)})
+				}
+			}
+			continue
+		}
+		for _, n := range imp.Names {
+			name := n.Alias
+			if name == nil {
+				name = n.Name
+			}
+			d, ok := names[name]
+			if !ok {
+				continue
+			}
+			inImport[d] = true
+			origin := ""
+			if decl := lookupImportable(imp.Document, d, n.Name.Value); decl != nil {
+				origin = originOf(sources, decl, n.Name.Value)
+			}
+			s.brought[imp] = append(s.brought[imp], imported{d, name, origin})
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		if inImport[def] {
+			continue
+		}
+		if imp, ok := def.(*ast.ImportDefinition); ok && imp.Document != nil {
+			s.imports = append(s.imports, imp)
+		} else if importableName(def) != nil {
+			s.local = append(s.local, def)
+		}
+	}
+	return &s
+}
+
+// references returns the names that the definitions of doc refer to, less
+// those of skip. Qualified names are returned as written.
+func references(doc *ast.Document, skip []imported) map[string]bool {
+	skipped := make(map[ast.Node]bool, len(skip))
+	for _, i := range skip {
+		skipped[i.def] = true
+	}
+	refs := make(map[string]bool)
+	for _, def := range doc.Definitions {
+		if skipped[def] {
+			continue
+		}
+		query.Inspect(def, func(path query.Path) bool {
+			switch n := path.Node().(type) {
+			case *ast.Named:
+				refs[n.QualifiedName()] = true
+			case *ast.Annotation:
+				refs["@"+n.Name.Value] = true
+			case *ast.DirectiveRequire:
+				refs["@"+n.Directive.Value] = true
+			}
+			return true
+		})
+	}
+	return refs
+}
+
+// reference returns how the definitions of the document refer to def.
+func reference(def ast.Node, name string) string {
+	if _, ok := def.(*ast.DirectiveDefinition); ok {
+		return "@" + name
+	}
+	return name
+}
+
+// importableName returns the name of the definitions that selective
+// imports can bring in.
+func importableName(def ast.Node) *ast.Name {
+	switch d := def.(type) {
+	case *ast.TypeDefinition, *ast.InterfaceDefinition, *ast.UnionDefinition,
+		*ast.EnumDefinition, *ast.AliasDefinition, *ast.DirectiveDefinition:
+		return query.NameOf(d)
+	}
+	return nil
+}
+
+// lookupImportable returns the definition of doc named name of the same
+// category as def.
+func lookupImportable(doc *ast.Document, def ast.Node, name string) ast.Node {
+	_, directive := def.(*ast.DirectiveDefinition)
+	for _, d := range doc.Definitions {
+		n := importableName(d)
+		if n == nil || n.Value != name {
+			continue
+		}
+		if _, ok := d.(*ast.DirectiveDefinition); ok == directive {
+			return d
+		}
+	}
+	return nil
+}
+
+// declaredIn records the name of the source that declares each definition
+// of the document that imp brings in. Locations are not used, as they have
+// no source when parsing with NoSource.
+func declaredIn(imp *ast.ImportDefinition, sources map[ast.Node]string) {
+	if imp.Document == nil || imp.Source == nil {
+		return
+	}
+	brought := make(map[ast.Node]bool)
+	for _, def := range imp.Document.Definitions {
+		if i, ok := def.(*ast.ImportDefinition); ok && i.All && i.Document != nil {
+			for _, d := range i.Document.Definitions {
+				brought[d] = true
+			}
+		}
+	}
+	for _, def := range imp.Document.Definitions {
+		if i, ok := def.(*ast.ImportDefinition); ok {
+			declaredIn(i, sources)
+		} else if !brought[def] {
+			sources[def] = imp.Source.Name
+		}
+	}
+}
+
+// originOf identifies the declaration decl of name by its source.
+func originOf(sources map[ast.Node]string, decl ast.Node, name string) string {
+	if src, ok := sources[decl]; ok {
+		return src + "#" + reference(decl, name)
+	}
+	return reference(decl, name)
+}
+
+// kindName returns how messages refer to the kind of def.
+func kindName(def ast.Node) string {
+	switch def.(type) {
+	case *ast.TypeDefinition:
+		return "type"
+	case *ast.InterfaceDefinition:
+		return "interface"
+	case *ast.UnionDefinition:
+		return "union"
+	case *ast.EnumDefinition:
+		return "enum"
+	case *ast.AliasDefinition:
+		return "alias"
+	case *ast.DirectiveDefinition:
+		return "directive"
+	}
+	return "definition"
+}
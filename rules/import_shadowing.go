@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"github.com/apexlang/apex-go/ast"
+)
+
+// ImportShadowing warns about imported definitions that have the name of
+// a definition of the document. The later of the two shadows the earlier.
+func ImportShadowing() ast.Visitor { return &importShadowing{} }
+
+type importShadowing struct{ ast.BaseVisitor }
+
+func (r *importShadowing) VisitImportsAfter(context ast.Context) {
+	scope := newImportScope(context.Document)
+	if len(scope.imports) == 0 {
+		return
+	}
+	index := make(map[ast.Node]int, len(context.Document.Definitions))
+	for i, def := range context.Document.Definitions {
+		index[def] = i
+	}
+	local := make(map[string]ast.Node, len(scope.local))
+	for _, def := range scope.local {
+		ref := reference(def, importableName(def).Value)
+		if _, exists := local[ref]; !exists {
+			local[ref] = def
+		}
+	}
+
+	for _, imp := range scope.imports {
+		for _, i := range scope.brought[imp] {
+			def, ok := local[reference(i.def, i.name.Value)]
+			if !ok {
+				continue
+			}
+			var node ast.Node = i.name
+			if imp.All {
+				node = imp.From
+			}
+			verb := "is shadowed by"
+			if index[def] < index[imp] {
+				verb = "shadows"
+			}
+			context.ReportError(
+				ValidationWarning(node, "%s %q imported from %q %s the local %s",
+					kindName(i.def), i.name.Value, imp.From.Value, verb, kindName(def)),
+			)
+		}
+	}
+}
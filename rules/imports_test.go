@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+)
+
+var importSources = map[string]string{
+	"billing": `namespace "billing"
+
+type Invoice {
+  total: f64
+}
+
+enum Currency { USD = 0 EUR = 1 }
+`,
+	"ledger": `namespace "ledger"
+
+type Invoice {
+  amount: f64
+}
+`,
+	"shared": `namespace "shared"
+
+import * as billing from "ledger"
+
+type Entry {
+  invoice: billing.Invoice
+}
+`,
+	"audit": `namespace "audit"
+
+type Audit {
+  by: string
+}
+`,
+}
+
+// validateImports parses src, resolving imports from importSources, and
+// returns the messages reported by rules, with and without sources.
+func validateImports(t *testing.T, src string, validationRules ...rules.ValidationRule) [2][]string {
+	t.Helper()
+	var messages [2][]string
+	for i, noSource := range []bool{false, true} {
+		doc, err := parser.Parse(parser.ParseParams{
+			Source: src,
+			Options: parser.ParseOptions{
+				NoSource: noSource,
+				Resolver: func(location, from string) (string, error) {
+					src, ok := importSources[location]
+					if !ok {
+						return "", fmt.Errorf("unknown import %q", location)
+					}
+					return src, nil
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, err := range rules.Validate(doc, validationRules...) {
+			messages[i] = append(messages[i], err.(*errors.Error).Message)
+		}
+	}
+	return messages
+}
+
+func TestImportRules(t *testing.T) {
+	tests := []struct {
+		name string
+		rule rules.ValidationRule
+		src  string
+		want []string
+	}{
+		{
+			name: "valid",
+			rule: rules.ValidImports,
+			src: `namespace "orders"
+
+import { Invoice, Currency as Money } from "billing"
+import * as billing from "billing"
+import * as billing from "billing"
+`,
+		},
+		{
+			name: "undefined name",
+			rule: rules.ValidImports,
+			src: `namespace "orders"
+
+import { Invoice, Receipt } from "billing"
+`,
+			want: []string{
+				`Validation Error: "Receipt" is not defined in "billing"`,
+			},
+		},
+		{
+			name: "alias used twice",
+			rule: rules.ValidImports,
+			src: `namespace "orders"
+
+import * as billing from "billing"
+import * as billing from "ledger"
+`,
+			want: []string{
+				`Validation Error: namespace alias "billing" is already used for "billing"`,
+			},
+		},
+		{
+			name: "alias of an import",
+			rule: rules.ValidImports,
+			src: `namespace "orders"
+
+import * as billing from "billing"
+import * from "shared"
+`,
+		},
+		{
+			name: "duplicate import",
+			rule: rules.UniqueImports,
+			src: `namespace "orders"
+
+import { Invoice } from "billing"
+import { Invoice } from "billing"
+`,
+			want: []string{
+				`Validation Warning: duplicate import of "billing"`,
+			},
+		},
+		{
+			name: "name imported twice",
+			rule: rules.UniqueImports,
+			src: `namespace "orders"
+
+import { Invoice } from "billing"
+import { Invoice, Currency } from "billing"
+`,
+			want: []string{
+				`Validation Warning: type "Invoice" is already imported from "billing"`,
+			},
+		},
+		{
+			name: "conflicting names",
+			rule: rules.UniqueImports,
+			src: `namespace "orders"
+
+import { Invoice } from "billing"
+import { Invoice } from "ledger"
+`,
+			want: []string{
+				`Validation Warning: type "Invoice" imported from "ledger" conflicts with the one imported from "billing"`,
+			},
+		},
+		{
+			name: "conflicting aliases",
+			rule: rules.UniqueImports,
+			src: `namespace "orders"
+
+import { Invoice as Bill } from "billing"
+import { Invoice as Bill } from "ledger"
+`,
+			want: []string{
+				`Validation Warning: type "Bill" imported from "ledger" conflicts with the one imported from "billing"`,
+			},
+		},
+		{
+			name: "shadowing",
+			rule: rules.ImportShadowing,
+			src: `namespace "orders"
+
+type Currency {
+  code: string
+}
+
+import * from "billing"
+
+type Invoice {
+  id: string
+}
+`,
+			want: []string{
+				`Validation Warning: type "Invoice" imported from "billing" is shadowed by the local type`,
+				`Validation Warning: enum "Currency" imported from "billing" shadows the local type`,
+			},
+		},
+		{
+			name: "unused",
+			rule: rules.UnusedImports,
+			src: `namespace "orders"
+
+import { Invoice, Currency } from "billing"
+import * from "audit"
+import * as bills from "billing"
+import * as books from "ledger"
+
+type Order {
+  invoice: Invoice
+  booked: books.Invoice
+}
+`,
+			want: []string{
+				`Validation Warning: imported enum "Currency" is never used`,
+				`Validation Warning: nothing imported from "audit" is used`,
+				`Validation Warning: namespace "billing" imported as "bills" is never used`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := validateImports(t, tt.src, tt.rule)
+			expectMessages(t, messages[0], tt.want)
+			t.Run("NoSource", func(t *testing.T) {
+				expectMessages(t, messages[1], tt.want)
+			})
+		})
+	}
+}
@@ -23,6 +23,9 @@ import (
 	"github.com/apexlang/apex-go/ast"
 )
 
+// KnownTypes reports the types of aliases, operations, parameters, fields,
+// union members and directive parameters that are neither built in nor
+// defined. Qualified names must refer to a namespace imported with an alias.
 func KnownTypes() ast.Visitor { return &knownTypes{} }
 
 type knownTypes struct{ ast.BaseVisitor }
@@ -55,7 +58,7 @@ func (c *knownTypes) VisitAlias(context ast.Context) {
 func (c *knownTypes) VisitOperationAfter(context ast.Context) {
 	oper := context.Operation
 	// "void" is a special case for operations without a return.
-	if named, ok := oper.Type.(*ast.Named); ok && named.Name.Value == "void" {
+	if named, ok := oper.Type.(*ast.Named); ok && named.Namespace == nil && named.Name.Value == "void" {
 		return
 	}
 
@@ -78,7 +81,7 @@ func (c *knownTypes) VisitParameter(context ast.Context) {
 	)
 }
 
-func (c *knownTypes) VisitField(context ast.Context) {
+func (c *knownTypes) VisitTypeField(context ast.Context) {
 	t := context.Type
 	field := context.Field
 	c.checkType(
@@ -123,7 +126,31 @@ func (c *knownTypes) checkType(
 		name := v.Name.Value
 		first := name[0:1]
 
-		if first == strings.ToLower(first) {
+		if v.Namespace != nil {
+			// Check against the imported namespace
+			if _, ok := context.Qualified[v.Namespace.Value]; !ok {
+				context.ReportError(
+					ValidationError(
+						v.Namespace,
+						"unknown namespace %q for %s in %q: it must be imported with `import * as %s`",
+						v.Namespace.Value,
+						forName,
+						parentName,
+						v.Namespace.Value,
+					),
+				)
+			} else if context.Lookup(v) == nil {
+				context.ReportError(
+					ValidationError(
+						v,
+						"unknown type %q for %s in %q",
+						v.QualifiedName(),
+						forName,
+						parentName,
+					),
+				)
+			}
+		} else if first == strings.ToLower(first) {
 			// Check for built-in types
 			if _, ok := builtInTypeNames[name]; !ok {
 				context.ReportError(
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestKnownTypesFields(t *testing.T) {
+	messages := validate(t, `namespace "test"
+
+type Order {
+  id: string
+  customer: Customer
+  lines: [Line]
+  notes: {string: note}?
+  total: Money
+}
+
+alias Money = f64
+`, rules.KnownTypes)
+	expectMessages(t, messages, []string{
+		`Validation Error: unknown type "Customer" for field "customer" in "Order"`,
+		`Validation Error: unknown type "Line" for field "lines" in "Order"`,
+		`Validation Error: invalid built-in type "note" for field "notes" in "Order"`,
+	})
+}
+
+func TestKnownTypesQualified(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: `namespace "orders"
+
+import * as billing from "billing"
+
+type Order {
+  invoice: billing.Invoice
+  receipt: billing.Receipt
+  customer: crm.Customer
+}
+
+func pay(invoice: billing.Invoice): billing.Invoice
+func void(): void
+`,
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				return `namespace "billing"
+
+type Invoice {
+  total: f64
+}
+`, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var messages []string
+	for _, err := range rules.Validate(doc, rules.KnownTypes) {
+		messages = append(messages, err.(*errors.Error).Message)
+	}
+	expectMessages(t, messages, []string{
+		`Validation Error: unknown type "billing.Receipt" for field "receipt" in "Order"`,
+		"Validation Error: unknown namespace \"crm\" for field \"customer\" in \"Order\": it must be imported with `import * as crm`",
+	})
+}
@@ -18,21 +18,37 @@ package rules
 
 import (
 	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/source"
 )
 
+// NamespaceFirst reports namespaces defined after other definitions of the
+// same source. Imports and directives may come first.
 func NamespaceFirst() ast.Visitor { return &namespaceFirst{} }
 
 type namespaceFirst struct{ ast.BaseVisitor }
 
 func (c *namespaceFirst) VisitNamespace(context ast.Context) {
-	pos := 0
+	// Definitions are counted by source, so the definitions of merged
+	// sources do not count against each other. Imported definitions are
+	// not counted, as sources are not kept when parsing with NoSource.
+	pos := make(map[*source.Source]int)
+	seen := make(map[*source.Source]bool)
+	imported := ast.ImportedDefinitions(context.Document)
 	for _, def := range context.Document.Definitions {
+		if imported[def] {
+			continue
+		}
 		switch v := def.(type) {
 		case *ast.ImportDefinition, *ast.DirectiveDefinition:
 			// Ignore the position
 		case *ast.NamespaceDefinition:
-			if pos == 0 {
-				return
+			// Further namespaces of a source are reported by
+			// SingleNamespaceDefined.
+			src := sourceOf(v)
+			first := !seen[src]
+			seen[src] = true
+			if !first || pos[src] == 0 {
+				continue
 			}
 			context.ReportError(
 				ValidationError(
@@ -41,7 +57,7 @@ func (c *namespaceFirst) VisitNamespace(context ast.Context) {
 				),
 			)
 		default:
-			pos++
+			pos[sourceOf(def)]++
 		}
 	}
 }
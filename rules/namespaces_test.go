@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+)
+
+var namespaceSources = map[string]string{
+	"b": `namespace "b"
+
+type B {
+  name: string
+}
+`,
+	"c": `namespace "c"
+
+import * from "b"
+
+type C {
+  b: B
+}
+`,
+}
+
+func TestNamespacesOfImports(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "import all",
+			src: `namespace "a"
+
+import * from "b"
+
+type A {
+  b: B
+}
+`,
+		},
+		{
+			name: "import before namespace",
+			src: `import * from "c"
+
+namespace "a"
+
+type A {
+  c: C
+}
+`,
+		},
+		{
+			name: "named import before namespace",
+			src: `import { B } from "b"
+
+namespace "a"
+
+type A {
+  b: B
+}
+`,
+		},
+		{
+			name: "second namespace",
+			src: `namespace "a"
+
+import * from "b"
+
+namespace "d"
+`,
+			want: []string{`Validation Error: only one namespace can be defined`},
+		},
+		{
+			name: "namespace after definitions",
+			src: `import * from "b"
+
+type A {
+  b: B
+}
+
+namespace "a"
+`,
+			want: []string{`Validation Error: namespace must be defined before any other definition`},
+		},
+	}
+	for _, tt := range tests {
+		for _, noSource := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/NoSource=%t", tt.name, noSource), func(t *testing.T) {
+				doc, err := parser.Parse(parser.ParseParams{
+					Source: tt.src,
+					Options: parser.ParseOptions{
+						NoSource: noSource,
+						Resolver: func(location, from string) (string, error) {
+							return namespaceSources[location], nil
+						},
+					},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				var messages []string
+				for _, err := range rules.Validate(doc,
+					rules.SingleNamespaceDefined, rules.NamespaceFirst) {
+					messages = append(messages, err.(*errors.Error).Message)
+				}
+				expectMessages(t, messages, tt.want)
+				if ns := ast.NewContext(doc).Namespace; ns.Name.Value != "a" {
+					t.Errorf("namespace = %q, want \"a\"", ns.Name.Value)
+				}
+			})
+		}
+	}
+}
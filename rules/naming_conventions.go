@@ -0,0 +1,421 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/source"
+)
+
+// Case is a naming style that a name can be checked against.
+type Case int
+
+const (
+	// CaseUnchecked disables the check.
+	CaseUnchecked Case = iota
+	// CamelCase is lower camel case, e.g. `userId`.
+	CamelCase
+	// PascalCase is upper camel case, e.g. `UserId`.
+	PascalCase
+	// SnakeCase is lower snake case, e.g. `user_id`.
+	SnakeCase
+	// ScreamingSnakeCase is upper snake case, e.g. `USER_ID`.
+	ScreamingSnakeCase
+	// DottedLowerCase is lower case segments separated by dots, e.g. `acme.users.v1`.
+	DottedLowerCase
+)
+
+func (c Case) String() string {
+	switch c {
+	case CamelCase:
+		return "camel case"
+	case PascalCase:
+		return "pascal case"
+	case SnakeCase:
+		return "snake case"
+	case ScreamingSnakeCase:
+		return "screaming snake case"
+	case DottedLowerCase:
+		return "dotted lower case"
+	}
+	return "unchecked"
+}
+
+// AcronymPolicy controls how acronyms are cased in camel and pascal case names.
+type AcronymPolicy int
+
+const (
+	// AcronymsAsWords treats acronyms as regular words, e.g. `userId` and `HttpServer`.
+	AcronymsAsWords AcronymPolicy = iota
+	// AcronymsUpperCase keeps known acronyms upper case, e.g. `userID` and `HTTPServer`.
+	AcronymsUpperCase
+)
+
+// NamingConfig configures the NamingConventions rule.
+type NamingConfig struct {
+	Fields     Case
+	Parameters Case
+	Operations Case
+	// EnumValues lists the accepted cases. Fixes use the first one.
+	EnumValues []Case
+	Namespace  Case
+
+	Acronyms AcronymPolicy
+	// KnownAcronyms lists the acronyms kept upper case with AcronymsUpperCase.
+	KnownAcronyms []string
+}
+
+// DefaultAcronyms are the acronyms recognized by DefaultNamingConfig.
+var DefaultAcronyms = []string{
+	"API", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID",
+	"IP", "JSON", "JWT", "RPC", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP",
+	"UI", "URI", "URL", "UTF8", "UUID", "XML",
+}
+
+// DefaultNamingConfig returns the conventions used by Apex specifications.
+func DefaultNamingConfig() NamingConfig {
+	return NamingConfig{
+		Fields:        CamelCase,
+		Parameters:    CamelCase,
+		Operations:    CamelCase,
+		EnumValues:    []Case{ScreamingSnakeCase, PascalCase},
+		Namespace:     DottedLowerCase,
+		Acronyms:      AcronymsAsWords,
+		KnownAcronyms: DefaultAcronyms,
+	}
+}
+
+// NamingConventions returns a rule that warns about member names that do not
+// follow config. Each warning carries edits that rename the declaration and,
+// for enum values, the default values and annotation arguments using it. No
+// edits are offered when a reference is in another source.
+func NamingConventions(config NamingConfig) ValidationRule {
+	acronyms := make(map[string]struct{}, len(config.KnownAcronyms))
+	for _, a := range config.KnownAcronyms {
+		acronyms[strings.ToUpper(a)] = struct{}{}
+	}
+	return func() ast.Visitor {
+		return &namingConventions{config: config, acronyms: acronyms}
+	}
+}
+
+type namingConventions struct {
+	ast.BaseVisitor
+	config   NamingConfig
+	acronyms map[string]struct{}
+}
+
+func (r *namingConventions) VisitNamespace(context ast.Context) {
+	r.check(context, "namespace", context.Namespace.Name, r.config.Namespace)
+}
+
+func (r *namingConventions) VisitFunction(context ast.Context) {
+	r.check(context, "function", context.Function.Name, r.config.Operations)
+}
+
+func (r *namingConventions) VisitOperation(context ast.Context) {
+	r.check(context, "operation", context.Operation.Name, r.config.Operations)
+}
+
+func (r *namingConventions) VisitParameter(context ast.Context) {
+	r.check(context, "parameter", context.Parameter.Name, r.config.Parameters)
+}
+
+func (r *namingConventions) VisitDirectiveParameter(context ast.Context) {
+	r.check(context, "parameter", context.Parameter.Name, r.config.Parameters)
+}
+
+func (r *namingConventions) VisitTypeField(context ast.Context) {
+	r.check(context, "field", context.Field.Name, r.config.Fields)
+}
+
+func (r *namingConventions) VisitEnumValue(context ast.Context) {
+	name := context.EnumValue.Name
+	expected, ok := r.expect(name, r.config.EnumValues...)
+	if ok {
+		return
+	}
+	err := r.warning("enum value", name, expected, r.config.EnumValues...)
+	if loc := name.Loc; loc != nil && loc.Source != nil {
+		err.Fixes = renameEdits(context.Document, context.Enum, name, expected)
+	}
+	context.ReportError(err)
+}
+
+func (r *namingConventions) check(context ast.Context, what string, name *ast.Name, c Case) {
+	expected, ok := r.expect(name, c)
+	if ok {
+		return
+	}
+
+	err := r.warning(what, name, expected, c)
+	if loc := name.Loc; loc != nil {
+		newText := expected
+		if loc.Source != nil && int(loc.Start) < len(loc.Source.Body) && loc.Source.Body[loc.Start] == '"' {
+			newText = `"` + expected + `"`
+		}
+		err.Fixes = []source.Edit{{Start: loc.Start, End: loc.End, NewText: newText}}
+	}
+	context.ReportError(err)
+}
+
+// expect returns name in the first of cases and whether name already
+// follows one of them.
+func (r *namingConventions) expect(name *ast.Name, cases ...Case) (string, bool) {
+	if name == nil || len(cases) == 0 {
+		return "", true
+	}
+	for _, c := range cases {
+		if c == CaseUnchecked {
+			return "", true
+		}
+		if expected := r.format(name.Value, c); expected == name.Value || expected == "" {
+			return "", true
+		}
+	}
+	return r.format(name.Value, cases[0]), false
+}
+
+func (r *namingConventions) warning(what string, name *ast.Name, expected string, cases ...Case) *errors.Error {
+	names := make([]string, len(cases))
+	for i, c := range cases {
+		names[i] = c.String()
+	}
+	return ValidationWarning(name, "%s %q should be %s: %q", what, name.Value, strings.Join(names, " or "), expected)
+}
+
+// renameEdits returns the edits that rename the value name of enum to
+// newName along with the default values and annotation arguments in doc
+// that refer to it, or nil when newName is taken or the value is declared
+// or used in another source.
+func renameEdits(doc *ast.Document, enum *ast.EnumDefinition, name *ast.Name, newName string) []source.Edit {
+	src := name.Loc.Source
+	if loc := doc.GetLoc(); enum == nil || loc == nil || loc.Source != src {
+		return nil
+	}
+	for _, v := range enum.Values {
+		if v.Name.Value == newName {
+			return nil
+		}
+	}
+
+	r := enumValueReferences{
+		enum:    enum,
+		name:    name,
+		newName: newName,
+		named:   make(map[string]ast.Definition),
+		edits:   []source.Edit{{Start: name.Loc.Start, End: name.Loc.End, NewText: newName}},
+		ok:      true,
+	}
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.AliasDefinition:
+			r.named[d.Name.Value] = d
+		case *ast.EnumDefinition:
+			r.named[d.Name.Value] = d
+		}
+	}
+	doc.Accept(ast.NewContext(doc), &r)
+	if !r.ok {
+		return nil
+	}
+	return r.edits
+}
+
+// enumValueReferences collects the edits for the default values and
+// annotation arguments that refer to a value of an enum. ok is false once
+// a reference is found in another source.
+type enumValueReferences struct {
+	ast.BaseVisitor
+	enum    *ast.EnumDefinition
+	name    *ast.Name
+	newName string
+	named   map[string]ast.Definition
+	edits   []source.Edit
+	ok      bool
+}
+
+func (r *enumValueReferences) VisitParameter(context ast.Context) {
+	r.value(context.Parameter.Default, context.Parameter.Type, 0)
+}
+
+func (r *enumValueReferences) VisitDirectiveParameter(context ast.Context) {
+	r.value(context.Parameter.Default, context.Parameter.Type, 0)
+}
+
+func (r *enumValueReferences) VisitTypeField(context ast.Context) {
+	r.value(context.Field.Default, context.Field.Type, 0)
+}
+
+func (r *enumValueReferences) VisitAnnotation(context ast.Context) {
+	a := context.Annotation
+	for _, directive := range context.Directives {
+		if directive.Name.Value != a.Name.Value {
+			continue
+		}
+		for _, arg := range a.Arguments {
+			if param := argumentParameter(directive, arg.Name.Value); param != nil {
+				r.value(arg.Value, param.Type, 0)
+			}
+		}
+		return
+	}
+}
+
+// value adds the edits for references in v, a value of type t.
+func (r *enumValueReferences) value(v ast.Value, t ast.Type, depth int) {
+	if v == nil || !r.ok || depth > 32 {
+		return
+	}
+	switch t := t.(type) {
+	case *ast.Optional:
+		r.value(v, t.Type, depth+1)
+	case *ast.ListType:
+		if list, ok := v.(*ast.ListValue); ok {
+			for _, item := range list.Values {
+				r.value(item, t.Type, depth+1)
+			}
+		}
+	case *ast.MapType:
+		if obj, ok := v.(*ast.ObjectValue); ok {
+			for _, f := range obj.Fields {
+				r.value(f.Value, t.ValueType, depth+1)
+			}
+		}
+	case *ast.Named:
+		if t.Namespace != nil {
+			// Qualified types are defined in imported documents.
+			return
+		}
+		switch def := r.named[t.Name.Value].(type) {
+		case *ast.AliasDefinition:
+			r.value(v, def.Type, depth+1)
+		case *ast.EnumDefinition:
+			ev, ok := v.(*ast.EnumValue)
+			if !ok || def != r.enum || ev.Value != r.name.Value {
+				return
+			}
+			if ev.Loc == nil || ev.Loc.Source != r.name.Loc.Source {
+				r.ok = false
+				return
+			}
+			r.edits = append(r.edits, source.Edit{Start: ev.Loc.Start, End: ev.Loc.End, NewText: r.newName})
+		}
+	}
+}
+
+// argumentParameter returns the parameter of directive that the argument
+// name sets. A lone parameter is also set by an argument named value.
+func argumentParameter(directive *ast.DirectiveDefinition, name string) *ast.ParameterDefinition {
+	for _, p := range directive.Parameters {
+		if p.Name.Value == name {
+			return p
+		}
+	}
+	if name == "value" && len(directive.Parameters) == 1 {
+		return directive.Parameters[0]
+	}
+	return nil
+}
+
+// format rewrites name in the given case.
+func (r *namingConventions) format(name string, c Case) string {
+	if c == DottedLowerCase {
+		segments := strings.Split(name, ".")
+		for i, segment := range segments {
+			segments[i] = strings.ToLower(strings.Join(splitWords(segment), ""))
+		}
+		return strings.Join(segments, ".")
+	}
+
+	words := splitWords(name)
+	for i, w := range words {
+		switch c {
+		case SnakeCase:
+			words[i] = strings.ToLower(w)
+		case ScreamingSnakeCase:
+			words[i] = strings.ToUpper(w)
+		case CamelCase:
+			if i == 0 {
+				words[i] = strings.ToLower(w)
+			} else {
+				words[i] = r.title(w)
+			}
+		case PascalCase:
+			words[i] = r.title(w)
+		}
+	}
+
+	switch c {
+	case SnakeCase, ScreamingSnakeCase:
+		return strings.Join(words, "_")
+	}
+	return strings.Join(words, "")
+}
+
+func (r *namingConventions) title(word string) string {
+	upper := strings.ToUpper(word)
+	if r.config.Acronyms == AcronymsUpperCase {
+		if _, ok := r.acronyms[upper]; ok {
+			return upper
+		}
+	}
+	runes := []rune(strings.ToLower(word))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// splitWords breaks a name into words on separators and case changes.
+// A run of upper case letters is kept together so `HTTPServer` yields
+// `HTTP` and `Server`. Digits stay with the preceding word.
+func splitWords(name string) []string {
+	runes := []rune(name)
+	var words []string
+	start := -1
+	flush := func(end int) {
+		if start >= 0 && end > start {
+			words = append(words, string(runes[start:end]))
+		}
+		start = -1
+	}
+	for i, c := range runes {
+		if c == '_' || c == '-' || c == '.' || unicode.IsSpace(c) {
+			flush(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		prev := runes[i-1]
+		if unicode.IsUpper(c) {
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				flush(i)
+				start = i
+			} else if unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				flush(i)
+				start = i
+			}
+		}
+	}
+	flush(len(runes))
+	return words
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+func TestNamingConventions(t *testing.T) {
+	tests := []struct {
+		name   string
+		config func(*rules.NamingConfig)
+		src    string
+		want   []string
+	}{
+		{
+			name: "members",
+			src: `namespace "acme.users.v1"
+type User { user_id: string UserName: string ok: bool }
+interface Users { GetUser(UserId: string): User }
+func list_users(): [User]
+`,
+			want: []string{
+				`Validation Warning: function "list_users" should be camel case: "listUsers"`,
+				`Validation Warning: operation "GetUser" should be camel case: "getUser"`,
+				`Validation Warning: parameter "UserId" should be camel case: "userId"`,
+				`Validation Warning: field "user_id" should be camel case: "userId"`,
+				`Validation Warning: field "UserName" should be camel case: "userName"`,
+			},
+		},
+		{
+			name: "namespace",
+			src:  `namespace "Acme.Users"`,
+			want: []string{
+				`Validation Warning: namespace "Acme.Users" should be dotted lower case: "acme.users"`,
+			},
+		},
+		{
+			name: "enum values in either case",
+			src: `namespace "test"
+enum Color { RED = 0 DarkBlue = 1 light_green = 2 }
+`,
+			want: []string{
+				`Validation Warning: enum value "light_green" should be screaming snake case or pascal case: "LIGHT_GREEN"`,
+			},
+		},
+		{
+			name: "enum values in one case",
+			config: func(c *rules.NamingConfig) {
+				c.EnumValues = []rules.Case{rules.PascalCase}
+			},
+			src: `namespace "test"
+enum Color { RED = 0 DarkBlue = 1 }
+`,
+			want: []string{
+				`Validation Warning: enum value "RED" should be pascal case: "Red"`,
+			},
+		},
+		{
+			name: "acronyms",
+			config: func(c *rules.NamingConfig) {
+				c.Acronyms = rules.AcronymsUpperCase
+			},
+			src: `namespace "test"
+type T { userId: string httpURL: string }
+`,
+			want: []string{
+				`Validation Warning: field "userId" should be camel case: "userID"`,
+			},
+		},
+		{
+			name: "unchecked",
+			config: func(c *rules.NamingConfig) {
+				c.Fields = rules.CaseUnchecked
+				c.EnumValues = nil
+			},
+			src: `namespace "test"
+type T { user_id: string }
+enum E { whatever = 0 }
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := rules.DefaultNamingConfig()
+			if tt.config != nil {
+				tt.config(&config)
+			}
+			expectMessages(t, validate(t, tt.src, rules.NamingConventions(config)), tt.want)
+		})
+	}
+}
+
+func TestNamingConventionsFixes(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "field",
+			src: `namespace "test"
+type T { user_id: string }
+`,
+			want: `namespace "test"
+type T { userId: string }
+`,
+		},
+		{
+			name: "namespace",
+			src:  `namespace "Test"`,
+			want: `namespace "test"`,
+		},
+		{
+			name: "enum value and its references",
+			src: `namespace "test"
+directive @level(value: Level) on FIELD
+enum Level { low = 0 HIGH = 1 }
+type T { a: Level = low @level(value: low) b: Level = HIGH }
+`,
+			want: `namespace "test"
+directive @level(value: Level) on FIELD
+enum Level { LOW = 0 HIGH = 1 }
+type T { a: Level = LOW @level(value: LOW) b: Level = HIGH }
+`,
+		},
+		{
+			name: "enum value taken",
+			src: `namespace "test"
+enum Level { low = 0 LOW = 1 }
+`,
+			want: `namespace "test"
+enum Level { low = 0 LOW = 1 }
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := source.NewSource("test.apex", []byte(tt.src))
+			doc, err := parser.Parse(parser.ParseParams{Source: src})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var edits []source.Edit
+			for _, err := range rules.Validate(doc, rules.NamingConventions(rules.DefaultNamingConfig())) {
+				edits = append(edits, err.(*errors.Error).Fixes...)
+			}
+			body, err := source.ApplyEdits(src.Body, edits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != tt.want {
+				t.Errorf("fixed source:\n%s\nwant:\n%s", body, tt.want)
+			}
+		})
+	}
+}
+
+// An enum value used from another source is not renamed, so no fix is
+// offered rather than one that leaves the reference dangling.
+func TestNamingConventionsNoFixAcrossSources(t *testing.T) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource("main.apex", []byte(`namespace "test"
+import * from "level.apex"
+type T { a: Level = low }
+`)),
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				return `enum Level { low = 0 }`, nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := rules.Validate(doc, rules.NamingConventions(rules.DefaultNamingConfig()))
+	if len(errs) != 1 {
+		t.Fatalf("got %d errors, want 1: %v", len(errs), errs)
+	}
+	if fixes := errs[0].(*errors.Error).Fixes; fixes != nil {
+		t.Errorf("fixes = %v, want none", fixes)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+)
+
+// Language identifies a code generation target.
+type Language string
+
+const (
+	LanguageGo         Language = "Go"
+	LanguageTypeScript Language = "TypeScript"
+	LanguageRust       Language = "Rust"
+	LanguagePython     Language = "Python"
+)
+
+// ReservedWords holds the keywords and builtin names of each target language.
+// Entries are case sensitive. Callers may add languages or words before
+// running the rule.
+var ReservedWords = map[Language][]string{
+	LanguageGo: {
+		// Keywords
+		"break", "case", "chan", "const", "continue", "default", "defer",
+		"else", "fallthrough", "for", "func", "go", "goto", "if", "import",
+		"interface", "map", "package", "range", "return", "select", "struct",
+		"switch", "type", "var",
+		// Predeclared identifiers
+		"any", "bool", "byte", "comparable", "complex64", "complex128",
+		"error", "float32", "float64", "int", "int8", "int16", "int32",
+		"int64", "rune", "string", "uint", "uint8", "uint16", "uint32",
+		"uint64", "uintptr", "true", "false", "iota", "nil",
+	},
+	LanguageTypeScript: {
+		// Keywords
+		"break", "case", "catch", "class", "const", "continue", "debugger",
+		"default", "delete", "do", "else", "enum", "export", "extends",
+		"false", "finally", "for", "function", "if", "import", "in",
+		"instanceof", "new", "null", "return", "super", "switch", "this",
+		"throw", "true", "try", "typeof", "var", "void", "while", "with",
+		"implements", "interface", "let", "package", "private", "protected",
+		"public", "static", "yield", "await",
+		// Builtins
+		"Array", "Boolean", "Date", "Error", "Function", "Map", "Number",
+		"Object", "Promise", "Record", "RegExp", "Set", "String", "Symbol",
+	},
+	LanguageRust: {
+		// Keywords
+		"as", "async", "await", "break", "const", "continue", "crate", "dyn",
+		"else", "enum", "extern", "false", "fn", "for", "if", "impl", "in",
+		"let", "loop", "match", "mod", "move", "mut", "pub", "ref", "return",
+		"self", "Self", "static", "struct", "super", "trait", "true", "type",
+		"unsafe", "use", "where", "while", "abstract", "become", "box", "do",
+		"final", "macro", "override", "priv", "typeof", "unsized", "virtual",
+		"yield", "try",
+		// Prelude
+		"Box", "Option", "Result", "Some", "None", "Ok", "Err", "String",
+		"Vec",
+	},
+	LanguagePython: {
+		// Keywords
+		"False", "None", "True", "and", "as", "assert", "async", "await",
+		"break", "class", "continue", "def", "del", "elif", "else", "except",
+		"finally", "for", "from", "global", "if", "import", "in", "is",
+		"lambda", "nonlocal", "not", "or", "pass", "raise", "return", "try",
+		"while", "with", "yield",
+		// Builtin types
+		"bool", "bytes", "dict", "float", "int", "list", "object", "set",
+		"str", "tuple", "type",
+	},
+}
+
+// AllLanguages lists the languages in ReservedWords in a stable order.
+var AllLanguages = []Language{
+	LanguageGo,
+	LanguageTypeScript,
+	LanguageRust,
+	LanguagePython,
+}
+
+// ReservedWordCollisions returns a rule that warns when a type, field,
+// parameter, operation or enum value name is reserved in one of languages.
+// All known languages are checked when none are given.
+func ReservedWordCollisions(languages ...Language) ValidationRule {
+	if len(languages) == 0 {
+		languages = AllLanguages
+	}
+	return func() ast.Visitor {
+		words := make(map[string][]Language)
+		for _, lang := range languages {
+			for _, word := range ReservedWords[lang] {
+				words[word] = append(words[word], lang)
+			}
+		}
+		return &reservedWordCollisions{words: words}
+	}
+}
+
+type reservedWordCollisions struct {
+	ast.BaseVisitor
+	words map[string][]Language
+}
+
+func (r *reservedWordCollisions) VisitAlias(context ast.Context) {
+	r.check(context, "alias", context.Alias.Name)
+}
+
+func (r *reservedWordCollisions) VisitFunction(context ast.Context) {
+	r.check(context, "function", context.Function.Name)
+}
+
+func (r *reservedWordCollisions) VisitInterface(context ast.Context) {
+	r.check(context, "interface", context.Interface.Name)
+}
+
+func (r *reservedWordCollisions) VisitOperation(context ast.Context) {
+	r.check(context, "operation", context.Operation.Name)
+}
+
+func (r *reservedWordCollisions) VisitParameter(context ast.Context) {
+	r.check(context, "parameter", context.Parameter.Name)
+}
+
+func (r *reservedWordCollisions) VisitType(context ast.Context) {
+	r.check(context, "type", context.Type.Name)
+}
+
+func (r *reservedWordCollisions) VisitTypeField(context ast.Context) {
+	r.check(context, "field", context.Field.Name)
+}
+
+func (r *reservedWordCollisions) VisitEnum(context ast.Context) {
+	r.check(context, "enum", context.Enum.Name)
+}
+
+func (r *reservedWordCollisions) VisitEnumValue(context ast.Context) {
+	r.check(context, "enum value", context.EnumValue.Name)
+}
+
+func (r *reservedWordCollisions) VisitUnion(context ast.Context) {
+	r.check(context, "union", context.Union.Name)
+}
+
+func (r *reservedWordCollisions) check(context ast.Context, what string, name *ast.Name) {
+	langs, ok := r.words[name.Value]
+	if !ok {
+		return
+	}
+	names := make([]string, len(langs))
+	for i, lang := range langs {
+		names[i] = string(lang)
+	}
+	context.ReportError(
+		ValidationWarning(
+			name,
+			"%s %q is a reserved word in %s",
+			what,
+			name.Value,
+			strings.Join(names, ", "),
+		),
+	)
+}
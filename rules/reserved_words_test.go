@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/rules"
+)
+
+func TestReservedWordCollisions(t *testing.T) {
+	src := `namespace "test"
+type Promise { range: string self: string name: string }
+enum Kind { None = 0 Other = 1 }
+interface Svc { import(from: string): void }
+`
+	tests := []struct {
+		name      string
+		languages []rules.Language
+		want      []string
+	}{
+		{
+			name: "all languages",
+			want: []string{
+				`Validation Warning: operation "import" is a reserved word in Go, TypeScript, Python`,
+				`Validation Warning: parameter "from" is a reserved word in Python`,
+				`Validation Warning: type "Promise" is a reserved word in TypeScript`,
+				`Validation Warning: field "range" is a reserved word in Go`,
+				`Validation Warning: field "self" is a reserved word in Rust`,
+				`Validation Warning: enum value "None" is a reserved word in Rust, Python`,
+			},
+		},
+		{
+			name:      "one language",
+			languages: []rules.Language{rules.LanguageGo},
+			want: []string{
+				`Validation Warning: operation "import" is a reserved word in Go`,
+				`Validation Warning: field "range" is a reserved word in Go`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectMessages(t, validate(t, src, rules.ReservedWordCollisions(tt.languages...)), tt.want)
+		})
+	}
+}
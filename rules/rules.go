@@ -45,6 +45,17 @@ var Rules = []ValidationRule{
 	ValidDirectiveParameterTypes,
 	ValidDirectiveRequires,
 	ValidEnumValueIndexes,
+	ValidImports,
+}
+
+// Lints are opt-in rules that report warnings rather than errors.
+var Lints = []ValidationRule{
+	DeprecatedUsage,
+	ImportShadowing,
+	NamingConventions(DefaultNamingConfig()),
+	ReservedWordCollisions(),
+	UniqueImports,
+	UnusedImports,
 }
 
 func Validate(
@@ -80,3 +91,10 @@ func ValidationError(node ast.Node, format string, a ...interface{}) *errors.Err
 		nil,
 	)
 }
+
+func ValidationWarning(node ast.Node, format string, a ...interface{}) *errors.Error {
+	err := ValidationError(node, format, a...)
+	err.Message = fmt.Sprintf(`Validation Warning: `+format, a...)
+	err.Severity = errors.SeverityWarning
+	return err
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+)
+
+// validate parses src and returns the messages reported by rules.
+func validate(t *testing.T, src string, validationRules ...rules.ValidationRule) []string {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{Source: src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var messages []string
+	for _, err := range rules.Validate(doc, validationRules...) {
+		messages = append(messages, err.(*errors.Error).Message)
+	}
+	return messages
+}
+
+// expectMessages fails t unless got and want hold the same messages in
+// the same order.
+func expectMessages(t *testing.T, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d messages, want %d:\ngot:  %q\nwant: %q", len(got), len(want), got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("message %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRulesAcceptFunctions(t *testing.T) {
+	messages := validate(t, `namespace "test"
+
+func add(a: i32, b: Missing): i32
+`, rules.Rules...)
+	expectMessages(t, messages, []string{
+		`Validation Error: unknown type "Missing" for parameter "b" in "add"`,
+	})
+}
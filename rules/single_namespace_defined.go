@@ -18,22 +18,41 @@ package rules
 
 import (
 	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/source"
 )
 
+// SingleNamespaceDefined reports sources that define more than one
+// namespace. Documents merged from several files have a namespace for each
+// source, and the namespaces of imports are not counted.
 func SingleNamespaceDefined() ast.Visitor { return &singleNamespaceDefined{} }
 
 type singleNamespaceDefined struct {
 	ast.BaseVisitor
-	found bool
 }
 
 func (r *singleNamespaceDefined) VisitNamespace(context ast.Context) {
-	if !r.found {
-		r.found = true
-		return
+	found := make(map[*source.Source]bool)
+	imported := ast.ImportedDefinitions(context.Document)
+	for _, def := range context.Document.Definitions {
+		ns, ok := def.(*ast.NamespaceDefinition)
+		if !ok || imported[ns] {
+			continue
+		}
+		src := sourceOf(ns)
+		if !found[src] {
+			found[src] = true
+			continue
+		}
+		context.ReportError(
+			ValidationError(ns, "only one namespace can be defined"),
+		)
 	}
+}
 
-	context.ReportError(
-		ValidationError(context.Namespace, "only one namespace can be defined"),
-	)
+// sourceOf returns the source of node, or nil when it has no location.
+func sourceOf(node ast.Node) *source.Source {
+	if loc := node.GetLoc(); loc != nil {
+		return loc.Source
+	}
+	return nil
 }
@@ -20,22 +20,22 @@ import (
 	"github.com/apexlang/apex-go/ast"
 )
 
-func UniqueDirectiveNames() ast.Visitor { return &uniqueDirectiveNames{names: map[string]struct{}{}} }
+func UniqueDirectiveNames() ast.Visitor { return &uniqueDirectiveNames{names: map[string]*ast.Name{}} }
 
 type uniqueDirectiveNames struct {
 	ast.BaseVisitor
-	names map[string]struct{}
+	names map[string]*ast.Name
 }
 
 func (r *uniqueDirectiveNames) VisitDirective(context ast.Context) {
 	directive := context.Directive
 	name := directive.Name.Value
-	if _, duplicate := r.names[name]; duplicate {
+	if first, duplicate := r.names[name]; duplicate {
 		context.ReportError(
-			ValidationError(directive.Name, "duplicate directive %q", name),
+			ValidationError(directive.Name, "duplicate directive %q%s", name, definedIn(first, directive.Name)),
 		)
 		return
 	}
 
-	r.names[name] = struct{}{}
+	r.names[name] = directive.Name
 }
@@ -20,22 +20,22 @@ import (
 	"github.com/apexlang/apex-go/ast"
 )
 
-func UniqueFunctionNames() ast.Visitor { return &uniqueFunctionNames{names: map[string]struct{}{}} }
+func UniqueFunctionNames() ast.Visitor { return &uniqueFunctionNames{names: map[string]*ast.Name{}} }
 
 type uniqueFunctionNames struct {
 	ast.BaseVisitor
-	names map[string]struct{}
+	names map[string]*ast.Name
 }
 
 func (r *uniqueFunctionNames) VisitFunction(context ast.Context) {
 	function := context.Function
 	name := function.Name.Value
-	if _, duplicate := r.names[name]; duplicate {
+	if first, duplicate := r.names[name]; duplicate {
 		context.ReportError(
-			ValidationError(function.Name, "duplicate function %q", name),
+			ValidationError(function.Name, "duplicate function %q%s", name, definedIn(first, function.Name)),
 		)
 		return
 	}
 
-	r.names[name] = struct{}{}
+	r.names[name] = function.Name
 }
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/source"
+)
+
+// UniqueImports warns about import statements that repeat an earlier
+// import of the same source, and about imports that bring in a name that
+// another import already binds to a different definition, such as two
+// `as` aliases with the same name.
+func UniqueImports() ast.Visitor { return &uniqueImports{} }
+
+type uniqueImports struct{ ast.BaseVisitor }
+
+// binding is the import that binds a name.
+type binding struct {
+	imp *ast.ImportDefinition
+	imported
+}
+
+func (r *uniqueImports) VisitImportsAfter(context ast.Context) {
+	scope := newImportScope(context.Document)
+	statements := make(map[*source.Source]map[string]bool)
+	bound := make(map[*source.Source]map[string]binding)
+	for _, imp := range scope.imports {
+		src := sourceOf(imp)
+		if statements[src] == nil {
+			statements[src] = make(map[string]bool)
+			bound[src] = make(map[string]binding)
+		}
+		key := importKey(imp)
+		if statements[src][key] {
+			context.ReportError(
+				ValidationWarning(imp, "duplicate import of %q", imp.From.Value),
+			)
+			continue
+		}
+		statements[src][key] = true
+
+		for _, i := range scope.brought[imp] {
+			ref := reference(i.def, i.name.Value)
+			prev, ok := bound[src][ref]
+			if !ok {
+				bound[src][ref] = binding{imp, i}
+				continue
+			}
+			var node ast.Node = i.name
+			if imp.All {
+				node = imp.From
+			}
+			if prev.origin != i.origin {
+				context.ReportError(
+					ValidationWarning(node, "%s %q imported from %q conflicts with the one imported from %q",
+						kindName(i.def), i.name.Value, imp.From.Value, prev.imp.From.Value),
+				)
+			} else if !imp.All {
+				context.ReportError(
+					ValidationWarning(node, "%s %q is already imported from %q",
+						kindName(i.def), i.name.Value, prev.imp.From.Value),
+				)
+			}
+		}
+	}
+}
+
+// importKey identifies an import statement by what it imports.
+func importKey(imp *ast.ImportDefinition) string {
+	var b strings.Builder
+	b.WriteString(imp.Source.Name)
+	if imp.All {
+		b.WriteString(" *")
+	}
+	if imp.Alias != nil {
+		b.WriteString(" as " + imp.Alias.Value)
+	}
+	for _, n := range imp.Names {
+		b.WriteString(" " + n.Name.Value)
+		if n.Alias != nil {
+			b.WriteString(" as " + n.Alias.Value)
+		}
+	}
+	return b.String()
+}
@@ -17,14 +17,16 @@ limitations under the License.
 package rules
 
 import (
+	"fmt"
+
 	"github.com/apexlang/apex-go/ast"
 )
 
-func UniqueObjectNames() ast.Visitor { return &uniqueObjectNames{names: map[string]struct{}{}} }
+func UniqueObjectNames() ast.Visitor { return &uniqueObjectNames{names: map[string]*ast.Name{}} }
 
 type uniqueObjectNames struct {
 	ast.BaseVisitor
-	names map[string]struct{}
+	names map[string]*ast.Name
 }
 
 func (r *uniqueObjectNames) VisitInterface(context ast.Context) {
@@ -48,12 +50,22 @@ func (r *uniqueObjectNames) VisitAlias(context ast.Context) {
 }
 
 func (r *uniqueObjectNames) check(context ast.Context, name *ast.Name, typeName string) {
-	if _, duplicate := r.names[name.Value]; duplicate {
+	if first, duplicate := r.names[name.Value]; duplicate {
 		context.ReportError(
-			ValidationError(name, "duplicate %s %q", typeName, name),
+			ValidationError(name, "duplicate %s %q%s", typeName, name.Value, definedIn(first, name)),
 		)
 		return
 	}
 
-	r.names[name.Value] = struct{}{}
+	r.names[name.Value] = name
+}
+
+// definedIn returns where first is defined when it is in another source
+// than duplicate, such as another file of a merged namespace.
+func definedIn(first, duplicate ast.Node) string {
+	loc, dup := first.GetLoc(), duplicate.GetLoc()
+	if loc == nil || dup == nil || loc.Source == nil || loc.Source == dup.Source {
+		return ""
+	}
+	return fmt.Sprintf(" (also defined in %s)", loc.Source.Name)
 }
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"github.com/apexlang/apex-go/ast"
+)
+
+// UnusedImports warns about imports whose definitions are never referenced
+// by the rest of the document: names of selective imports, namespace
+// aliases without qualified references, and `import *` statements that
+// nothing is used from.
+func UnusedImports() ast.Visitor { return &unusedImports{} }
+
+type unusedImports struct{ ast.BaseVisitor }
+
+func (r *unusedImports) VisitImportsAfter(context ast.Context) {
+	doc := context.Document
+	scope := newImportScope(doc)
+	for _, imp := range scope.imports {
+		if imp.Alias != nil {
+			if !usesNamespace(doc, imp.Alias.Value) {
+				context.ReportError(
+					ValidationWarning(imp.Alias, "namespace %q imported as %q is never used", imp.From.Value, imp.Alias.Value),
+				)
+			}
+			continue
+		}
+
+		brought := scope.brought[imp]
+		refs := references(doc, brought)
+		if imp.All {
+			used := false
+			for _, i := range brought {
+				if refs[reference(i.def, i.name.Value)] {
+					used = true
+					break
+				}
+			}
+			if !used {
+				context.ReportError(
+					ValidationWarning(imp.From, "nothing imported from %q is used", imp.From.Value),
+				)
+			}
+			continue
+		}
+		for _, i := range brought {
+			if !refs[reference(i.def, i.name.Value)] {
+				context.ReportError(
+					ValidationWarning(i.name, "imported %s %q is never used", kindName(i.def), i.name.Value),
+				)
+			}
+		}
+	}
+}
+
+// usesNamespace reports whether a qualified name of doc refers to the
+// namespace imported as alias.
+func usesNamespace(doc *ast.Document, alias string) bool {
+	prefix := alias + "."
+	for ref := range references(doc, nil) {
+		if len(ref) > len(prefix) && ref[:len(prefix)] == prefix {
+			return true
+		}
+	}
+	return false
+}
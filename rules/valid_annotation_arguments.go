@@ -158,7 +158,7 @@ func (r *validAnnotationArguments) check(
 				)
 			}
 		} else {
-			definition := context.Named[v.Name.Value]
+			definition := context.Lookup(v)
 			if definition == nil {
 				// error reported by KnownTypes
 				return
@@ -119,7 +119,8 @@ dirRequiresLoop:
 					break dirRequiresLoop
 				}
 			case "NAMESPACE":
-				if findAnnotation(req.Directive.Value, context.Namespace.Annotations) {
+				if context.Namespace != nil &&
+					findAnnotation(req.Directive.Value, context.Namespace.Annotations) {
 					found = true
 					break dirRequiresLoop
 				}
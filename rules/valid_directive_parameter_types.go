@@ -42,8 +42,8 @@ func (r *validDirectiveParameterTypes) check(context ast.Context, dir *ast.Direc
 		r.check(context, dir, v.Type)
 
 	case *ast.Named:
-		typeDef, ok := context.Named[v.Name.Value]
-		if !ok {
+		typeDef := context.Lookup(v)
+		if typeDef == nil {
 			return
 		}
 
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/source"
+)
+
+// ValidImports reports the names of selective imports that the imported
+// source does not define, and namespace aliases that a source uses for
+// more than one namespace.
+func ValidImports() ast.Visitor {
+	return &validImports{aliases: map[*source.Source]map[string]*ast.ImportDefinition{}}
+}
+
+type validImports struct {
+	ast.BaseVisitor
+	aliases map[*source.Source]map[string]*ast.ImportDefinition
+	// imported are the definitions brought in by imports, including the
+	// imports of imported sources, whose aliases are their own.
+	imported map[ast.Node]bool
+}
+
+func (r *validImports) VisitImportsBefore(context ast.Context) {
+	r.imported = ast.ImportedDefinitions(context.Document)
+}
+
+func (r *validImports) VisitImport(context ast.Context) {
+	imp := context.Import
+	if imp.Alias != nil && !r.imported[imp] {
+		src := sourceOf(imp)
+		aliases, ok := r.aliases[src]
+		if !ok {
+			aliases = make(map[string]*ast.ImportDefinition)
+			r.aliases[src] = aliases
+		}
+		if first, used := aliases[imp.Alias.Value]; !used {
+			aliases[imp.Alias.Value] = imp
+		} else if first.From.Value != imp.From.Value {
+			context.ReportError(
+				ValidationError(imp.Alias, "namespace alias %q is already used for %q", imp.Alias.Value, first.From.Value),
+			)
+		}
+	}
+
+	if imp.Document == nil {
+		// The import was not resolved.
+		return
+	}
+	defined := make(map[string]bool)
+	for _, def := range imp.Document.Definitions {
+		if name := importableName(def); name != nil {
+			defined[name.Value] = true
+		}
+	}
+	for _, n := range imp.Names {
+		if !defined[n.Name.Value] {
+			context.ReportError(
+				ValidationError(n.Name, "%q is not defined in %q", n.Name.Value, imp.From.Value),
+			)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// Edit replaces the bytes between Start and End of a source body with
+// NewText. Offsets are byte offsets into the original body.
+type Edit struct {
+	Start   uint   `json:"start"`
+	End     uint   `json:"end"`
+	NewText string `json:"newText"`
+}
+
+// ApplyEdits returns a copy of body with edits applied. Edits are applied
+// against the original body and must not overlap.
+func ApplyEdits(body []byte, edits []Edit) ([]byte, error) {
+	sorted := make([]Edit, len(edits))
+	copy(sorted, edits)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	out := make([]byte, 0, len(body))
+	var last uint
+	for _, e := range sorted {
+		if e.Start > e.End || e.End > uint(len(body)) {
+			return nil, errors.New("edit " + span(e) + " is out of range")
+		}
+		if e.Start < last {
+			return nil, errors.New("edit " + span(e) + " overlaps a previous edit")
+		}
+		out = append(out, body[last:e.Start]...)
+		out = append(out, e.NewText...)
+		last = e.End
+	}
+	out = append(out, body[last:]...)
+	return out, nil
+}
+
+// span formats the range of e as [start, end).
+func span(e Edit) string {
+	return "[" + strconv.FormatUint(uint64(e.Start), 10) + ", " + strconv.FormatUint(uint64(e.End), 10) + ")"
+}
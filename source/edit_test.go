@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/source"
+)
+
+func TestApplyEdits(t *testing.T) {
+	body := []byte("type user { id: string }")
+	tests := []struct {
+		name  string
+		edits []source.Edit
+		want  string
+		err   string
+	}{
+		{name: "none", want: "type user { id: string }"},
+		{
+			name: "unordered",
+			edits: []source.Edit{
+				{Start: 12, End: 14, NewText: "key"},
+				{Start: 5, End: 9, NewText: "User"},
+			},
+			want: "type User { key: string }",
+		},
+		{
+			name:  "insert",
+			edits: []source.Edit{{Start: 24, End: 24, NewText: "\n"}},
+			want:  "type user { id: string }\n",
+		},
+		{
+			name:  "delete",
+			edits: []source.Edit{{Start: 10, End: 24}},
+			want:  "type user ",
+		},
+		{
+			name:  "out of range",
+			edits: []source.Edit{{Start: 20, End: 25}},
+			err:   "edit [20, 25) is out of range",
+		},
+		{
+			name:  "reversed",
+			edits: []source.Edit{{Start: 9, End: 5}},
+			err:   "edit [9, 5) is out of range",
+		},
+		{
+			name:  "overlapping",
+			edits: []source.Edit{{Start: 5, End: 9}, {Start: 8, End: 10}},
+			err:   "edit [8, 10) overlaps a previous edit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := source.ApplyEdits(body, tt.edits)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Errorf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ApplyEdits = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if string(body) != "type user { id: string }" {
+		t.Errorf("body was modified: %q", body)
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// Unit is the unit that columns are counted in.
+type Unit int
+
+const (
+	// Bytes counts columns in bytes.
+	Bytes Unit = iota
+	// Runes counts columns in Unicode code points.
+	Runes
+	// UTF16 counts columns in UTF-16 code units, as used by the Language
+	// Server Protocol and JavaScript hosts.
+	UTF16
+)
+
+// lineTable holds the offsets at which each line of a body starts. It is
+// computed on first use.
+type lineTable struct {
+	once   sync.Once
+	starts []uint
+}
+
+// lineStarts returns the offset of the start of every line. Lines end with
+// "\r\n", "\n" or "\r". The body must not be modified once the table has
+// been computed.
+func (s *Source) lineStarts() []uint {
+	if s.lines == nil {
+		return computeLineStarts(s.Body)
+	}
+	s.lines.once.Do(func() {
+		s.lines.starts = computeLineStarts(s.Body)
+	})
+	return s.lines.starts
+}
+
+func computeLineStarts(body []byte) []uint {
+	starts := []uint{0}
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\r':
+			if i+1 < len(body) && body[i+1] == '\n' {
+				i++
+			}
+		case '\n':
+		default:
+			continue
+		}
+		starts = append(starts, uint(i+1))
+	}
+	return starts
+}
+
+// LineCount returns the number of lines in the body. An empty body has one
+// line.
+func (s *Source) LineCount() uint {
+	if s == nil {
+		return 1
+	}
+	return uint(len(s.lineStarts()))
+}
+
+// Line returns the text of the one based line without its line terminator.
+func (s *Source) Line(line uint) ([]byte, bool) {
+	if s == nil {
+		return nil, line == 1
+	}
+	starts := s.lineStarts()
+	if line < 1 || line > uint(len(starts)) {
+		return nil, false
+	}
+	start, end := starts[line-1], s.lineEnd(starts, line)
+	return s.Body[start:end], true
+}
+
+// lineEnd returns the offset of the line terminator of the one based line,
+// or the length of the body for the last line.
+func (s *Source) lineEnd(starts []uint, line uint) uint {
+	if line >= uint(len(starts)) {
+		return uint(len(s.Body))
+	}
+	end := starts[line]
+	if end > 0 && s.Body[end-1] == '\n' {
+		end--
+	}
+	if end > starts[line-1] && s.Body[end-1] == '\r' {
+		end--
+	}
+	return end
+}
+
+// LineColumn converts a byte offset into a one based line and column,
+// counting the column in unit. Offsets past the end of the body are
+// clamped to its end.
+func (s *Source) LineColumn(offset uint, unit Unit) (line, column uint) {
+	if s == nil {
+		return 1, offset + 1
+	}
+	if offset > uint(len(s.Body)) {
+		offset = uint(len(s.Body))
+	}
+	starts := s.lineStarts()
+	// The line is the last one that starts at or before offset.
+	i := sort.Search(len(starts), func(i int) bool {
+		return starts[i] > offset
+	}) - 1
+	return uint(i) + 1, columns(s.Body[starts[i]:offset], unit) + 1
+}
+
+// Offset converts a one based line and column, counted in unit, into a
+// byte offset. ok is false when the line does not exist or the column is
+// past the end of the line, in which case the offset is clamped to the
+// nearest position in the body.
+func (s *Source) Offset(line, column uint, unit Unit) (offset uint, ok bool) {
+	if s == nil {
+		return 0, line == 1 && column == 1
+	}
+	starts := s.lineStarts()
+	if line < 1 {
+		return 0, false
+	}
+	if line > uint(len(starts)) {
+		return uint(len(s.Body)), false
+	}
+	if column < 1 {
+		return starts[line-1], false
+	}
+
+	start, end := starts[line-1], s.lineEnd(starts, line)
+	want := column - 1
+	if unit == Bytes {
+		if start+want > end {
+			return end, false
+		}
+		return start + want, true
+	}
+	i := start
+	for n := uint(0); n < want; {
+		if i >= end {
+			return end, false
+		}
+		r, size := utf8.DecodeRune(s.Body[i:end])
+		n += runeColumns(r, unit)
+		i += uint(size)
+	}
+	return i, true
+}
+
+// columns returns the width of text in unit.
+func columns(text []byte, unit Unit) uint {
+	switch unit {
+	case Runes:
+		return uint(utf8.RuneCount(text))
+	case UTF16:
+		var n uint
+		for len(text) > 0 {
+			r, size := utf8.DecodeRune(text)
+			n += runeColumns(r, UTF16)
+			text = text[size:]
+		}
+		return n
+	}
+	return uint(len(text))
+}
+
+func runeColumns(r rune, unit Unit) uint {
+	if unit == UTF16 && r >= 0x10000 {
+		// Supplementary characters are encoded as surrogate pairs.
+		return 2
+	}
+	return 1
+}
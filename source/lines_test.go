@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source_test
+
+import (
+	"testing"
+
+	"github.com/apexlang/apex-go/source"
+)
+
+// body has "\r\n", "\r" and "\n" line endings. "é" is two bytes and one
+// UTF-16 unit; "😀" is four bytes and two UTF-16 units.
+const body = "a\r\nbé😀c\rd\n"
+
+func TestLines(t *testing.T) {
+	s := source.NewSource("test", []byte(body))
+	if got := s.LineCount(); got != 4 {
+		t.Errorf("LineCount = %d, want 4", got)
+	}
+	for i, want := range []string{"a", "bé😀c", "d", ""} {
+		line, ok := s.Line(uint(i + 1))
+		if !ok || string(line) != want {
+			t.Errorf("Line(%d) = %q, %v, want %q", i+1, line, ok, want)
+		}
+	}
+	for _, line := range []uint{0, 5} {
+		if _, ok := s.Line(line); ok {
+			t.Errorf("Line(%d) exists", line)
+		}
+	}
+}
+
+func TestLineColumn(t *testing.T) {
+	s := source.NewSource("test", []byte(body))
+	tests := []struct {
+		offset uint
+		unit   source.Unit
+		line   uint
+		column uint
+	}{
+		{0, source.Bytes, 1, 1},
+		{1, source.Bytes, 1, 2},
+		{3, source.Bytes, 2, 1},
+		{10, source.Bytes, 2, 8},
+		{10, source.Runes, 2, 4},
+		{10, source.UTF16, 2, 5},
+		{6, source.UTF16, 2, 3},
+		{12, source.UTF16, 3, 1},
+		{14, source.Bytes, 4, 1},
+		{100, source.Bytes, 4, 1},
+	}
+	for _, tt := range tests {
+		line, column := s.LineColumn(tt.offset, tt.unit)
+		if line != tt.line || column != tt.column {
+			t.Errorf("LineColumn(%d, %d) = %d:%d, want %d:%d", tt.offset, tt.unit, line, column, tt.line, tt.column)
+		}
+	}
+}
+
+func TestOffset(t *testing.T) {
+	s := source.NewSource("test", []byte(body))
+	tests := []struct {
+		line, column uint
+		unit         source.Unit
+		offset       uint
+		ok           bool
+	}{
+		{1, 1, source.Bytes, 0, true},
+		{1, 2, source.Bytes, 1, true},
+		{2, 8, source.Bytes, 10, true},
+		{2, 4, source.Runes, 10, true},
+		{2, 5, source.UTF16, 10, true},
+		{2, 6, source.UTF16, 11, true},
+		{3, 1, source.UTF16, 12, true},
+		{4, 1, source.Bytes, 14, true},
+		{1, 3, source.Bytes, 1, false},
+		{2, 7, source.UTF16, 11, false},
+		{2, 0, source.Bytes, 3, false},
+		{0, 1, source.Bytes, 0, false},
+		{5, 1, source.Bytes, 14, false},
+	}
+	for _, tt := range tests {
+		offset, ok := s.Offset(tt.line, tt.column, tt.unit)
+		if offset != tt.offset || ok != tt.ok {
+			t.Errorf("Offset(%d, %d, %d) = %d, %v, want %d, %v", tt.line, tt.column, tt.unit, offset, ok, tt.offset, tt.ok)
+		}
+	}
+}
+
+// Offsets and positions convert back and forth in every unit.
+func TestLineColumnOffsetRoundTrip(t *testing.T) {
+	s := source.NewSource("test", []byte(body))
+	boundaries := []uint{0, 1, 3, 4, 6, 10, 11, 12, 13, 14}
+	for _, unit := range []source.Unit{source.Bytes, source.Runes, source.UTF16} {
+		for _, offset := range boundaries {
+			line, column := s.LineColumn(offset, unit)
+			got, ok := s.Offset(line, column, unit)
+			if !ok || got != offset {
+				t.Errorf("unit %d: offset %d -> %d:%d -> %d, %v", unit, offset, line, column, got, ok)
+			}
+		}
+	}
+}
+
+// Sources that are not created with NewSource compute their lines on
+// every use.
+func TestLinesWithoutNewSource(t *testing.T) {
+	s := &source.Source{Name: "test", Body: []byte("a\nb")}
+	if line, column := s.LineColumn(2, source.Bytes); line != 2 || column != 1 {
+		t.Errorf("LineColumn = %d:%d, want 2:1", line, column)
+	}
+	var nilSource *source.Source
+	if got := nilSource.LineCount(); got != 1 {
+		t.Errorf("nil LineCount = %d, want 1", got)
+	}
+	if line, column := nilSource.LineColumn(4, source.Bytes); line != 1 || column != 5 {
+		t.Errorf("nil LineColumn = %d:%d, want 1:5", line, column)
+	}
+}
@@ -19,11 +19,16 @@ package source
 type Source struct {
 	Body []byte `json:"body,omitempty"`
 	Name string `json:"name,omitempty"`
+
+	// lines caches the line table of Body. It is shared by copies of the
+	// source and is nil for sources that are not created with NewSource.
+	lines *lineTable
 }
 
 func NewSource(name string, body []byte) *Source {
 	return &Source{
-		Name: name,
-		Body: body,
+		Name:  name,
+		Body:  body,
+		lines: &lineTable{},
 	}
 }
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wapc
+
+import (
+	"context"
+
+	"github.com/apexlang/apex-go/model"
+	msgpack "github.com/wapc/tinygo-msgpack"
+	"github.com/wapc/wapc-guest-tinygo"
+)
+
+// RegisterFormatConverter registers the convert and load operations of
+// the Parser service.
+func RegisterFormatConverter(svc model.FormatConverter) {
+	wapc.RegisterFunction("apexlang.v1.Parser/convert", parserConvertWrapper(svc))
+	wapc.RegisterFunction("apexlang.v1.Parser/load", parserLoadWrapper(svc))
+}
+
+func parserConvertWrapper(svc model.FormatConverter) wapc.Function {
+	return func(payload []byte) ([]byte, error) {
+		ctx := context.Background()
+		decoder := msgpack.NewDecoder(payload)
+		var inputArgs model.ParserConvertArgs
+		inputArgs.Decode(&decoder)
+		response, err := svc.Convert(ctx, inputArgs.Source, inputArgs.Format)
+		if err != nil {
+			return nil, err
+		}
+		var sizer msgpack.Sizer
+		sizer.WriteByteArray(response)
+		ua := make([]byte, sizer.Len())
+		encoder := msgpack.NewEncoder(ua)
+		encoder.WriteByteArray(response)
+		return ua, nil
+	}
+}
+
+func parserLoadWrapper(svc model.FormatConverter) wapc.Function {
+	return func(payload []byte) ([]byte, error) {
+		ctx := context.Background()
+		decoder := msgpack.NewDecoder(payload)
+		var inputArgs model.ParserLoadArgs
+		inputArgs.Decode(&decoder)
+		response, err := svc.Load(ctx, inputArgs.Data, inputArgs.Format)
+		if err != nil {
+			return nil, err
+		}
+		return msgpack.ToBytes(response)
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/errors"
+	"github.com/apexlang/apex-go/model"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+// result is the parsed state of a file.
+type result struct {
+	// imports are the files imported directly.
+	imports []string
+
+	doc *ast.Document
+	// namespace is the name of the namespace the file declares.
+	namespace string
+	// diagnostics are the errors reading or parsing the file.
+	diagnostics []error
+	// checked is the validated state of a file that is not merged with
+	// others because it is not loaded or declares no namespace.
+	checked *namespace
+}
+
+// namespace is the validated state of the files declaring a namespace.
+type namespace struct {
+	// results are those of the files when they were validated, in path
+	// order.
+	results []*result
+	paths   []string
+	// diagnostics are the errors and warnings, by path.
+	diagnostics map[string][]error
+	doc         *ast.Document
+	// model is nil when the files have errors.
+	model   *model.Namespace
+	sources *model.SourceMap
+}
+
+// compile returns the result of the file at path, parsing it unless the
+// cached result is current.
+func (w *Workspace) compile(path string) *result {
+	f := w.file(path)
+	if f.result != nil {
+		return f.result
+	}
+
+	r := &result{}
+	f.result = r
+	f.deps = map[string]string{f.path: f.hash}
+	f.unresolved = false
+	if f.err != nil {
+		r.diagnostics = []error{f.err}
+		return r
+	}
+
+	src := source.NewSource(f.path, f.content)
+	c := compilation{w: w, file: f, result: r}
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: src,
+		Options: parser.ParseOptions{
+			Resolver: c.resolve,
+			Locator:  c.locate,
+		},
+	})
+	if err != nil {
+		if e, ok := err.(*errors.Error); ok && e.Source != nil && e.Source.Name != f.path {
+			err = fmt.Errorf("imported specification %s has errors", e.Source.Name)
+		}
+		r.diagnostics = []error{err}
+		return r
+	}
+	r.doc = doc
+	if ns := namespaceOf(doc); ns != nil {
+		r.namespace = ns.Name.Value
+	}
+	return r
+}
+
+// check returns the result of the file at path and its validated state,
+// which is nil when the file does not parse. Loaded files declaring the
+// same namespace are validated together.
+func (w *Workspace) check(path string) (*result, *namespace) {
+	f := w.file(path)
+	r := w.compile(path)
+	if r.doc == nil {
+		return r, nil
+	}
+	if !f.loaded || r.namespace == "" {
+		if r.checked == nil {
+			r.checked = w.validate([]string{f.path}, []*result{r})
+		}
+		return r, r.checked
+	}
+
+	var (
+		paths   []string
+		results []*result
+	)
+	for _, other := range w.sortedFiles() {
+		if !other.loaded {
+			continue
+		}
+		if o := w.compile(other.path); o.doc != nil && o.namespace == r.namespace {
+			paths = append(paths, other.path)
+			results = append(results, o)
+		}
+	}
+	// The files are validated again when one of them, or the set of
+	// files declaring the namespace, changed.
+	if ns := w.namespaces[r.namespace]; ns != nil && sameResults(ns.results, results) {
+		return r, ns
+	}
+	ns := w.validate(paths, results)
+	w.namespaces[r.namespace] = ns
+	return r, ns
+}
+
+func sameResults(a, b []*result) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// validate merges the documents of results, validates them and converts
+// them to a model when there are no errors.
+func (w *Workspace) validate(paths []string, results []*result) *namespace {
+	ns := &namespace{
+		results:     results,
+		paths:       paths,
+		diagnostics: make(map[string][]error),
+	}
+	docs := make([]*ast.Document, len(results))
+	files := make(map[*source.Source]string, len(results))
+	for i, r := range results {
+		docs[i] = r.doc
+		files[r.doc.Loc.Source] = paths[i]
+	}
+	doc := docs[0]
+	if len(docs) > 1 {
+		var err error
+		if doc, err = Merge(docs...); err != nil {
+			ns.diagnostics[paths[0]] = []error{err}
+			return ns
+		}
+	}
+	ns.doc = doc
+
+	validation := w.Rules
+	if validation == nil {
+		validation = rules.Rules
+	}
+	errs := rules.Validate(doc, validation...)
+	reported := make(map[string]bool)
+	for _, err := range errs {
+		path := paths[0]
+		if e, ok := err.(*errors.Error); ok && e.Source != nil {
+			var ok bool
+			if path, ok = files[e.Source]; !ok {
+				// Imported files report their own diagnostics when they
+				// are loaded, but their errors fail the importing file.
+				if !errors.HasErrors([]error{e}) {
+					continue
+				}
+				path = w.importer(paths, e.Source.Name)
+				if reported[path+"\x00"+e.Source.Name] {
+					continue
+				}
+				reported[path+"\x00"+e.Source.Name] = true
+				err = fmt.Errorf("imported specification %s has errors", e.Source.Name)
+			}
+		}
+		ns.diagnostics[path] = append(ns.diagnostics[path], err)
+	}
+	if errors.HasErrors(errs) {
+		return ns
+	}
+
+	m, errs := model.Convert(doc)
+	if len(errs) > 0 {
+		ns.diagnostics[paths[0]] = append(ns.diagnostics[paths[0]], errs...)
+		return ns
+	}
+	ns.model = m
+	ns.sources = model.NewSourceMap(doc)
+	return ns
+}
+
+// importer returns the first of paths that imports the file at name,
+// directly or not, or the first path when none does.
+func (w *Workspace) importer(paths []string, name string) string {
+	for _, path := range paths {
+		seen := map[string]bool{path: true}
+		queue := []string{path}
+		for len(queue) > 0 {
+			r := w.compile(queue[0])
+			queue = queue[1:]
+			for _, imp := range r.imports {
+				if imp == name {
+					return path
+				}
+				if !seen[imp] {
+					seen[imp] = true
+					queue = append(queue, imp)
+				}
+			}
+		}
+	}
+	return paths[0]
+}
+
+// compilation tracks the imports read while compiling a file. The
+// parser calls resolve for one import at a time.
+type compilation struct {
+	w      *Workspace
+	file   *file
+	result *result
+}
+
+// locate returns the path of the file that an import refers to.
+func (c *compilation) locate(location, from string) (string, error) {
+	name, err := c.w.resolver.Locate(location, from)
+	if err != nil {
+		c.file.unresolved = true
+	}
+	return name, err
+}
+
+// resolve reads an import from the workspace, recording it as a
+// dependency of the result. The parser reports import cycles before
+// resolving them.
+func (c *compilation) resolve(location, from string) (string, error) {
+	name, err := c.locate(location, from)
+	if err != nil {
+		return "", err
+	}
+	f := c.w.file(name)
+	c.file.deps[f.path] = f.hash
+	if from == c.file.path {
+		c.result.imports = append(c.result.imports, f.path)
+	}
+	if f.err != nil {
+		return "", f.err
+	}
+	return string(f.content), nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	stderrs "errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/kinds"
+	"github.com/apexlang/apex-go/query"
+	"github.com/apexlang/apex-go/rules"
+	"github.com/apexlang/apex-go/source"
+)
+
+// Merge combines documents that declare the same namespace, such as the
+// files of a namespace split across several files, into one document.
+//
+// Documents are ordered by source name and keep their definitions and
+// locations, so that each definition can be traced to its file and
+// rules.Validate checks the namespace as a whole, reporting definitions
+// declared by more than one file with the Unique rules. The first
+// namespace definition is that of the first document, with the first
+// description and the annotations of all of them. Repeated imports, and
+// the definitions they bring in, are kept once.
+func Merge(docs ...*ast.Document) (*ast.Document, error) {
+	if len(docs) == 0 {
+		return nil, stderrs.New("no documents to merge")
+	}
+	sorted := make([]*ast.Document, len(docs))
+	copy(sorted, docs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sourceName(sorted[i]) < sourceName(sorted[j])
+	})
+
+	var (
+		ns          *ast.NamespaceDefinition
+		definitions []ast.Node
+		imports     = make(map[string]bool)
+		imported    = make(map[importedKey]bool)
+	)
+	for _, doc := range sorted {
+		src := doc.Loc.Source
+		own := namespaceOf(doc)
+		if own == nil {
+			return nil, fmt.Errorf("%s does not declare a namespace", src.Name)
+		}
+		first := ns == nil
+		if first {
+			merged := *own
+			merged.Annotations = append([]*ast.Annotation(nil), own.Annotations...)
+			ns = &merged
+		} else if own.Name.Value != ns.Name.Value {
+			return nil, rules.ValidationError(own.Name,
+				"namespace %q does not match namespace %q of %s",
+				own.Name.Value, ns.Name.Value, ns.Loc.Source.Name)
+		} else {
+			mergeNamespace(ns, own)
+		}
+
+		names := importNames(doc)
+		for _, def := range doc.Definitions {
+			if def == own && first {
+				definitions = append(definitions, ns)
+				continue
+			}
+			if imp, ok := def.(*ast.ImportDefinition); ok {
+				key := importKey(imp)
+				if !imports[key] {
+					imports[key] = true
+					definitions = append(definitions, def)
+				}
+				continue
+			}
+			// The definitions that selective imports bring in are named
+			// by the import.
+			var from *source.Source
+			if imp, ok := names[query.NameOf(def)]; ok {
+				from = imp.Source
+			} else if loc := def.GetLoc(); loc != nil && loc.Source != src {
+				from = loc.Source
+			}
+			if from != nil {
+				key := importedKey{def.GetKind(), nameOf(def), from.Name}
+				if imported[key] {
+					continue
+				}
+				imported[key] = true
+			}
+			definitions = append(definitions, def)
+		}
+	}
+	return ast.NewDocument(sorted[0].Loc, definitions), nil
+}
+
+// importedKey identifies an imported definition across documents.
+type importedKey struct {
+	kind   kinds.Kind
+	name   string
+	source string
+}
+
+func sourceName(doc *ast.Document) string {
+	if doc.Loc != nil && doc.Loc.Source != nil {
+		return doc.Loc.Source.Name
+	}
+	return ""
+}
+
+// namespaceOf returns the namespace that doc declares itself, as opposed
+// to those of the documents it imports.
+func namespaceOf(doc *ast.Document) *ast.NamespaceDefinition {
+	for _, def := range doc.Definitions {
+		if ns, ok := def.(*ast.NamespaceDefinition); ok && ns.Loc.Source == doc.Loc.Source {
+			return ns
+		}
+	}
+	return nil
+}
+
+// mergeNamespace adds the description and annotations of other to ns
+// when ns does not have them.
+func mergeNamespace(ns, other *ast.NamespaceDefinition) {
+	if ns.Description == nil {
+		ns.Description = other.Description
+	}
+	for _, a := range other.Annotations {
+		if ns.Annotation(a.Name.Value) == nil {
+			ns.Annotations = append(ns.Annotations, a)
+		}
+	}
+}
+
+// importNames returns the names of the imports of doc, by which the parser
+// names the definitions that selective imports bring in.
+func importNames(doc *ast.Document) map[*ast.Name]*ast.ImportDefinition {
+	names := make(map[*ast.Name]*ast.ImportDefinition)
+	for _, def := range doc.Definitions {
+		if imp, ok := def.(*ast.ImportDefinition); ok && imp.Source != nil {
+			for _, n := range imp.Names {
+				names[n.Name] = imp
+				if n.Alias != nil {
+					names[n.Alias] = imp
+				}
+			}
+		}
+	}
+	return names
+}
+
+// importKey identifies an import statement by what it imports.
+func importKey(imp *ast.ImportDefinition) string {
+	var b strings.Builder
+	if imp.Source != nil {
+		b.WriteString(imp.Source.Name)
+	} else {
+		b.WriteString(imp.From.Value)
+	}
+	if imp.All {
+		b.WriteString(" *")
+	}
+	if imp.Alias != nil {
+		b.WriteString(" as " + imp.Alias.Value)
+	}
+	for _, n := range imp.Names {
+		b.WriteString(" " + n.Name.Value)
+		if n.Alias != nil {
+			b.WriteString(":" + n.Alias.Value)
+		}
+	}
+	return b.String()
+}
+
+func nameOf(def ast.Node) string {
+	if name := query.NameOf(def); name != nil {
+		return name.Value
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/parser"
+	"github.com/apexlang/apex-go/query"
+	"github.com/apexlang/apex-go/source"
+	"github.com/apexlang/apex-go/workspace"
+)
+
+var common = map[string]string{
+	"common.apex": `type Item { name: string }
+`,
+}
+
+func parse(t *testing.T, name, body string) *ast.Document {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(name, []byte(body)),
+		Options: parser.ParseOptions{
+			Resolver: func(location, from string) (string, error) {
+				return common[location], nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestMerge(t *testing.T) {
+	b := parse(t, "b.apex", `"Second"
+namespace "shop" @path("/b")
+import * from "common.apex"
+
+type B { item: Item }
+`)
+	a := parse(t, "a.apex", `namespace "shop" @version("1")
+import * from "common.apex"
+
+type A { item: Item }
+`)
+	doc, err := workspace.Merge(b, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, def := range doc.Definitions {
+		name := ""
+		if n := query.NameOf(def); n != nil {
+			name = n.Value
+		}
+		got = append(got, string(def.GetKind())+" "+name+" "+def.GetLoc().Source.Name)
+	}
+	// Each file keeps its namespace definition, and the repeated import
+	// is dropped with the definition it brings in.
+	want := []string{
+		"NamespaceDefinition shop a.apex",
+		"TypeDefinition Item common.apex",
+		"ImportDefinition  a.apex",
+		"TypeDefinition A a.apex",
+		"NamespaceDefinition shop b.apex",
+		"TypeDefinition B b.apex",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("definitions = %q, want %q", got, want)
+	}
+
+	ns := doc.Definitions[0].(*ast.NamespaceDefinition)
+	if ns.Description == nil || ns.Description.Value != "Second" {
+		t.Errorf("description = %v, want Second", ns.Description)
+	}
+	var annotations []string
+	for _, a := range ns.Annotations {
+		annotations = append(annotations, a.Name.Value)
+	}
+	if want := []string{"version", "path"}; !reflect.DeepEqual(annotations, want) {
+		t.Errorf("annotations = %v, want %v", annotations, want)
+	}
+	// The namespace of the first document is not changed.
+	if a.Definitions[0].(*ast.NamespaceDefinition).Description != nil {
+		t.Error("Merge changed the namespace of a document")
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		docs []*ast.Document
+		want string
+	}{
+		{"none", nil, "no documents"},
+		{"no namespace", []*ast.Document{
+			parse(t, "a.apex", `namespace "a"`),
+			parse(t, "b.apex", `type B { name: string }`),
+		}, "b.apex does not declare a namespace"},
+		{"different", []*ast.Document{
+			parse(t, "a.apex", `namespace "a"`),
+			parse(t, "b.apex", `namespace "b"`),
+		}, `namespace "b" does not match namespace "a" of a.apex`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := workspace.Merge(tt.docs...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Merge() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
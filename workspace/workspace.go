@@ -0,0 +1,346 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package workspace compiles a set of Apex specifications. It tracks the
+// imports between files and caches the parsed, validated and converted
+// result of each file until the content of the file or of anything it
+// imports changes. Loaded files declaring the same namespace are merged
+// and validated as one namespace.
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/apexlang/apex-go/ast"
+	"github.com/apexlang/apex-go/model"
+	"github.com/apexlang/apex-go/resolver"
+	"github.com/apexlang/apex-go/rules"
+)
+
+// Workspace is a set of specification files and their compiled results.
+// It is safe for concurrent use.
+type Workspace struct {
+	// Rules validate each file. rules.Rules are used when nil.
+	Rules []rules.ValidationRule
+
+	mu       sync.Mutex
+	resolver *resolver.FileSystem
+	files    map[string]*file
+	// namespaces are the validated namespaces of the loaded files, by
+	// name.
+	namespaces map[string]*namespace
+}
+
+// file is a specification in the workspace, either loaded explicitly or
+// imported by another file.
+type file struct {
+	path    string
+	loaded  bool
+	overlay bool
+	content []byte
+	hash    string
+	// err is the error reading the file from disk.
+	err error
+
+	// deps are the content hashes of the file and of every file it
+	// imports, directly or not, when it was last compiled. They are kept
+	// when result is invalidated.
+	deps map[string]string
+	// unresolved is true when an import could not be located when the
+	// file was last compiled.
+	unresolved bool
+	result     *result
+}
+
+// New returns an empty workspace resolving non-relative imports from roots,
+// as resolver.FileSystem does.
+func New(roots ...string) *Workspace {
+	return &Workspace{
+		resolver:   resolver.NewFileSystem(roots...),
+		files:      make(map[string]*file),
+		namespaces: make(map[string]*namespace),
+	}
+}
+
+// Roots returns the import roots of w.
+func (w *Workspace) Roots() []string {
+	return w.resolver.Roots
+}
+
+// Load adds files to w. Directories are walked for .apex files.
+func (w *Workspace) Load(paths ...string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			w.file(path).loaded = true
+			continue
+		}
+		err = filepath.WalkDir(path, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && filepath.Ext(path) == ".apex" {
+				w.file(path).loaded = true
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Files returns the paths of the files in w, including those only
+// imported by others, in order.
+func (w *Workspace) Files() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	paths := make([]string, 0, len(w.files))
+	for path := range w.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Open sets the content of a file, such as an unsaved editor buffer, in
+// place of the file on disk. It returns the file and the files importing
+// it, whose results are invalidated.
+func (w *Workspace) Open(path string, content []byte) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	f := w.file(path)
+	f.loaded = true
+	f.overlay = true
+	return w.setContent(f, content, nil)
+}
+
+// Close discards the content set by Open and reads the file from disk
+// again. It returns the same files as Open.
+func (w *Workspace) Close(path string) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	f, ok := w.files[filepath.Clean(path)]
+	if !ok || !f.overlay {
+		return nil
+	}
+	f.overlay = false
+	return w.read(f)
+}
+
+// Changed reads a file from disk again after it changed. Unless its
+// content is the same as before, it returns the file and the files
+// importing it, directly or not, whose results are invalidated. Files
+// opened with Open are not affected.
+func (w *Workspace) Changed(path string) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	f, ok := w.files[filepath.Clean(path)]
+	if !ok || f.overlay {
+		return nil
+	}
+	return w.read(f)
+}
+
+// Remove removes a file from w. It returns the file and the files
+// importing it, whose results are invalidated. A file that is still
+// imported is read from disk again when an importer is compiled.
+func (w *Workspace) Remove(path string) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	path = filepath.Clean(path)
+	if _, ok := w.files[path]; !ok {
+		return nil
+	}
+	affected := w.invalidate(path)
+	delete(w.files, path)
+	return affected
+}
+
+// file returns the file at path, reading it if it is new to w.
+func (w *Workspace) file(path string) *file {
+	path = filepath.Clean(path)
+	f, ok := w.files[path]
+	if !ok {
+		f = &file{path: path}
+		w.files[path] = f
+		w.read(f)
+		// The new file may be an import that could not be located.
+		for _, other := range w.files {
+			if other.unresolved {
+				other.result = nil
+			}
+		}
+	}
+	return f
+}
+
+func (w *Workspace) read(f *file) []string {
+	content, err := os.ReadFile(f.path)
+	return w.setContent(f, content, err)
+}
+
+func (w *Workspace) setContent(f *file, content []byte, err error) []string {
+	hash := contentHash(content)
+	if f.hash == hash && f.err == nil && err == nil {
+		return nil
+	}
+	f.content, f.hash, f.err = content, hash, err
+	return w.invalidate(f.path)
+}
+
+// invalidate drops the results that depend on path and returns the paths
+// of their files.
+func (w *Workspace) invalidate(path string) []string {
+	affected := []string{path}
+	for _, f := range w.files {
+		if _, ok := f.deps[path]; ok {
+			f.result = nil
+			if f.path != path {
+				affected = append(affected, f.path)
+			}
+		}
+	}
+	sort.Strings(affected[1:])
+	return affected
+}
+
+// Document returns the parsed document of a file, or nil when it does not
+// parse.
+func (w *Workspace) Document(path string) *ast.Document {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.compile(path).doc
+}
+
+// Diagnostics returns the errors and warnings located in a file, or
+// about it, such as an import that cannot be resolved. The definitions of
+// the other loaded files declaring the same namespace are visible to the
+// file.
+func (w *Workspace) Diagnostics(path string) []error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	r, ns := w.check(path)
+	if ns == nil {
+		return r.diagnostics
+	}
+	return ns.diagnostics[filepath.Clean(path)]
+}
+
+// Imports returns the files a file imports directly.
+func (w *Workspace) Imports(path string) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.compile(path).imports
+}
+
+// Importers returns the files that import a file directly.
+func (w *Workspace) Importers(path string) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	path = filepath.Clean(path)
+	var importers []string
+	for _, f := range w.sortedFiles() {
+		for _, imp := range w.compile(f.path).imports {
+			if imp == path {
+				importers = append(importers, f.path)
+				break
+			}
+		}
+	}
+	return importers
+}
+
+// Namespace is the model of a namespace and the files declaring it.
+type Namespace struct {
+	Name string
+	// Path is the first of Paths.
+	Path string
+	// Paths are the files declaring the namespace, in order.
+	Paths []string
+	Model *model.Namespace
+	// Sources locates the definitions of Model in their files.
+	Sources *model.SourceMap
+}
+
+// Namespaces returns the models of the namespaces declared by the loaded
+// files that compile without errors, ordered by namespace name and path.
+// Files declaring the same namespace are merged into one model, as Merge
+// does.
+func (w *Workspace) Namespaces() []Namespace {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var namespaces []Namespace
+	seen := make(map[*namespace]bool)
+	for _, f := range w.sortedFiles() {
+		if !f.loaded {
+			continue
+		}
+		if _, ns := w.check(f.path); ns != nil && ns.model != nil && !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, Namespace{
+				Name:    ns.model.Name,
+				Path:    ns.paths[0],
+				Paths:   ns.paths,
+				Model:   ns.model,
+				Sources: ns.sources,
+			})
+		}
+	}
+	sort.SliceStable(namespaces, func(i, j int) bool {
+		return namespaces[i].Name < namespaces[j].Name
+	})
+	return namespaces
+}
+
+func (w *Workspace) sortedFiles() []*file {
+	files := make([]*file, 0, len(w.files))
+	for _, f := range w.files {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path < files[j].path
+	})
+	return files
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
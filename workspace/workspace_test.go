@@ -0,0 +1,354 @@
+/*
+Copyright 2024 The Apex Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apexlang/apex-go/workspace"
+)
+
+// tree writes files, keyed by slash separated paths, under a temporary
+// directory and returns it.
+func tree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, text := range files {
+		write(t, filepath.Join(dir, filepath.FromSlash(name)), text)
+	}
+	return dir
+}
+
+func write(t *testing.T, path, text string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// rel returns paths relative to dir, with slashes.
+func rel(t *testing.T, dir string, paths []string) []string {
+	t.Helper()
+	var names []string
+	for _, path := range paths {
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, filepath.ToSlash(name))
+	}
+	return names
+}
+
+func messages(errs []error) []string {
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return msgs
+}
+
+var specs = map[string]string{
+	"main.apex": `namespace "main"
+import * from "./types.apex"
+
+type Order {
+  item: Item
+}
+`,
+	"types.apex": `namespace "types"
+import { Name } from "./names.apex"
+
+type Item {
+  name: Name
+}
+`,
+	"names.apex": `alias Name = string
+`,
+}
+
+func load(t *testing.T, files map[string]string) (*workspace.Workspace, string) {
+	t.Helper()
+	dir := tree(t, files)
+	w := workspace.New()
+	if err := w.Load(dir); err != nil {
+		t.Fatal(err)
+	}
+	return w, dir
+}
+
+func TestLoad(t *testing.T) {
+	w, dir := load(t, map[string]string{
+		"a.apex":         `namespace "a"`,
+		"nested/b.apex":  `namespace "b"`,
+		"nested/c.axdl":  `namespace "c"`,
+		"nested/d/e.txt": ``,
+	})
+	want := []string{"a.apex", "nested/b.apex"}
+	if got := rel(t, dir, w.Files()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Files() = %v, want %v", got, want)
+	}
+	if err := w.Load(filepath.Join(dir, "missing.apex")); err == nil {
+		t.Error("Load of a missing file succeeded")
+	}
+}
+
+func TestImports(t *testing.T) {
+	w, dir := load(t, specs)
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	tests := []struct {
+		name      string
+		imports   []string
+		importers []string
+	}{
+		{"main.apex", []string{"types.apex"}, nil},
+		{"types.apex", []string{"names.apex"}, []string{"main.apex"}},
+		{"names.apex", nil, []string{"types.apex"}},
+	}
+	for _, tt := range tests {
+		if got := rel(t, dir, w.Imports(path(tt.name))); !reflect.DeepEqual(got, tt.imports) {
+			t.Errorf("Imports(%s) = %v, want %v", tt.name, got, tt.imports)
+		}
+		if got := rel(t, dir, w.Importers(path(tt.name))); !reflect.DeepEqual(got, tt.importers) {
+			t.Errorf("Importers(%s) = %v, want %v", tt.name, got, tt.importers)
+		}
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	files := map[string]string{
+		"orders.apex": `namespace "shop"
+
+type Order {
+  item: Item
+}
+`,
+		"items.apex": `namespace "shop"
+
+type Item {
+  name: string
+}
+`,
+		"broken.apex": `namespace "broken"
+
+alias Broken = Missing
+`,
+		"other.apex": `namespace "other"`,
+	}
+	w, dir := load(t, files)
+
+	var names []string
+	for _, ns := range w.Namespaces() {
+		names = append(names, ns.Name)
+		switch ns.Name {
+		case "shop":
+			if want := []string{"items.apex", "orders.apex"}; !reflect.DeepEqual(rel(t, dir, ns.Paths), want) {
+				t.Errorf("shop paths = %v, want %v", rel(t, dir, ns.Paths), want)
+			}
+			if ns.Path != ns.Paths[0] {
+				t.Errorf("shop path = %s, want %s", ns.Path, ns.Paths[0])
+			}
+			if len(ns.Model.Types) != 2 {
+				t.Errorf("shop has %d types, want 2", len(ns.Model.Types))
+			}
+		}
+	}
+	if want := []string{"other", "shop"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Namespaces() = %v, want %v", names, want)
+	}
+
+	diags := messages(w.Diagnostics(filepath.Join(dir, "broken.apex")))
+	if len(diags) != 1 || !strings.Contains(diags[0], `"Missing"`) {
+		t.Errorf("Diagnostics(broken.apex) = %q", diags)
+	}
+	if diags := w.Diagnostics(filepath.Join(dir, "orders.apex")); len(diags) != 0 {
+		t.Errorf("Diagnostics(orders.apex) = %q, want none", messages(diags))
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	w, dir := load(t, map[string]string{
+		"syntax.apex":  `type {`,
+		"missing.apex": `import * from "./nowhere.apex"`,
+		"imports.apex": `import * from "./syntax.apex"`,
+		"cycle/a.apex": `import * from "./b.apex"`,
+		"cycle/b.apex": `import * from "./a.apex"`,
+		"valid.apex":   `namespace "valid"`,
+	})
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"syntax.apex", "Syntax Error"},
+		{"missing.apex", "nowhere.apex"},
+		{"imports.apex", "imported specification " + filepath.Join(dir, "syntax.apex") + " has errors"},
+		{"cycle/a.apex", "Import cycle: " + filepath.Join(dir, "cycle", "a.apex") + " imports " + filepath.Join(dir, "cycle", "b.apex") + " imports " + filepath.Join(dir, "cycle", "a.apex")},
+		{"cycle/b.apex", "Import cycle: " + filepath.Join(dir, "cycle", "b.apex") + " imports " + filepath.Join(dir, "cycle", "a.apex") + " imports " + filepath.Join(dir, "cycle", "b.apex")},
+		{"valid.apex", ""},
+	}
+	for _, tt := range tests {
+		diags := messages(w.Diagnostics(filepath.Join(dir, filepath.FromSlash(tt.name))))
+		if tt.want == "" {
+			if len(diags) != 0 {
+				t.Errorf("Diagnostics(%s) = %q, want none", tt.name, diags)
+			}
+			continue
+		}
+		if len(diags) != 1 || !strings.Contains(diags[0], tt.want) {
+			t.Errorf("Diagnostics(%s) = %q, want %q", tt.name, diags, tt.want)
+		}
+	}
+}
+
+func TestInvalidation(t *testing.T) {
+	w, dir := load(t, specs)
+	path := func(name string) string { return filepath.Join(dir, name) }
+	main := w.Document(path("main.apex"))
+	types := w.Document(path("types.apex"))
+	if main == nil || types == nil {
+		t.Fatal("specifications do not parse")
+	}
+
+	// Unchanged content keeps the cached results.
+	if got := w.Changed(path("names.apex")); got != nil {
+		t.Errorf("Changed with the same content = %v, want nil", got)
+	}
+	if w.Document(path("main.apex")) != main {
+		t.Error("unchanged document was parsed again")
+	}
+
+	write(t, path("names.apex"), "alias Name = string\nalias Other = string\n")
+	want := []string{"names.apex", "main.apex", "types.apex"}
+	if got := rel(t, dir, w.Changed(path("names.apex"))); !reflect.DeepEqual(got, want) {
+		t.Errorf("Changed(names.apex) = %v, want %v", got, want)
+	}
+	if w.Document(path("main.apex")) == main {
+		t.Error("document importing a changed file was not parsed again")
+	}
+
+	// Open replaces the file on disk until Close.
+	main = w.Document(path("main.apex"))
+	want = []string{"types.apex", "main.apex"}
+	if got := rel(t, dir, w.Open(path("types.apex"), []byte("type {"))); !reflect.DeepEqual(got, want) {
+		t.Errorf("Open(types.apex) = %v, want %v", got, want)
+	}
+	if w.Document(path("types.apex")) != nil {
+		t.Error("opened content was not used")
+	}
+	if diags := w.Diagnostics(path("main.apex")); len(diags) != 1 {
+		t.Errorf("Diagnostics(main.apex) = %q, want one", messages(diags))
+	}
+	write(t, path("types.apex"), specs["types.apex"]+"\ntype Other {\n  name: Name\n}\n")
+	if got := w.Changed(path("types.apex")); got != nil {
+		t.Errorf("Changed of an open file = %v, want nil", got)
+	}
+	if got := rel(t, dir, w.Close(path("types.apex"))); !reflect.DeepEqual(got, want) {
+		t.Errorf("Close(types.apex) = %v, want %v", got, want)
+	}
+	if doc := w.Document(path("types.apex")); doc == nil || !strings.Contains(string(doc.Loc.Source.Body), "Other") {
+		t.Error("closed file was not read from disk")
+	}
+
+	want = []string{"names.apex", "types.apex"}
+	if got := rel(t, dir, w.Remove(path("names.apex"))); !reflect.DeepEqual(got, want) {
+		t.Errorf("Remove(names.apex) = %v, want %v", got, want)
+	}
+	if got := w.Remove(path("names.apex")); got != nil {
+		t.Errorf("second Remove = %v, want nil", got)
+	}
+}
+
+func TestUnresolvedImport(t *testing.T) {
+	w, dir := load(t, map[string]string{
+		"main.apex": `namespace "main"
+import * from "./types.apex"`,
+	})
+	main := filepath.Join(dir, "main.apex")
+	if diags := w.Diagnostics(main); len(diags) != 1 {
+		t.Fatalf("Diagnostics(main.apex) = %q, want one", messages(diags))
+	}
+
+	// Adding the missing file fixes the importer.
+	write(t, filepath.Join(dir, "types.apex"), `type Item { name: string }`)
+	if err := w.Load(filepath.Join(dir, "types.apex")); err != nil {
+		t.Fatal(err)
+	}
+	if diags := w.Diagnostics(main); len(diags) != 0 {
+		t.Errorf("Diagnostics(main.apex) = %q, want none", messages(diags))
+	}
+}
+
+func TestImportedErrors(t *testing.T) {
+	dir := tree(t, map[string]string{
+		"main.apex": `namespace "main"
+import * from "./common.apex"
+
+type Item {
+  id: ID
+}
+`,
+		"common.apex": `alias ID = string`,
+	})
+	// Only main.apex is loaded; common.apex is read as an import.
+	w := workspace.New()
+	main := filepath.Join(dir, "main.apex")
+	if err := w.Load(main); err != nil {
+		t.Fatal(err)
+	}
+	want := "imported specification " + filepath.Join(dir, "common.apex") + " has errors"
+	if diags := messages(w.Diagnostics(main)); len(diags) == 0 || !strings.Contains(diags[0], want) {
+		t.Errorf("Diagnostics(main.apex) = %q, want %q", diags, want)
+	}
+	if ns := w.Namespaces(); len(ns) != 0 {
+		t.Errorf("Namespaces() = %v, want none", ns)
+	}
+}
+
+func TestRoots(t *testing.T) {
+	dir := tree(t, map[string]string{
+		"lib/common/types.apex": `type Item { name: string }`,
+		"specs/main.apex": `namespace "main"
+import { Item } from "common/types.apex"
+
+type Order { item: Item }
+`,
+	})
+	lib := filepath.Join(dir, "lib")
+	w := workspace.New(lib)
+	if got := w.Roots(); !reflect.DeepEqual(got, []string{lib}) {
+		t.Errorf("Roots() = %v", got)
+	}
+	main := filepath.Join(dir, "specs", "main.apex")
+	if err := w.Load(main); err != nil {
+		t.Fatal(err)
+	}
+	if diags := w.Diagnostics(main); len(diags) != 0 {
+		t.Errorf("Diagnostics(main.apex) = %q, want none", messages(diags))
+	}
+	want := []string{"lib/common/types.apex", "specs/main.apex"}
+	if got := rel(t, dir, w.Files()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Files() = %v, want %v", got, want)
+	}
+}